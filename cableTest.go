@@ -0,0 +1,387 @@
+package ethtool
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"strings"
+	"time"
+)
+
+const (
+	// Start a cable test
+	cableTestActCommand = 0x1a
+	// Start a TDR (time domain reflectometry) cable test
+	cableTestTDRActCommand = 0x1b
+	// Cable test notification
+	cableTestNotification = 0x1b
+	// TDR cable test notification
+	cableTestTDRNotification = 0x1c
+)
+
+/* ETHTOOL_A_CABLE_TEST_* and ETHTOOL_A_CABLE_TEST_TDR_* */
+const (
+	cableTestAttrHeader    = 0x01
+	cableTestTDRAttrHeader = 0x01
+	cableTestTDRAttrConfig = 0x02
+
+	cableTestTDRConfigAttrFirst = 0x01
+	cableTestTDRConfigAttrLast  = 0x02
+	cableTestTDRConfigAttrStep  = 0x03
+	cableTestTDRConfigAttrPair  = 0x04
+)
+
+/* ETHTOOL_A_CABLE_TEST_NTF_*, shared by cable test and TDR notifications */
+const (
+	cableTestNotificationAttrHeader = 0x01
+	cableTestNotificationAttrStatus = 0x02
+	cableTestNotificationAttrNest   = 0x03
+
+	cableTestNotificationStatusStarted   = 0x01
+	cableTestNotificationStatusCompleted = 0x02
+)
+
+/* Nested cable test results (ETHTOOL_A_CABLE_NEST_*, ETHTOOL_A_CABLE_RESULT_*, ETHTOOL_A_CABLE_FAULT_LENGTH_*) */
+const (
+	cableNestAttrResult      = 0x01
+	cableNestAttrFaultLength = 0x02
+
+	cableResultAttrPair = 0x01
+	cableResultAttrCode = 0x02
+
+	cableFaultLengthAttrPair = 0x01
+	cableFaultLengthAttrCm   = 0x02
+)
+
+/* Nested TDR results (ETHTOOL_A_CABLE_TDR_NEST_*, ETHTOOL_A_CABLE_AMPLITUDE_*, ETHTOOL_A_CABLE_PULSE_*, ETHTOOL_A_CABLE_STEP_*) */
+const (
+	cableTDRNestAttrStep      = 0x01
+	cableTDRNestAttrAmplitude = 0x02
+	cableTDRNestAttrPulse     = 0x03
+
+	cableAmplitudeAttrPair = 0x01
+	cableAmplitudeAttrMv   = 0x02
+
+	cablePulseAttrMv = 0x01
+
+	cableStepAttrFirstDistance = 0x01
+	cableStepAttrLastDistance  = 0x02
+	cableStepAttrStepDistance  = 0x03
+)
+
+// CablePair a twisted pair of a copper cable
+type CablePair uint8
+
+const (
+	// CablePairA pair A
+	CablePairA CablePair = 0x00
+	// CablePairB pair B
+	CablePairB CablePair = 0x01
+	// CablePairC pair C
+	CablePairC CablePair = 0x02
+	// CablePairD pair D
+	CablePairD CablePair = 0x03
+)
+
+func (c CablePair) String() string {
+	str, found := map[CablePair]string{
+		CablePairA: "A",
+		CablePairB: "B",
+		CablePairC: "C",
+		CablePairD: "D",
+	}[c]
+	if found {
+		return str
+	}
+	return fmt.Sprintf("Unknown (%d)", uint8(c))
+}
+
+// MarshalJSON implements the encoding/json/Marshaler interface's MarshalJSON function
+func (c CablePair) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+// CableTestResultCode outcome of a cable test for a single pair
+type CableTestResultCode uint8
+
+const (
+	// CableTestResultUnspecified the PHY could not determine the state of the pair
+	CableTestResultUnspecified CableTestResultCode = 0x00
+	// CableTestResultOK pair is fine
+	CableTestResultOK CableTestResultCode = 0x01
+	// CableTestResultOpen pair is open
+	CableTestResultOpen CableTestResultCode = 0x02
+	// CableTestResultSameShort pair is shorted within itself
+	CableTestResultSameShort CableTestResultCode = 0x03
+	// CableTestResultCrossShort pair is shorted to another pair
+	CableTestResultCrossShort CableTestResultCode = 0x04
+)
+
+func (c CableTestResultCode) String() string {
+	str, found := map[CableTestResultCode]string{
+		CableTestResultUnspecified: "Unspecified",
+		CableTestResultOK:          "OK",
+		CableTestResultOpen:        "Open circuit",
+		CableTestResultSameShort:   "Short within pair",
+		CableTestResultCrossShort:  "Short to another pair",
+	}[c]
+	if found {
+		return str
+	}
+	return fmt.Sprintf("Unknown (%d)", uint8(c))
+}
+
+// MarshalJSON implements the encoding/json/Marshaler interface's MarshalJSON function
+func (c CableTestResultCode) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+// CableTestPairResult result of a cable test for a single pair
+type CableTestPairResult struct {
+	Pair CablePair
+	Code CableTestResultCode
+	// Distance to the fault in centimeters, only valid if FaultLengthReported is set
+	FaultLength         uint32
+	FaultLengthReported bool
+}
+
+// CableTestResult the return type of the PerformCableTest function
+type CableTestResult struct {
+	Pairs []CableTestPairResult
+}
+
+// CableTestTDRConfig optional parameters of a TDR cable test, distances are given in centimeters.
+// Nil fields are left to the PHY driver's defaults.
+type CableTestTDRConfig struct {
+	First *uint32
+	Last  *uint32
+	Step  *uint32
+	Pair  *CablePair
+}
+
+// CableTestTDRStep distances the following amplitude samples were taken at, in centimeters
+type CableTestTDRStep struct {
+	FirstDistance uint32
+	LastDistance  uint32
+	StepDistance  uint32
+}
+
+// CableTestTDRSample a reflected amplitude sampled on a pair at the given distance (in centimeters)
+type CableTestTDRSample struct {
+	Pair       CablePair
+	Distance   uint32
+	Millivolts int16
+}
+
+// CableTestTDRResult the return type of the PerformCableTestTDR function
+type CableTestTDRResult struct {
+	// Amplitude of the pulse sent into the cable in millivolts
+	PulseMillivolts int16
+	Steps           []CableTestTDRStep
+	Samples         []CableTestTDRSample
+}
+
+// PerformCableTest runs a cable test on the interface's PHY and waits up to timeout for its results.
+// Note that the link goes down while the test is running.
+func (i *Interface) PerformCableTest(timeout time.Duration) (*CableTestResult, error) {
+	attrs := &netlinkAttributeEncoder{}
+	i.encodeRequestHeader(attrs, cableTestAttrHeader, 0)
+
+	nest, err := i.runCableTest(cableTestActCommand, cableTestNotification, attrs, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return newCableTestResult(nest)
+}
+
+// PerformCableTestTDR runs a TDR cable test on the interface's PHY and waits up to timeout for the raw amplitude samples.
+// config may be nil to use the driver's defaults. Note that the link goes down while the test is running.
+func (i *Interface) PerformCableTestTDR(config *CableTestTDRConfig, timeout time.Duration) (*CableTestTDRResult, error) {
+	attrs := &netlinkAttributeEncoder{}
+	i.encodeRequestHeader(attrs, cableTestTDRAttrHeader, 0)
+	if config != nil {
+		attrs.nested(cableTestTDRAttrConfig, func(cfg *netlinkAttributeEncoder) {
+			if config.First != nil {
+				cfg.uint32(cableTestTDRConfigAttrFirst, *config.First)
+			}
+			if config.Last != nil {
+				cfg.uint32(cableTestTDRConfigAttrLast, *config.Last)
+			}
+			if config.Step != nil {
+				cfg.uint32(cableTestTDRConfigAttrStep, *config.Step)
+			}
+			if config.Pair != nil {
+				cfg.uint8(cableTestTDRConfigAttrPair, uint8(*config.Pair))
+			}
+		})
+	}
+
+	nest, err := i.runCableTest(cableTestTDRActCommand, cableTestTDRNotification, attrs, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return newCableTestTDRResult(nest)
+}
+
+// runCableTest starts a cable test and returns the result nest of the completion notification
+func (i *Interface) runCableTest(command uint8, notification uint8, attrs *netlinkAttributeEncoder, timeout time.Duration) (netlinkAttributes, error) {
+	// results are only sent as notifications, so subscribe before starting the test
+	monitor, err := newNetlinkSocket()
+	if err != nil {
+		return nil, err
+	}
+	defer monitor.close()
+	if err := monitor.joinGroup(ethtoolMcgrpMonitorName); err != nil {
+		return nil, err
+	}
+
+	if _, err := i.netlinkRequest(command, attrs); err != nil {
+		return nil, errors.Wrapf(err, "Could not start cable test on interface %s", i.Name)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, fmt.Errorf("Cable test on interface %s did not complete within %s", i.Name, timeout)
+		}
+		if err := monitor.setReceiveTimeout(remaining); err != nil {
+			return nil, errors.Wrapf(err, "Could not set netlink receive timeout")
+		}
+		messages, err := monitor.receive()
+		if err != nil {
+			return nil, errors.Wrapf(err, "Waiting for cable test results of interface %s failed", i.Name)
+		}
+		for _, message := range messages {
+			if message.Command != notification || !i.isNotificationFor(message) {
+				continue
+			}
+			status, found := message.Attributes.get(cableTestNotificationAttrStatus)
+			if !found || status.uint8() != cableTestNotificationStatusCompleted {
+				continue
+			}
+			nest, found := message.Attributes.get(cableTestNotificationAttrNest)
+			if !found {
+				return netlinkAttributes{}, nil
+			}
+			return nest.nested()
+		}
+	}
+}
+
+// isNotificationFor checks whether a notification's header refers to this interface
+func (i *Interface) isNotificationFor(message genetlinkMessage) bool {
+	header, found := message.Attributes.get(cableTestNotificationAttrHeader)
+	if !found {
+		return false
+	}
+	headerAttrs, err := header.nested()
+	if err != nil {
+		return false
+	}
+	name, found := headerAttrs.get(headerAttrDevName)
+	return found && name.string() == i.Name
+}
+
+func newCableTestResult(nest netlinkAttributes) (*CableTestResult, error) {
+	result := &CableTestResult{
+		Pairs: []CableTestPairResult{},
+	}
+	pairIndex := map[CablePair]int{}
+	getPair := func(pair CablePair) *CableTestPairResult {
+		index, found := pairIndex[pair]
+		if !found {
+			index = len(result.Pairs)
+			pairIndex[pair] = index
+			result.Pairs = append(result.Pairs, CableTestPairResult{Pair: pair})
+		}
+		return &result.Pairs[index]
+	}
+
+	for _, attr := range nest {
+		entry, err := attr.nested()
+		if err != nil {
+			return nil, errors.Wrapf(err, "Could not parse cable test results")
+		}
+		switch attr.Type {
+		case cableNestAttrResult:
+			pair, pairFound := entry.get(cableResultAttrPair)
+			code, codeFound := entry.get(cableResultAttrCode)
+			if pairFound && codeFound {
+				getPair(CablePair(pair.uint8())).Code = CableTestResultCode(code.uint8())
+			}
+		case cableNestAttrFaultLength:
+			pair, pairFound := entry.get(cableFaultLengthAttrPair)
+			length, lengthFound := entry.get(cableFaultLengthAttrCm)
+			if pairFound && lengthFound {
+				p := getPair(CablePair(pair.uint8()))
+				p.FaultLength = length.uint32()
+				p.FaultLengthReported = true
+			}
+		}
+	}
+	return result, nil
+}
+
+func newCableTestTDRResult(nest netlinkAttributes) (*CableTestTDRResult, error) {
+	result := &CableTestTDRResult{
+		Steps:   []CableTestTDRStep{},
+		Samples: []CableTestTDRSample{},
+	}
+	// samples of each pair are reported in order of increasing distance, starting at the latest step's first distance
+	step := CableTestTDRStep{}
+	sampleCount := map[CablePair]uint32{}
+
+	for _, attr := range nest {
+		entry, err := attr.nested()
+		if err != nil {
+			return nil, errors.Wrapf(err, "Could not parse TDR cable test results")
+		}
+		switch attr.Type {
+		case cableTDRNestAttrStep:
+			step = CableTestTDRStep{}
+			if first, found := entry.get(cableStepAttrFirstDistance); found {
+				step.FirstDistance = first.uint32()
+			}
+			if last, found := entry.get(cableStepAttrLastDistance); found {
+				step.LastDistance = last.uint32()
+			}
+			if stepDistance, found := entry.get(cableStepAttrStepDistance); found {
+				step.StepDistance = stepDistance.uint32()
+			}
+			result.Steps = append(result.Steps, step)
+			sampleCount = map[CablePair]uint32{}
+		case cableTDRNestAttrAmplitude:
+			pair, pairFound := entry.get(cableAmplitudeAttrPair)
+			mv, mvFound := entry.get(cableAmplitudeAttrMv)
+			if !pairFound || !mvFound {
+				continue
+			}
+			p := CablePair(pair.uint8())
+			result.Samples = append(result.Samples, CableTestTDRSample{
+				Pair:       p,
+				Distance:   step.FirstDistance + sampleCount[p]*step.StepDistance,
+				Millivolts: int16(mv.uint16()),
+			})
+			sampleCount[p]++
+		case cableTDRNestAttrPulse:
+			if mv, found := entry.get(cablePulseAttrMv); found {
+				result.PulseMillivolts = int16(mv.uint16())
+			}
+		}
+	}
+	return result, nil
+}
+
+func (c *CableTestResult) String() string {
+	builder := strings.Builder{}
+	fmt.Fprintf(&builder, "Cable test results:\n")
+	for _, pair := range c.Pairs {
+		fmt.Fprintf(&builder, " * Pair %s: %s", pair.Pair, pair.Code)
+		if pair.FaultLengthReported {
+			fmt.Fprintf(&builder, ", fault length %.2f m", float64(pair.FaultLength)/100)
+		}
+		fmt.Fprintf(&builder, "\n")
+	}
+	return builder.String()
+}
@@ -0,0 +1,280 @@
+package ethtool
+
+import (
+	"encoding/binary"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/wobcom/go-ethtool/eeprom/cmis"
+	"time"
+)
+
+const (
+	// Interval between polls of the CDB status while the module is busy
+	cdbPollInterval = 20 * time.Millisecond
+	// Timeout of commands without an advertised maximum duration
+	cdbDefaultTimeout = time.Second
+	// Length of the block address preceding the data of firmware write commands
+	cdbBlockAddressLength = 4
+	// Reply length and reply checksum within page 9Fh (bytes 134-135)
+	cdbReplyHeaderOffset = 0x86
+	// Local reply payload within page 9Fh (byte 136)
+	cdbReplyOffset = 0x88
+)
+
+// CDBQueryStatus reply of the CDB Query Status command
+type CDBQueryStatus struct {
+	// The host password has been accepted, i.e. password protected commands are available
+	Unlocked bool
+}
+
+// FirmwareUpgradeOptions controls UpgradeFirmware, the zero value only downloads the image
+type FirmwareUpgradeOptions struct {
+	// Timeout per CDB command, defaults to the maximum durations advertised by the module
+	Timeout time.Duration
+	// Use local payload writes even if extended payload writes are supported
+	ForceLPL bool
+	// Run the new image after the download completed
+	Run     bool
+	RunMode cmis.FirmwareRunMode
+	// Commit the new image after running it, i.e. boot it by default
+	Commit bool
+	// Called after each written block with the number of image bytes written so far
+	Progress func(written int, total int)
+}
+
+// CDBCommand sends a command to a CMIS module's command data block (CDB) instance 1 and returns its local reply payload.
+// The extended payload epl may be nil. Commands are written through the ModuleEEPROMWriter,
+// the status and replies are read through the kernel. Read errors while polling are tolerated until the timeout,
+// as some commands reset the module.
+func (i *Interface) CDBCommand(command uint16, lpl []byte, epl []byte, timeout time.Duration) ([]byte, error) {
+	request, err := cmis.NewCDBRequest(command, lpl, len(epl))
+	if err != nil {
+		return nil, err
+	}
+	if len(epl) > 0 {
+		if err := i.writeModuleEEPROM(cmis.CDBEPLOffset, epl); err != nil {
+			return nil, errors.Wrapf(err, "Could not write CDB extended payload")
+		}
+	}
+	// writing the command ID triggers the command, so it is written last
+	if err := i.writeModuleEEPROM(cmis.CDBCommandOffset+2, request[2:]); err != nil {
+		return nil, errors.Wrapf(err, "Could not write CDB command %#04x", command)
+	}
+	if err := i.writeModuleEEPROM(cmis.CDBCommandOffset, request[:2]); err != nil {
+		return nil, errors.Wrapf(err, "Could not write CDB command %#04x", command)
+	}
+
+	status, err := i.waitForCDB(timeout)
+	if err != nil {
+		return nil, errors.Wrapf(err, "CDB command %#04x did not complete", command)
+	}
+	if status != cmis.CDBStatusSuccess {
+		return nil, fmt.Errorf("CDB command %#04x failed: %s", command, status)
+	}
+	return i.readCDBReply()
+}
+
+// waitForCDB polls the CDB status until the module is no longer busy
+func (i *Interface) waitForCDB(timeout time.Duration) (cmis.CDBStatus, error) {
+	deadline := time.Now().Add(timeout)
+	var lastErr error
+	for {
+		time.Sleep(cdbPollInterval)
+		status, err := i.ReadModuleEEPROMPage(0x00, 0, cmis.CDBStatusOffset, 1)
+		if err == nil && len(status) == 1 && !cmis.CDBStatus(status[0]).IsBusy() {
+			return cmis.CDBStatus(status[0]), nil
+		}
+		if err != nil {
+			lastErr = err
+		}
+		if time.Now().After(deadline) {
+			if lastErr != nil {
+				return 0, errors.Wrapf(lastErr, "Timeout after %s", timeout)
+			}
+			return 0, fmt.Errorf("Timeout after %s, module still busy", timeout)
+		}
+	}
+}
+
+// readCDBReply reads and verifies the local reply payload of the last CDB command
+func (i *Interface) readCDBReply() ([]byte, error) {
+	header, err := i.ReadModuleEEPROMPage(cmis.CDBPage, 0, cdbReplyHeaderOffset, 2)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Could not read CDB reply header")
+	}
+	if len(header) != 2 {
+		return nil, fmt.Errorf("CDB reply header of %d bytes read, expected 2", len(header))
+	}
+	length := int(header[0])
+	if length == 0 {
+		return []byte{}, nil
+	}
+	if length > cmis.CDBMaxLPLLength {
+		return nil, fmt.Errorf("CDB reply length %d exceeds maximum of %d bytes", length, cmis.CDBMaxLPLLength)
+	}
+	reply, err := i.ReadModuleEEPROMPage(cmis.CDBPage, 0, cdbReplyOffset, uint32(length))
+	if err != nil {
+		return nil, errors.Wrapf(err, "Could not read CDB reply")
+	}
+	if len(reply) != length {
+		return nil, fmt.Errorf("CDB reply of %d bytes read, expected %d", len(reply), length)
+	}
+	if checksum := cmis.CDBChecksum(reply); checksum != header[1] {
+		return nil, fmt.Errorf("CDB reply checksum mismatch, got %#02x, expected %#02x", header[1], checksum)
+	}
+	return reply, nil
+}
+
+// getCDBModuleEEPROM reads and parses the module EEPROM, failing for modules not implementing CDB
+func (i *Interface) getCDBModuleEEPROM() (*cmis.EEPROM, error) {
+	raw, _, err := i.ReadModuleEEPROM()
+	if err != nil {
+		return nil, err
+	}
+	e, err := cmis.NewEEPROM(i.readUpperPages(raw, []uint8{0x01}))
+	if err != nil {
+		return nil, err
+	}
+	if !e.Identifier.UsesCMIS() || e.Advertising == nil || e.Advertising.CDBInstances == 0 {
+		return nil, fmt.Errorf("Module of interface %s does not implement CDB", i.Name)
+	}
+	return e, nil
+}
+
+// QueryCDBStatus sends the CDB Query Status command (0000h)
+func (i *Interface) QueryCDBStatus(timeout time.Duration) (*CDBQueryStatus, error) {
+	if _, err := i.getCDBModuleEEPROM(); err != nil {
+		return nil, err
+	}
+	// response delay of 0 ms
+	reply, err := i.CDBCommand(cmis.CDBCommandQueryStatus, []byte{0x00, 0x00}, nil, timeout)
+	if err != nil {
+		return nil, err
+	}
+	if len(reply) < 2 {
+		return nil, fmt.Errorf("Query status reply of %d bytes is too short", len(reply))
+	}
+	return &CDBQueryStatus{
+		Unlocked: reply[1]&0x01 > 0,
+	}, nil
+}
+
+// GetFirmwareManagementFeatures retrieves the module's firmware upgrade capabilities (CDB command 0041h)
+func (i *Interface) GetFirmwareManagementFeatures(timeout time.Duration) (*cmis.FirmwareManagementFeatures, error) {
+	if _, err := i.getCDBModuleEEPROM(); err != nil {
+		return nil, err
+	}
+	reply, err := i.CDBCommand(cmis.CDBCommandFirmwareManagementFeatures, nil, nil, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return cmis.NewFirmwareManagementFeatures(reply)
+}
+
+// GetFirmwareInfo retrieves information about the module's firmware images (CDB command 0100h)
+func (i *Interface) GetFirmwareInfo(timeout time.Duration) (*cmis.FirmwareInfo, error) {
+	if _, err := i.getCDBModuleEEPROM(); err != nil {
+		return nil, err
+	}
+	reply, err := i.CDBCommand(cmis.CDBCommandGetFirmwareInfo, nil, nil, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return cmis.NewFirmwareInfo(reply)
+}
+
+// UpgradeFirmware downloads a firmware image to the module's inactive image slot using the
+// Start (0101h), Write (0103h / 0104h) and Complete (0107h) CDB commands, optionally followed by
+// Run (0109h) and Commit (010Ah). The download is aborted if a write fails.
+func (i *Interface) UpgradeFirmware(image []byte, options *FirmwareUpgradeOptions) error {
+	if options == nil {
+		options = &FirmwareUpgradeOptions{}
+	}
+	timeoutOr := func(advertised uint16) time.Duration {
+		if options.Timeout > 0 {
+			return options.Timeout
+		} else if advertised == 0 {
+			return cdbDefaultTimeout
+		}
+		return time.Duration(advertised) * time.Millisecond
+	}
+
+	features, err := i.GetFirmwareManagementFeatures(timeoutOr(0))
+	if err != nil {
+		return errors.Wrapf(err, "Could not retrieve firmware management features")
+	}
+	useEPL := features.EPLWriteSupported && !options.ForceLPL
+	if !useEPL && !features.LPLWriteSupported {
+		return fmt.Errorf("Module of interface %s supports neither LPL nor EPL firmware writes", i.Name)
+	}
+	if len(image) <= features.StartPayloadSize {
+		return fmt.Errorf("Firmware image of %d bytes is too short", len(image))
+	}
+
+	start := make([]byte, 8+features.StartPayloadSize)
+	binary.BigEndian.PutUint32(start[0:4], uint32(len(image)))
+	copy(start[8:], image[:features.StartPayloadSize])
+	if _, err := i.CDBCommand(cmis.CDBCommandStartFirmwareDownload, start, nil, timeoutOr(features.MaxStartDuration)); err != nil {
+		return errors.Wrapf(err, "Could not start firmware download")
+	}
+
+	blockLength := features.MaxWriteLength
+	if useEPL && blockLength > cmis.CDBMaxEPLLength {
+		blockLength = cmis.CDBMaxEPLLength
+	} else if !useEPL && blockLength > cmis.CDBMaxLPLLength-cdbBlockAddressLength {
+		blockLength = cmis.CDBMaxLPLLength - cdbBlockAddressLength
+	}
+	for offset := features.StartPayloadSize; offset < len(image); offset += blockLength {
+		end := offset + blockLength
+		if end > len(image) {
+			end = len(image)
+		}
+		address := make([]byte, cdbBlockAddressLength)
+		binary.BigEndian.PutUint32(address, uint32(offset-features.StartPayloadSize))
+		if useEPL {
+			_, err = i.CDBCommand(cmis.CDBCommandWriteFirmwareBlockEPL, address, image[offset:end], timeoutOr(features.MaxWriteDuration))
+		} else {
+			_, err = i.CDBCommand(cmis.CDBCommandWriteFirmwareBlockLPL, append(address, image[offset:end]...), nil, timeoutOr(features.MaxWriteDuration))
+		}
+		if err != nil {
+			// best effort, the original error is more relevant
+			i.CDBCommand(cmis.CDBCommandAbortFirmwareDownload, nil, nil, timeoutOr(features.MaxStartDuration))
+			return errors.Wrapf(err, "Could not write firmware block at offset %d", offset)
+		}
+		if options.Progress != nil {
+			options.Progress(end, len(image))
+		}
+	}
+
+	if _, err := i.CDBCommand(cmis.CDBCommandCompleteFirmwareDownload, nil, nil, timeoutOr(features.MaxCompleteDuration)); err != nil {
+		return errors.Wrapf(err, "Could not complete firmware download")
+	}
+
+	if options.Run {
+		if err := i.RunFirmwareImage(options.RunMode, 0, timeoutOr(features.MaxCompleteDuration)); err != nil {
+			return err
+		}
+		if options.Commit {
+			return i.CommitFirmwareImage(timeoutOr(features.MaxCompleteDuration))
+		}
+	}
+	return nil
+}
+
+// RunFirmwareImage resets the module to run a firmware image (CDB command 0109h), delay in milliseconds
+func (i *Interface) RunFirmwareImage(mode cmis.FirmwareRunMode, delay uint16, timeout time.Duration) error {
+	lpl := []byte{0x00, byte(mode), 0x00, 0x00}
+	binary.BigEndian.PutUint16(lpl[2:4], delay)
+	if _, err := i.CDBCommand(cmis.CDBCommandRunFirmwareImage, lpl, nil, timeout); err != nil {
+		return errors.Wrapf(err, "Could not run firmware image (%s)", mode)
+	}
+	return nil
+}
+
+// CommitFirmwareImage makes the running firmware image the one booted by default (CDB command 010Ah)
+func (i *Interface) CommitFirmwareImage(timeout time.Duration) error {
+	if _, err := i.CDBCommand(cmis.CDBCommandCommitFirmwareImage, nil, nil, timeout); err != nil {
+		return errors.Wrapf(err, "Could not commit firmware image")
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+package ethtool
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"sort"
+	"unsafe"
+)
+
+const (
+	// Get coalesce config
+	getCoalesceIoctl = 0x0000000e
+	// Set coalesce config
+	setCoalesceIoctl = 0x0000000f
+	// Apply a sub command to a set of queues
+	perQueueIoctl = 0x0000004b
+
+	// Maximum number of queues addressable by perQueueIoctl
+	maxNumQueues = 4096
+)
+
+type ethtoolCoalesce struct {
+	cmd                      uint32
+	rxCoalesceUsecs          uint32
+	rxMaxCoalescedFrames     uint32
+	rxCoalesceUsecsIrq       uint32
+	rxMaxCoalescedFramesIrq  uint32
+	txCoalesceUsecs          uint32
+	txMaxCoalescedFrames     uint32
+	txCoalesceUsecsIrq       uint32
+	txMaxCoalescedFramesIrq  uint32
+	statsBlockCoalesceUsecs  uint32
+	useAdaptiveRxCoalesce    uint32
+	useAdaptiveTxCoalesce    uint32
+	pktRateLow               uint32
+	rxCoalesceUsecsLow       uint32
+	rxMaxCoalescedFramesLow  uint32
+	txCoalesceUsecsLow       uint32
+	txMaxCoalescedFramesLow  uint32
+	pktRateHigh              uint32
+	rxCoalesceUsecsHigh      uint32
+	rxMaxCoalescedFramesHigh uint32
+	txCoalesceUsecsHigh      uint32
+	txMaxCoalescedFramesHigh uint32
+	rateSampleInterval       uint32
+}
+
+// ethtoolPerQueueOp header of struct ethtool_per_queue_op, followed by one sub command struct per selected queue
+type ethtoolPerQueueOp struct {
+	cmd        uint32
+	subCommand uint32
+	queueMask  [maxNumQueues / 32]uint32
+}
+
+// Coalesce interrupt coalescing parameters, see struct ethtool_coalesce in the kernel's uapi/linux/ethtool.h.
+// Drivers usually implement only a subset of these parameters.
+type Coalesce struct {
+	// How many usecs to delay an RX interrupt after a packet arrives
+	RxUsecs uint32
+	// Maximum number of packets to receive before an RX interrupt
+	RxMaxFrames uint32
+	// Same as RxUsecs, except that this value applies while an IRQ is being serviced by the host
+	RxUsecsIrq uint32
+	// Same as RxMaxFrames, except that this value applies while an IRQ is being serviced by the host
+	RxMaxFramesIrq uint32
+	// How many usecs to delay a TX interrupt after a packet is sent
+	TxUsecs uint32
+	// Maximum number of packets to be sent before a TX interrupt
+	TxMaxFrames uint32
+	// Same as TxUsecs, except that this value applies while an IRQ is being serviced by the host
+	TxUsecsIrq uint32
+	// Same as TxMaxFrames, except that this value applies while an IRQ is being serviced by the host
+	TxMaxFramesIrq uint32
+	// How many usecs to delay in-memory statistics block updates
+	StatsBlockUsecs uint32
+	// Enable adaptive RX coalescing
+	UseAdaptiveRx bool
+	// Enable adaptive TX coalescing
+	UseAdaptiveTx bool
+	// Threshold for low packet rate (packets per second)
+	PktRateLow uint32
+	// RxUsecs when packet rate is below PktRateLow
+	RxUsecsLow uint32
+	// RxMaxFrames when packet rate is below PktRateLow
+	RxMaxFramesLow uint32
+	// TxUsecs when packet rate is below PktRateLow
+	TxUsecsLow uint32
+	// TxMaxFrames when packet rate is below PktRateLow
+	TxMaxFramesLow uint32
+	// Threshold for high packet rate (packets per second)
+	PktRateHigh uint32
+	// RxUsecs when packet rate is above PktRateHigh
+	RxUsecsHigh uint32
+	// RxMaxFrames when packet rate is above PktRateHigh
+	RxMaxFramesHigh uint32
+	// TxUsecs when packet rate is above PktRateHigh
+	TxUsecsHigh uint32
+	// TxMaxFrames when packet rate is above PktRateHigh
+	TxMaxFramesHigh uint32
+	// How often to do adaptive coalescing packet rate sampling, measured in seconds
+	RateSampleInterval uint32
+}
+
+func newCoalesce(c *ethtoolCoalesce) *Coalesce {
+	return &Coalesce{
+		RxUsecs:            c.rxCoalesceUsecs,
+		RxMaxFrames:        c.rxMaxCoalescedFrames,
+		RxUsecsIrq:         c.rxCoalesceUsecsIrq,
+		RxMaxFramesIrq:     c.rxMaxCoalescedFramesIrq,
+		TxUsecs:            c.txCoalesceUsecs,
+		TxMaxFrames:        c.txMaxCoalescedFrames,
+		TxUsecsIrq:         c.txCoalesceUsecsIrq,
+		TxMaxFramesIrq:     c.txMaxCoalescedFramesIrq,
+		StatsBlockUsecs:    c.statsBlockCoalesceUsecs,
+		UseAdaptiveRx:      c.useAdaptiveRxCoalesce != 0,
+		UseAdaptiveTx:      c.useAdaptiveTxCoalesce != 0,
+		PktRateLow:         c.pktRateLow,
+		RxUsecsLow:         c.rxCoalesceUsecsLow,
+		RxMaxFramesLow:     c.rxMaxCoalescedFramesLow,
+		TxUsecsLow:         c.txCoalesceUsecsLow,
+		TxMaxFramesLow:     c.txMaxCoalescedFramesLow,
+		PktRateHigh:        c.pktRateHigh,
+		RxUsecsHigh:        c.rxCoalesceUsecsHigh,
+		RxMaxFramesHigh:    c.rxMaxCoalescedFramesHigh,
+		TxUsecsHigh:        c.txCoalesceUsecsHigh,
+		TxMaxFramesHigh:    c.txMaxCoalescedFramesHigh,
+		RateSampleInterval: c.rateSampleInterval,
+	}
+}
+
+func (c *Coalesce) toEthtoolCoalesce(cmd uint32) ethtoolCoalesce {
+	boolToUint32 := func(b bool) uint32 {
+		if b {
+			return 1
+		}
+		return 0
+	}
+	return ethtoolCoalesce{
+		cmd:                      cmd,
+		rxCoalesceUsecs:          c.RxUsecs,
+		rxMaxCoalescedFrames:     c.RxMaxFrames,
+		rxCoalesceUsecsIrq:       c.RxUsecsIrq,
+		rxMaxCoalescedFramesIrq:  c.RxMaxFramesIrq,
+		txCoalesceUsecs:          c.TxUsecs,
+		txMaxCoalescedFrames:     c.TxMaxFrames,
+		txCoalesceUsecsIrq:       c.TxUsecsIrq,
+		txMaxCoalescedFramesIrq:  c.TxMaxFramesIrq,
+		statsBlockCoalesceUsecs:  c.StatsBlockUsecs,
+		useAdaptiveRxCoalesce:    boolToUint32(c.UseAdaptiveRx),
+		useAdaptiveTxCoalesce:    boolToUint32(c.UseAdaptiveTx),
+		pktRateLow:               c.PktRateLow,
+		rxCoalesceUsecsLow:       c.RxUsecsLow,
+		rxMaxCoalescedFramesLow:  c.RxMaxFramesLow,
+		txCoalesceUsecsLow:       c.TxUsecsLow,
+		txMaxCoalescedFramesLow:  c.TxMaxFramesLow,
+		pktRateHigh:              c.PktRateHigh,
+		rxCoalesceUsecsHigh:      c.RxUsecsHigh,
+		rxMaxCoalescedFramesHigh: c.RxMaxFramesHigh,
+		txCoalesceUsecsHigh:      c.TxUsecsHigh,
+		txMaxCoalescedFramesHigh: c.TxMaxFramesHigh,
+		rateSampleInterval:       c.RateSampleInterval,
+	}
+}
+
+// GetCoalesce retrieves the interface's interrupt coalescing parameters
+func (i *Interface) GetCoalesce() (*Coalesce, error) {
+	coalesce := ethtoolCoalesce{
+		cmd: getCoalesceIoctl,
+	}
+	if err := i.performIoctl(uintptr(unsafe.Pointer(&coalesce))); err != nil {
+		return nil, errors.Wrapf(err, "Error running ioctl getCoalesceIoctl")
+	}
+	return newCoalesce(&coalesce), nil
+}
+
+// SetCoalesce sets the interface's interrupt coalescing parameters.
+// Retrieve the current parameters using GetCoalesce first, as all parameters are written.
+func (i *Interface) SetCoalesce(c *Coalesce) error {
+	coalesce := c.toEthtoolCoalesce(setCoalesceIoctl)
+	if err := i.performIoctl(uintptr(unsafe.Pointer(&coalesce))); err != nil {
+		return errors.Wrapf(err, "Error running ioctl setCoalesceIoctl")
+	}
+	return nil
+}
+
+// GetQueueCoalesce retrieves the interrupt coalescing parameters of the given queues
+func (i *Interface) GetQueueCoalesce(queues []uint32) (map[uint32]*Coalesce, error) {
+	queues, err := sortQueues(queues)
+	if err != nil {
+		return nil, err
+	}
+	buf, entries := newPerQueueOp(getCoalesceIoctl, queues)
+	for _, entry := range entries {
+		entry.cmd = getCoalesceIoctl
+	}
+
+	if err := i.performIoctl(uintptr(unsafe.Pointer(&buf[0]))); err != nil {
+		return nil, errors.Wrapf(err, "Error running ioctl perQueueIoctl")
+	}
+
+	ret := make(map[uint32]*Coalesce)
+	for index, queue := range queues {
+		ret[queue] = newCoalesce(entries[index])
+	}
+	return ret, nil
+}
+
+// SetQueueCoalesce sets the interrupt coalescing parameters per queue, the map is keyed by queue number.
+// Retrieve the current parameters using GetQueueCoalesce first, as all parameters are written.
+func (i *Interface) SetQueueCoalesce(settings map[uint32]*Coalesce) error {
+	queues := make([]uint32, 0, len(settings))
+	for queue := range settings {
+		queues = append(queues, queue)
+	}
+	queues, err := sortQueues(queues)
+	if err != nil {
+		return err
+	}
+	buf, entries := newPerQueueOp(setCoalesceIoctl, queues)
+	for index, queue := range queues {
+		*entries[index] = settings[queue].toEthtoolCoalesce(setCoalesceIoctl)
+	}
+
+	if err := i.performIoctl(uintptr(unsafe.Pointer(&buf[0]))); err != nil {
+		return errors.Wrapf(err, "Error running ioctl perQueueIoctl")
+	}
+	return nil
+}
+
+// sortQueues sorts the queue numbers in the order the kernel expects per queue data
+func sortQueues(queues []uint32) ([]uint32, error) {
+	sorted := make([]uint32, len(queues))
+	copy(sorted, queues)
+	sort.Slice(sorted, func(a, b int) bool { return sorted[a] < sorted[b] })
+	for index, queue := range sorted {
+		if queue >= maxNumQueues {
+			return nil, fmt.Errorf("Queue %d exceeds maximum of %d queues", queue, maxNumQueues)
+		}
+		if index > 0 && sorted[index-1] == queue {
+			return nil, fmt.Errorf("Queue %d given twice", queue)
+		}
+	}
+	if len(sorted) == 0 {
+		return nil, fmt.Errorf("No queues given")
+	}
+	return sorted, nil
+}
+
+// newPerQueueOp allocates a struct ethtool_per_queue_op for the given sorted queues,
+// returning the buffer and pointers to each queue's coalesce struct within it
+func newPerQueueOp(subCommand uint32, queues []uint32) ([]byte, []*ethtoolCoalesce) {
+	headerLength := int(unsafe.Sizeof(ethtoolPerQueueOp{}))
+	entryLength := int(unsafe.Sizeof(ethtoolCoalesce{}))
+	buf := make([]byte, headerLength+len(queues)*entryLength)
+
+	header := (*ethtoolPerQueueOp)(unsafe.Pointer(&buf[0]))
+	header.cmd = perQueueIoctl
+	header.subCommand = subCommand
+	entries := make([]*ethtoolCoalesce, len(queues))
+	for index, queue := range queues {
+		header.queueMask[queue/32] |= 1 << (queue % 32)
+		entries[index] = (*ethtoolCoalesce)(unsafe.Pointer(&buf[headerLength+index*entryLength]))
+	}
+	return buf, entries
+}
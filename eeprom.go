@@ -1,16 +1,16 @@
 package ethtool
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/pkg/errors"
 	"github.com/wobcom/go-ethtool/eeprom"
+	"github.com/wobcom/go-ethtool/eeprom/cmis"
+	"github.com/wobcom/go-ethtool/eeprom/sff8079"
 	"github.com/wobcom/go-ethtool/eeprom/sff8472"
 	"github.com/wobcom/go-ethtool/eeprom/sff8636"
-	"github.com/wobcom/go-ethtool/util"
-	"strings"
 	"time"
 	"unicode"
-	"unicode/utf8"
 	"unsafe"
 )
 
@@ -40,6 +40,8 @@ const (
 	setEepromDataIoctl = 0x0000000c
 	// Maximum support eeprom length
 	eepromMaxLength = 32768
+	// Length of the SFF-8636 memory map up to upper page 02h, as required by sff8636.NewEEPROM
+	sff8636MinLength = 512
 )
 
 // WriteEEPROM writes the given data to the given offset
@@ -56,7 +58,6 @@ func (i *Interface) WriteEEPROM(data []byte, offset uint32) error {
 	if err := i.performIoctl(uintptr(unsafe.Pointer(&ethtoolEeprom))); err != nil {
 		return errors.Wrapf(err, "ioctl getEepromDataIoctl returned error")
 	}
-	fmt.Printf("read: %v\n", ethtoolEeprom.Data[0:ethtoolEeprom.Length])
 
 	/* Write data to eeprom */
 	for i := 0; i < len(data); i++ {
@@ -80,7 +81,13 @@ func isASCII(s []byte) bool {
 	return true
 }
 
-func (i *Interface) getEEPROM() (eeprom.EEPROM, error) {
+// GetEEPROM reads and parses the module EEPROM, nil options parse leniently and record checksum errors in the result.
+// Strict options fail on checksum errors, as reported by corrupted or counterfeit modules.
+func (i *Interface) GetEEPROM(options *eeprom.ParseOptions) (eeprom.EEPROM, error) {
+	return i.getEEPROM(options)
+}
+
+func (i *Interface) getEEPROM(options *eeprom.ParseOptions) (eeprom.EEPROM, error) {
 	ethtoolModInfo, err := i.getEEPROMModuleInfo()
 	if err != nil {
 		return nil, errors.Wrapf(err, "Could not retrieve module info for interface %s", i.Name)
@@ -97,33 +104,112 @@ func (i *Interface) getEEPROM() (eeprom.EEPROM, error) {
 			continue
 		}
 
-		eepromType := eeprom.Type(ethtoolModInfo.EepromType)
 		data := ethtoolEeprom.Data[:ethtoolModInfo.Length]
+		// drivers report CMIS modules as SFF-8636 and some report the wrong type for SFP and QSFP modules
+		eepromType := eeprom.DetectType(data, eeprom.Type(ethtoolModInfo.EepromType))
 
-		switch eepromType {
-		case eeprom.TypeSFF8472:
-			// bypasses a glitch (maybe in the sx_netdev driver?) which would return just garbage
-			rawVendorName := data[0x14:0x23]
-			if !utf8.Valid(rawVendorName) || strings.HasPrefix(util.GetValidUtf8String(rawVendorName), "/") {
-				fmt.Printf("Avoiding weird SFF8472 vendor name for interface  %s\n", i.Name)
-			}
-			e, err := sff8472.NewEEPROM(data)
-			if err != nil {
-				fmt.Printf("sff8472: %s\n", err.Error())
-			}
-			return e, err
-		case eeprom.TypeSFF8436:
-			return sff8636.NewEEPROM(data)
-		case eeprom.TypeSFF8636:
-			return sff8636.NewEEPROM(data)
-		default:
+		e, err := i.parseEEPROM(data, eepromType, options)
+		if err == errUnsupportedEEPROMType {
 			err = fmt.Errorf("EEPROM Type %v not supported", eepromType.String())
 			continue
 		}
+		if err != nil {
+			return e, err
+		}
+		return eeprom.ApplyQuirks(e, data, options)
 	}
 	return nil, fmt.Errorf("Could not read EEPROM for interface %s after 3 tries", i.Name)
 }
 
+// errUnsupportedEEPROMType returned by parseEEPROM for standards without parser
+var errUnsupportedEEPROMType = errors.New("EEPROM type not supported")
+
+// parseEEPROM parses data according to the given standard, reading further pages if required
+func (i *Interface) parseEEPROM(data []byte, eepromType eeprom.Type, options *eeprom.ParseOptions) (eeprom.EEPROM, error) {
+	switch eepromType {
+	case eeprom.TypeCMIS:
+		return i.getCMISEEPROM(data, options)
+	case eeprom.TypeSFF8079:
+		return sff8079.NewEEPROMWithOptions(data, options)
+	case eeprom.TypeSFF8472:
+		// garbage returned by some drivers (e.g. sx_netdev) is reported through GetParseWarnings and handled by a known quirk
+		return sff8472.NewEEPROMWithOptions(data, options)
+	case eeprom.TypeSFF8436, eeprom.TypeSFF8636:
+		return sff8636.NewEEPROMWithOptions(padSFF8636(data), options)
+	default:
+		return nil, errUnsupportedEEPROMType
+	}
+}
+
+// padSFF8636 pads data to the length required by sff8636.NewEEPROM with erased (0xFF) bytes,
+// drivers reporting the base length or the wrong type only provide the lower page and upper page 00h.
+// sff8636.NewEEPROM skips erased upper pages 01h and 02h, so they are not decoded from the padding.
+func padSFF8636(data []byte) []byte {
+	if len(data) >= sff8636MinLength {
+		return data
+	}
+	return append(data, bytes.Repeat([]byte{0xFF}, sff8636MinLength-len(data))...)
+}
+
+// Upper pages parsed by the cmis package: advertising, thresholds, lane controls and lane status
+var cmisUpperPages = []uint8{0x01, 0x02, 0x10, 0x11}
+
+const (
+	// Page and offset of the number of supported VDM groups (bits 1-0, plus one)
+	cmisVDMGroupsPage   = 0x2F
+	cmisVDMGroupsOffset = 0x80
+	// Latched VDM flags of all groups
+	cmisVDMFlagsPage = 0x2C
+)
+
+// Pages of coherent (C-CMIS) modules: tunable laser and media lane performance monitoring
+var (
+	cmisCoherentLaserPages = []uint8{0x12}
+	cmisCoherentPMPages    = []uint8{0x34, 0x35}
+)
+
+// getCMISEEPROM reads the upper pages of a CMIS module and parses them.
+// VDM pages are only read if the module advertises them, limited to the supported groups,
+// the tunable laser and performance monitoring pages only for coherent modules.
+func (i *Interface) getCMISEEPROM(data []byte, options *eeprom.ParseOptions) (*cmis.EEPROM, error) {
+	raw := i.readUpperPages(data, cmisUpperPages)
+	e, err := cmis.NewEEPROMWithOptions(raw, options)
+	if err != nil {
+		return e, err
+	}
+	vdmSupported := e.Advertising != nil && e.Advertising.VDMSupported
+	if !vdmSupported && !e.IsCoherent() {
+		return e, nil
+	}
+
+	// pages have to be read in ascending order, each group separately so a missing one does not affect the others
+	if e.IsCoherent() {
+		raw = i.readUpperPages(raw, cmisCoherentLaserPages)
+	}
+	if vdmSupported {
+		groupsSupported, err := i.ReadModuleEEPROMPage(cmisVDMGroupsPage, 0, cmisVDMGroupsOffset, 1)
+		if err == nil && len(groupsSupported) == 1 {
+			raw = i.readUpperPages(raw, cmisVDMPages(groupsSupported[0]&0x03+1))
+		}
+	}
+	if e.IsCoherent() {
+		raw = i.readUpperPages(raw, cmisCoherentPMPages)
+	}
+	return cmis.NewEEPROMWithOptions(raw, options)
+}
+
+// cmisVDMPages returns the VDM pages of the given number of groups in ascending order:
+// descriptor pages 20h-23h, sample pages 24h-27h, threshold pages 28h-2Bh, flags page 2Ch and page 2Fh
+func cmisVDMPages(groups uint8) []uint8 {
+	pages := []uint8{}
+	for _, firstPage := range []uint8{0x20, 0x24, 0x28} {
+		for group := uint8(0); group < groups; group++ {
+			pages = append(pages, firstPage+group)
+		}
+	}
+	return append(pages, cmisVDMFlagsPage, cmisVDMGroupsPage)
+}
+
 func (i *Interface) getEEPROMModuleInfo() (*ethtoolModinfo, error) {
 	ethtoolModInfo := &ethtoolModinfo{
 		Command: getModuleInfoIoctl,
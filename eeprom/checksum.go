@@ -0,0 +1,67 @@
+package eeprom
+
+import (
+	"fmt"
+)
+
+// ParseOptions control how strictly an EEPROM is parsed
+type ParseOptions struct {
+	// Strict aborts parsing on the first checksum error, otherwise checksum errors are recorded and parsing continues
+	Strict bool
+}
+
+// ChecksumError occurs when a region of the EEPROM does not match its check code
+type ChecksumError struct {
+	// Region name of the check code and the bytes it covers, e.g. "CC_BASE (bytes 0-62)"
+	Region string
+	// Expected check code as stored in the EEPROM
+	Expected byte
+	// Actual low order 8 bits of the sum of the covered bytes
+	Actual byte
+	// Continued is set if parsing continued despite the error
+	Continued bool
+}
+
+func (c *ChecksumError) Error() string {
+	action := "parsing aborted"
+	if c.Continued {
+		action = "parsing continued"
+	}
+	return fmt.Sprintf("Invalid checksum of %s: expected %#02x, got %#02x, %s", c.Region, c.Expected, c.Actual, action)
+}
+
+// Checksum returns the low order 8 bits of the sum of data, as used by the check codes of SFF-8472, SFF-8636 and CMIS
+func Checksum(data []byte) byte {
+	sum := byte(0)
+	for _, b := range data {
+		sum += b
+	}
+	return sum
+}
+
+// VerifyChecksum returns a ChecksumError if the check code of data does not match expected, nil otherwise
+func VerifyChecksum(region string, data []byte, expected byte, options *ParseOptions) *ChecksumError {
+	actual := Checksum(data)
+	if actual == expected {
+		return nil
+	}
+	return &ChecksumError{
+		Region:    region,
+		Expected:  expected,
+		Actual:    actual,
+		Continued: options == nil || !options.Strict,
+	}
+}
+
+// CheckChecksum verifies the check code of data, a ChecksumError is appended to checksumErrors in lenient mode and returned in strict mode
+func CheckChecksum(checksumErrors *[]*ChecksumError, region string, data []byte, expected byte, options *ParseOptions) error {
+	err := VerifyChecksum(region, data, expected, options)
+	if err == nil {
+		return nil
+	}
+	if !err.Continued {
+		return err
+	}
+	*checksumErrors = append(*checksumErrors, err)
+	return nil
+}
@@ -0,0 +1,82 @@
+package cmis
+
+// Advertising module capabilities and characteristics advertised in upper page 01h, see CMIS 5.2 table 8-41
+type Advertising struct {
+	ActiveFirmwareVersion   Revision
+	InactiveFirmwareVersion Revision
+	// Supported link lengths in meters
+	LengthSMF float64
+	LengthOM5 float64
+	LengthOM4 float64
+	LengthOM3 float64
+	LengthOM2 float64
+	// Nominal wavelength and tolerance in nm
+	Wavelength          float64
+	WavelengthTolerance float64
+	MonitorsImplemented *MonitorsImplemented
+	// Versatile diagnostics monitoring pages 20h-2Fh are implemented
+	VDMSupported bool
+	// Number of command data block instances, 0 if CDB is not supported
+	CDBInstances byte
+}
+
+// Offsets relative to the start of upper page 01h (byte 128)
+const (
+	// Active firmware version, major and minor revision
+	activeFirmwareVersionOffset = 0x00
+	// Inactive firmware version, major and minor revision
+	inactiveFirmwareVersionOffset = 0x02
+	// Link length supported for SMF fiber in km (bits 7-6 multiplier 0.1 km / 1 km, bits 5-0 base value)
+	lengthSmfOffset = 0x04
+	// Link length supported for OM5 fiber, units of 2 m
+	lengthOM5Offset = 0x05
+	// Link length supported for OM4 fiber, units of 2 m
+	lengthOM4Offset = 0x06
+	// Link length supported for OM3 fiber, units of 2 m
+	lengthOM3Offset = 0x07
+	// Link length supported for OM2 fiber, units of 1 m
+	lengthOM2Offset = 0x08
+	// Nominal wavelength, units of 0.05 nm
+	wavelengthOffset = 0x0A
+	// Wavelength tolerance, units of 0.005 nm
+	wavelengthToleranceOffset = 0x0C
+	// Supported pages, bit 6 is set if VDM pages are implemented
+	pagesSupportedOffset = 0x0E
+	// CDB support, number of instances in bits 7-6
+	cdbSupportOffset = 0x23
+	// Implemented monitors
+	monitorsImplementedOffset = 0x1F
+)
+
+// NewAdvertising parses upper page 01h into a new Advertising instance
+func NewAdvertising(raw [128]byte) *Advertising {
+	return &Advertising{
+		ActiveFirmwareVersion: Revision{
+			Major: raw[activeFirmwareVersionOffset],
+			Minor: raw[activeFirmwareVersionOffset+1],
+		},
+		InactiveFirmwareVersion: Revision{
+			Major: raw[inactiveFirmwareVersionOffset],
+			Minor: raw[inactiveFirmwareVersionOffset+1],
+		},
+		LengthSMF: parseLength(raw[lengthSmfOffset], [4]float64{100, 1000, 0, 0}),
+		LengthOM5: float64(raw[lengthOM5Offset]) * 2,
+		LengthOM4: float64(raw[lengthOM4Offset]) * 2,
+		LengthOM3: float64(raw[lengthOM3Offset]) * 2,
+		LengthOM2: float64(raw[lengthOM2Offset]),
+		Wavelength: parseWavelength(
+			raw[wavelengthOffset+0],
+			raw[wavelengthOffset+1],
+		),
+		WavelengthTolerance: parseWavelengthTolerance(
+			raw[wavelengthToleranceOffset+0],
+			raw[wavelengthToleranceOffset+1],
+		),
+		MonitorsImplemented: NewMonitorsImplemented([2]byte{
+			raw[monitorsImplementedOffset+0],
+			raw[monitorsImplementedOffset+1],
+		}),
+		VDMSupported: raw[pagesSupportedOffset]&(1<<6) > 0,
+		CDBInstances: raw[cdbSupportOffset] >> 6,
+	}
+}
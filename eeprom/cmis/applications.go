@@ -0,0 +1,181 @@
+package cmis
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/wobcom/go-ethtool/eeprom/sff8024"
+)
+
+const (
+	// Number of applications advertised in the lower page, see CMIS 5.2 table 8-23
+	lowerPageApplicationCount = 8
+	// Maximum number of applications, the remaining ones are advertised in upper page 01h
+	maxApplicationCount = 15
+	// Each application descriptor is made of 4 bytes
+	applicationDescriptorLength = 4
+)
+
+// MediaInterfaceID media interface code, its meaning depends on the module's media type (see SFF-8024 tables 4-6 to 4-10)
+type MediaInterfaceID struct {
+	MediaType MediaType
+	ID        byte
+}
+
+// Specification returns the interface's properties, false if the ID is unknown for the media type
+func (m MediaInterfaceID) Specification() (sff8024.InterfaceSpecification, bool) {
+	switch m.MediaType {
+	case MediaTypeMMF:
+		return sff8024.MMFMediaInterfaceID(m.ID).Specification()
+	case MediaTypeSMF:
+		return sff8024.SMFMediaInterfaceID(m.ID).Specification()
+	case MediaTypePassiveCopper:
+		return sff8024.PassiveCopperMediaInterfaceID(m.ID).Specification()
+	case MediaTypeActiveCable:
+		return sff8024.ActiveCableMediaInterfaceID(m.ID).Specification()
+	case MediaTypeBaseT:
+		return sff8024.BaseTMediaInterfaceID(m.ID).Specification()
+	}
+	return sff8024.InterfaceSpecification{Name: "Unknown"}, false
+}
+
+func (m MediaInterfaceID) String() string {
+	spec, _ := m.Specification()
+	return spec.Name
+}
+
+// MarshalJSON implements the encoding/json/Marshaler interface's MarshalJSON function
+func (m MediaInterfaceID) MarshalJSON() ([]byte, error) {
+	spec, _ := m.Specification()
+	return json.Marshal(map[string]interface{}{
+		"ascii":             spec.Name,
+		"hex":               fmt.Sprintf("%#02x", m.ID),
+		"laneCount":         spec.LaneCount,
+		"laneSignalingRate": spec.LaneSignalingRate,
+		"modulation":        spec.Modulation,
+	})
+}
+
+// Application an application advertised by the module, as defined in CMIS 5.2 section 6.2.1 and table 8-23
+type Application struct {
+	// Application select code (AppSel) 1 to 15, used to refer to the application in data path configurations
+	ApplicationSelectCode byte
+	HostInterfaceID       sff8024.HostInterfaceID
+	MediaInterfaceID      MediaInterfaceID
+	HostLaneCount         byte
+	MediaLaneCount        byte
+	// Bit n is set if the application's data path may start on host lane n+1
+	HostLaneAssignmentOptions byte
+	// Bit n is set if the application's data path may start on media lane n+1, only available for paged modules
+	MediaLaneAssignmentOptions byte
+}
+
+func (a Application) String() string {
+	return fmt.Sprintf("%d: %s (%d host lanes) / %s (%d media lanes)", a.ApplicationSelectCode,
+		a.HostInterfaceID, a.HostLaneCount, a.MediaInterfaceID, a.MediaLaneCount)
+}
+
+// NewApplications parses the application descriptors of the lower page (bytes 86-117) and,
+// if available, upper page 01h into a list of applications, stopping at the end of list marker
+func NewApplications(lowerPage [32]byte, page01h *[128]byte, mediaType MediaType) []Application {
+	descriptors := make([][]byte, 0, maxApplicationCount)
+	for index := 0; index < lowerPageApplicationCount; index++ {
+		descriptors = append(descriptors, lowerPage[index*applicationDescriptorLength:(index+1)*applicationDescriptorLength])
+	}
+	if page01h != nil {
+		// applications 9 to 15 (bytes 223-250)
+		for index := 0; index < maxApplicationCount-lowerPageApplicationCount; index++ {
+			start := 0x5F + index*applicationDescriptorLength
+			descriptors = append(descriptors, page01h[start:start+applicationDescriptorLength])
+		}
+	}
+
+	applications := []Application{}
+	for index, descriptor := range descriptors {
+		hostInterfaceID := sff8024.HostInterfaceID(descriptor[0])
+		if hostInterfaceID.IsEndOfList() {
+			break
+		}
+		if descriptor[0] == 0x00 {
+			continue
+		}
+		application := Application{
+			ApplicationSelectCode:     byte(index + 1),
+			HostInterfaceID:           hostInterfaceID,
+			MediaInterfaceID:          MediaInterfaceID{MediaType: mediaType, ID: descriptor[1]},
+			HostLaneCount:             descriptor[2] >> 4,
+			MediaLaneCount:            descriptor[2] & 0x0F,
+			HostLaneAssignmentOptions: descriptor[3],
+		}
+		if page01h != nil {
+			// media lane assignment options (bytes 176-190)
+			application.MediaLaneAssignmentOptions = page01h[0x30+index]
+		}
+		applications = append(applications, application)
+	}
+	return applications
+}
+
+// LaneApplications per host lane data path configuration, as defined in CMIS 5.2 tables 8-72 and 8-89
+type LaneApplications [MaxLanes]LaneApplication
+
+// LaneApplication data path configuration of a single host lane
+type LaneApplication struct {
+	// Application select code, 0 if the lane is not part of a data path
+	ApplicationSelectCode byte
+	// Index of the data path's first host lane (0 for lane 1)
+	DataPathID byte
+	// Whether signal integrity settings are controlled by the host instead of the application's defaults
+	ExplicitControl bool
+}
+
+// NewLaneApplications parses [8]byte, one byte per lane, into a new LaneApplications instance
+func NewLaneApplications(raw [8]byte) *LaneApplications {
+	l := &LaneApplications{}
+	for lane := 0; lane < MaxLanes; lane++ {
+		l[lane].ApplicationSelectCode = raw[lane] >> 4
+		l[lane].DataPathID = (raw[lane] >> 1) & 0x07
+		l[lane].ExplicitControl = raw[lane]&0x01 > 0
+	}
+	return l
+}
+
+// DataPath a group of host lanes running a common application
+type DataPath struct {
+	// Index of the data path's first host lane (0 for lane 1)
+	ID byte
+	// Indices of the host lanes belonging to the data path
+	HostLanes []int
+	// Application the data path is configured for, nil if the module does not advertise it
+	Application *Application
+}
+
+// GetActiveDataPaths returns the data paths of the active control set (upper page 11h), ordered by ID.
+// nil is returned if page 11h is not available.
+func (e *EEPROM) GetActiveDataPaths() []DataPath {
+	if e.ActiveApplications == nil {
+		return nil
+	}
+
+	dataPaths := []DataPath{}
+	for lane, laneApplication := range e.ActiveApplications {
+		if laneApplication.ApplicationSelectCode == 0 {
+			continue
+		}
+		if len(dataPaths) > 0 && dataPaths[len(dataPaths)-1].ID == laneApplication.DataPathID {
+			dataPaths[len(dataPaths)-1].HostLanes = append(dataPaths[len(dataPaths)-1].HostLanes, lane)
+			continue
+		}
+		dataPath := DataPath{
+			ID:        laneApplication.DataPathID,
+			HostLanes: []int{lane},
+		}
+		for index := range e.Applications {
+			if e.Applications[index].ApplicationSelectCode == laneApplication.ApplicationSelectCode {
+				dataPath.Application = &e.Applications[index]
+				break
+			}
+		}
+		dataPaths = append(dataPaths, dataPath)
+	}
+	return dataPaths
+}
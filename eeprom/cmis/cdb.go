@@ -0,0 +1,228 @@
+package cmis
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+)
+
+/* Command data block (CDB) messaging as defined in CMIS 5.2 sections 8.4.11 (lower page) and 9 (page 9Fh).
+ * Offsets use the linear layout, i.e. upper page n starts at 0x80 + n * 0x80 */
+const (
+	// CDBStatusOffset status of CDB instance 1 (lower page byte 37)
+	CDBStatusOffset = 0x25
+	// CDBPage page holding the command header and local payload (LPL)
+	CDBPage = 0x9F
+	// CDBCommandOffset offset of the command ID (page 9Fh byte 128), writing it triggers the command
+	CDBCommandOffset = 0x80 + CDBPage*0x80
+	// CDBHeaderLength length of the command header preceding the local payload
+	CDBHeaderLength = 8
+	// CDBEPLOffset start of the extended payload (EPL), pages A0h-AFh are contiguous in the linear layout
+	CDBEPLOffset = 0x80 + 0xA0*0x80
+	// CDBMaxLPLLength maximum length of a local payload
+	CDBMaxLPLLength = 0x80 - CDBHeaderLength
+	// CDBMaxEPLLength maximum length of an extended payload
+	CDBMaxEPLLength = 16 * 0x80
+)
+
+// CDB command IDs, see CMIS 5.2 table 9-4
+const (
+	CDBCommandQueryStatus                uint16 = 0x0000
+	CDBCommandFirmwareManagementFeatures uint16 = 0x0041
+	CDBCommandGetFirmwareInfo            uint16 = 0x0100
+	CDBCommandStartFirmwareDownload      uint16 = 0x0101
+	CDBCommandAbortFirmwareDownload      uint16 = 0x0102
+	CDBCommandWriteFirmwareBlockLPL      uint16 = 0x0103
+	CDBCommandWriteFirmwareBlockEPL      uint16 = 0x0104
+	CDBCommandCompleteFirmwareDownload   uint16 = 0x0107
+	CDBCommandRunFirmwareImage           uint16 = 0x0109
+	CDBCommandCommitFirmwareImage        uint16 = 0x010A
+)
+
+// CDBStatus status of the last CDB command, as defined in CMIS 5.2 table 8-22.
+// Bit 7 is set while the module is busy, bit 6 if the command failed, bits 5-0 hold the result code.
+type CDBStatus byte
+
+const (
+	// CDBStatusSuccess command completed successfully
+	CDBStatusSuccess CDBStatus = 0x01
+)
+
+// IsBusy returns true while the module is processing a command
+func (c CDBStatus) IsBusy() bool {
+	return c&0x80 > 0
+}
+
+// IsFailed returns true if the last command failed
+func (c CDBStatus) IsFailed() bool {
+	return !c.IsBusy() && c&0x40 > 0
+}
+
+func (c CDBStatus) String() string {
+	str, found := map[CDBStatus]string{
+		0x00: "Idle",
+		0x01: "Success",
+		0x80: "Busy",
+		0x81: "Busy capturing command",
+		0x82: "Busy checking command",
+		0x83: "Busy executing command",
+		0x40: "Failed, no specific failure code",
+		0x41: "Failed, unknown command",
+		0x42: "Failed, parameter range error or not supported",
+		0x43: "Failed, previous command was not aborted",
+		0x44: "Failed, command checking timed out",
+		0x45: "Failed, checksum error",
+		0x46: "Failed, password error",
+		0x47: "Failed, command not compatible with operating status",
+	}[c]
+	if found {
+		return str
+	} else if c.IsBusy() {
+		return "Busy, custom"
+	} else if c.IsFailed() {
+		return "Failed, custom"
+	}
+	return "Reserved"
+}
+
+// MarshalJSON implements the encoding/json/Marshaler interface's MarshalJSON function
+func (c CDBStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{
+		"ascii": c.String(),
+		"hex":   fmt.Sprintf("%#02X", byte(c)),
+	})
+}
+
+// CDBChecksum returns the ones' complement of the 8 bit sum of data
+func CDBChecksum(data []byte) byte {
+	sum := byte(0)
+	for _, b := range data {
+		sum += b
+	}
+	return ^sum
+}
+
+// NewCDBRequest encodes a CDB command header followed by the local payload, to be written starting at CDBCommandOffset.
+// eplLength announces the length of an extended payload written to CDBEPLOffset beforehand.
+func NewCDBRequest(command uint16, lpl []byte, eplLength int) ([]byte, error) {
+	if len(lpl) > CDBMaxLPLLength {
+		return nil, fmt.Errorf("CDB local payload of %d bytes exceeds maximum of %d bytes", len(lpl), CDBMaxLPLLength)
+	}
+	if eplLength > CDBMaxEPLLength {
+		return nil, fmt.Errorf("CDB extended payload of %d bytes exceeds maximum of %d bytes", eplLength, CDBMaxEPLLength)
+	}
+
+	request := make([]byte, CDBHeaderLength+len(lpl))
+	binary.BigEndian.PutUint16(request[0:2], command)
+	binary.BigEndian.PutUint16(request[2:4], uint16(eplLength))
+	request[4] = byte(len(lpl))
+	copy(request[CDBHeaderLength:], lpl)
+	// the checksum covers the header and local payload, with checksum and reply fields set to 0
+	request[5] = CDBChecksum(request)
+	return request, nil
+}
+
+// FirmwareManagementFeatures firmware upgrade capabilities reported by CDB command 0041h, see CMIS 5.2 table 9-15
+type FirmwareManagementFeatures struct {
+	// Number of bytes of the image's vendor header sent with the start command
+	StartPayloadSize int
+	// Maximum number of bytes written per write command
+	MaxWriteLength    int
+	LPLWriteSupported bool
+	EPLWriteSupported bool
+	// Maximum durations of the respective commands in milliseconds
+	MaxStartDuration    uint16
+	MaxWriteDuration    uint16
+	MaxCompleteDuration uint16
+}
+
+// NewFirmwareManagementFeatures parses the reply of CDB command 0041h
+func NewFirmwareManagementFeatures(rpl []byte) (*FirmwareManagementFeatures, error) {
+	if len(rpl) < 16 {
+		return nil, fmt.Errorf("Firmware management features reply of %d bytes is too short", len(rpl))
+	}
+	return &FirmwareManagementFeatures{
+		StartPayloadSize:    int(rpl[2]),
+		MaxWriteLength:      8 * (int(rpl[4]) + 1),
+		LPLWriteSupported:   rpl[5]&0x01 > 0,
+		EPLWriteSupported:   rpl[5]&0x10 > 0,
+		MaxStartDuration:    binary.BigEndian.Uint16(rpl[8:10]),
+		MaxWriteDuration:    binary.BigEndian.Uint16(rpl[12:14]),
+		MaxCompleteDuration: binary.BigEndian.Uint16(rpl[14:16]),
+	}, nil
+}
+
+// FirmwareImage information about one of the module's firmware images
+type FirmwareImage struct {
+	Present bool
+	// Running and Committed are not reported for the factory image
+	Running   bool
+	Committed bool
+	// Image is empty or invalid
+	Invalid bool
+	Version Revision
+	Build   uint16
+	// Vendor specific additional information
+	ExtraInfo string
+}
+
+// FirmwareInfo firmware images reported by CDB command 0100h, see CMIS 5.2 table 9-16
+type FirmwareInfo struct {
+	ImageA  FirmwareImage
+	ImageB  FirmwareImage
+	Factory FirmwareImage
+}
+
+// NewFirmwareInfo parses the reply of CDB command 0100h
+func NewFirmwareInfo(rpl []byte) (*FirmwareInfo, error) {
+	// status and presence bytes, followed by 36 bytes per image
+	if len(rpl) < 2+36 {
+		return nil, fmt.Errorf("Firmware info reply of %d bytes is too short", len(rpl))
+	}
+	parseImage := func(present bool, statusBits byte, offset int) FirmwareImage {
+		image := FirmwareImage{
+			Present:   present,
+			Running:   statusBits&0x01 > 0,
+			Committed: statusBits&0x02 > 0,
+			Invalid:   statusBits&0x04 > 0,
+		}
+		if present && len(rpl) >= offset+36 {
+			image.Version = Revision{Major: rpl[offset], Minor: rpl[offset+1]}
+			image.Build = binary.BigEndian.Uint16(rpl[offset+2 : offset+4])
+			image.ExtraInfo = parseString(rpl[offset+4 : offset+36])
+		}
+		return image
+	}
+	return &FirmwareInfo{
+		ImageA:  parseImage(rpl[1]&0x01 > 0, rpl[0]&0x0F, 2),
+		ImageB:  parseImage(rpl[1]&0x02 > 0, rpl[0]>>4, 38),
+		Factory: parseImage(rpl[1]&0x04 > 0, 0, 74),
+	}, nil
+}
+
+// FirmwareRunMode how CDB command 0109h resets the module to run a firmware image, see CMIS 5.2 table 9-20
+type FirmwareRunMode byte
+
+const (
+	// FirmwareRunModeResetToInactive traffic affecting reset to the inactive image
+	FirmwareRunModeResetToInactive FirmwareRunMode = 0x00
+	// FirmwareRunModeHitlessResetToInactive attempt a hitless reset to the inactive image
+	FirmwareRunModeHitlessResetToInactive FirmwareRunMode = 0x01
+	// FirmwareRunModeResetToRunning traffic affecting reset to the running image
+	FirmwareRunModeResetToRunning FirmwareRunMode = 0x02
+	// FirmwareRunModeHitlessResetToRunning attempt a hitless reset to the running image
+	FirmwareRunModeHitlessResetToRunning FirmwareRunMode = 0x03
+)
+
+func (f FirmwareRunMode) String() string {
+	str, found := map[FirmwareRunMode]string{
+		FirmwareRunModeResetToInactive:        "Traffic affecting reset to inactive image",
+		FirmwareRunModeHitlessResetToInactive: "Hitless reset to inactive image",
+		FirmwareRunModeResetToRunning:         "Traffic affecting reset to running image",
+		FirmwareRunModeHitlessResetToRunning:  "Hitless reset to running image",
+	}[f]
+	if found {
+		return str
+	}
+	return "Reserved"
+}
@@ -0,0 +1,219 @@
+package cmis
+
+import (
+	"encoding/binary"
+)
+
+// Speed of light in vacuum in m/s, used to convert laser frequencies to wavelengths
+const speedOfLight = 299792458
+
+// GridSpacing laser frequency grid spacing in GHz, as defined in CMIS 5.2 table 8-93
+type GridSpacing float64
+
+var gridSpacings = map[byte]GridSpacing{
+	0x00: 3.125,
+	0x01: 6.25,
+	0x02: 12.5,
+	0x03: 25,
+	0x04: 50,
+	0x05: 100,
+	0x06: 33,
+	0x07: 75,
+}
+
+// TunableLaser tunable laser control and status of page 12h, as defined in CMIS 5.2 section 8.8
+type TunableLaser [MaxLanes]TunableLaserLane
+
+// TunableLaserLane tunable laser configuration and status of a single lane
+type TunableLaserLane struct {
+	// Grid spacing in GHz, 0 if reserved
+	GridSpacing       GridSpacing
+	FineTuningEnabled bool
+	// Channel number relative to 193.1 THz in multiples of the grid spacing
+	ChannelNumber int16
+	// Fine tuning offset in GHz
+	FineTuningOffset float64
+	// Current laser frequency in GHz
+	CurrentFrequency float64
+	// Target output power in dBm
+	TargetOutputPower float64
+	TuningInProgress  bool
+	// Wavelength is not locked to the configured frequency
+	WavelengthUnlocked bool
+}
+
+// Offsets relative to the start of upper page 12h (byte 128)
+const (
+	// Grid spacing in bits 7-4, fine tuning enable in bit 0, one byte per lane
+	gridSpacingOffset = 0x00
+	// Channel number, 2 bytes per lane
+	channelNumberOffset = 0x08
+	// Fine tuning offset in units of 0.001 GHz, 2 bytes per lane
+	fineTuningOffsetOffset = 0x18
+	// Current laser frequency in units of MHz, 4 bytes per lane
+	currentFrequencyOffset = 0x28
+	// Target output power in units of 0.01 dBm, 2 bytes per lane
+	targetOutputPowerOffset = 0x48
+	// Tuning status, bit 1 tuning in progress, bit 0 wavelength unlocked, one byte per lane
+	tuningStatusOffset = 0x5E
+)
+
+// NewTunableLaser parses upper page 12h into a new TunableLaser instance
+func NewTunableLaser(raw [128]byte) *TunableLaser {
+	t := &TunableLaser{}
+	for lane := 0; lane < MaxLanes; lane++ {
+		t[lane] = TunableLaserLane{
+			GridSpacing:        gridSpacings[raw[gridSpacingOffset+lane]>>4],
+			FineTuningEnabled:  raw[gridSpacingOffset+lane]&0x01 > 0,
+			ChannelNumber:      parseInt16(raw[channelNumberOffset+2*lane], raw[channelNumberOffset+2*lane+1]),
+			FineTuningOffset:   float64(parseInt16(raw[fineTuningOffsetOffset+2*lane], raw[fineTuningOffsetOffset+2*lane+1])) * 0.001,
+			CurrentFrequency:   float64(binary.BigEndian.Uint32(raw[currentFrequencyOffset+4*lane:])) / 1000,
+			TargetOutputPower:  float64(parseInt16(raw[targetOutputPowerOffset+2*lane], raw[targetOutputPowerOffset+2*lane+1])) * 0.01,
+			TuningInProgress:   raw[tuningStatusOffset+lane]&0x02 > 0,
+			WavelengthUnlocked: raw[tuningStatusOffset+lane]&0x01 > 0,
+		}
+	}
+	return t
+}
+
+// GetWavelength returns the wavelength in nm corresponding to the lane's current frequency, 0 if unknown
+func (t *TunableLaserLane) GetWavelength() float64 {
+	if t.CurrentFrequency == 0 {
+		return 0
+	}
+	// speed of light in m/s divided by frequency in GHz gives nm
+	return speedOfLight / t.CurrentFrequency
+}
+
+// PMStatistic average, minimum and maximum of a performance monitoring parameter over the last PM interval
+type PMStatistic struct {
+	Average float64
+	Min     float64
+	Max     float64
+}
+
+// CoherentPM media lane FEC and link performance monitoring of pages 34h and 35h, as defined in OIF C-CMIS 1.2 section 8.5.
+// Counters and statistics cover the current PM interval.
+type CoherentPM struct {
+	RxBits                    uint64
+	RxBitsSubInterval         uint64
+	RxCorrectedBits           uint64
+	RxMinCorrectedBitsSub     uint64
+	RxMaxCorrectedBitsSub     uint64
+	RxFrames                  uint32
+	RxFramesSubInterval       uint32
+	RxUncorrectedFrames       uint32
+	RxMinUncorrectedFramesSub uint32
+	RxMaxUncorrectedFramesSub uint32
+
+	// Chromatic dispersion in ps/nm
+	CD PMStatistic
+	// Differential group delay in ps
+	DGD PMStatistic
+	// Second order polarization mode dispersion in ps^2
+	SOPMD PMStatistic
+	// Polarization dependent loss in dB
+	PDL PMStatistic
+	// Optical signal to noise ratio in dB
+	OSNR PMStatistic
+	// Electrical signal to noise ratio in dB
+	ESNR PMStatistic
+	// Carrier frequency offset in MHz
+	CFO PMStatistic
+	// Error vector magnitude in percent
+	EVM PMStatistic
+	// Tx output power in dBm
+	TxPower PMStatistic
+	// Rx total input power in dBm
+	RxTotalPower PMStatistic
+	// Rx channel input power in dBm
+	RxSignalPower PMStatistic
+	// State of polarization rate of change in krad/s
+	SOPROC PMStatistic
+	// Modulation error ratio in dB
+	MER PMStatistic
+}
+
+// Offsets relative to the start of upper page 34h (byte 128), page 35h follows at 0x80
+const (
+	pmRxBitsOffset              = 0x00
+	pmRxBitsSubIntervalOffset   = 0x08
+	pmRxCorrectedBitsOffset     = 0x10
+	pmRxMinCorrectedBitsOffset  = 0x18
+	pmRxMaxCorrectedBitsOffset  = 0x20
+	pmRxFramesOffset            = 0x28
+	pmRxFramesSubIntervalOffset = 0x2C
+	pmRxUncorrectedFramesOffset = 0x30
+	pmRxMinUncorrectedOffset    = 0x34
+	pmRxMaxUncorrectedOffset    = 0x38
+	// Link statistics, average, minimum and maximum of each parameter
+	pmCDOffset   = 0x80
+	pmLinkOffset = 0x8C
+)
+
+// NewCoherentPM parses upper pages 34h and 35h into a new CoherentPM instance
+func NewCoherentPM(raw [256]byte) *CoherentPM {
+	c := &CoherentPM{
+		RxBits:                    binary.BigEndian.Uint64(raw[pmRxBitsOffset:]),
+		RxBitsSubInterval:         binary.BigEndian.Uint64(raw[pmRxBitsSubIntervalOffset:]),
+		RxCorrectedBits:           binary.BigEndian.Uint64(raw[pmRxCorrectedBitsOffset:]),
+		RxMinCorrectedBitsSub:     binary.BigEndian.Uint64(raw[pmRxMinCorrectedBitsOffset:]),
+		RxMaxCorrectedBitsSub:     binary.BigEndian.Uint64(raw[pmRxMaxCorrectedBitsOffset:]),
+		RxFrames:                  binary.BigEndian.Uint32(raw[pmRxFramesOffset:]),
+		RxFramesSubInterval:       binary.BigEndian.Uint32(raw[pmRxFramesSubIntervalOffset:]),
+		RxUncorrectedFrames:       binary.BigEndian.Uint32(raw[pmRxUncorrectedFramesOffset:]),
+		RxMinUncorrectedFramesSub: binary.BigEndian.Uint32(raw[pmRxMinUncorrectedOffset:]),
+		RxMaxUncorrectedFramesSub: binary.BigEndian.Uint32(raw[pmRxMaxUncorrectedOffset:]),
+		CD: PMStatistic{
+			Average: float64(int32(binary.BigEndian.Uint32(raw[pmCDOffset:]))),
+			Min:     float64(int32(binary.BigEndian.Uint32(raw[pmCDOffset+4:]))),
+			Max:     float64(int32(binary.BigEndian.Uint32(raw[pmCDOffset+8:]))),
+		},
+	}
+
+	// 2 byte average, minimum and maximum per parameter, in the order of the list
+	for index, parameter := range []struct {
+		statistic *PMStatistic
+		signed    bool
+		scale     float64
+	}{
+		{&c.DGD, false, 0.01},
+		{&c.SOPMD, false, 0.01},
+		{&c.PDL, false, 0.1},
+		{&c.OSNR, false, 0.1},
+		{&c.ESNR, false, 0.1},
+		{&c.CFO, true, 1},
+		{&c.EVM, false, 100.0 / 65535},
+		{&c.TxPower, true, 0.01},
+		{&c.RxTotalPower, true, 0.01},
+		{&c.RxSignalPower, true, 0.01},
+		{&c.SOPROC, false, 1},
+		{&c.MER, false, 0.1},
+	} {
+		offset := pmLinkOffset + index*6
+		values := [3]float64{}
+		for i := range values {
+			if parameter.signed {
+				values[i] = float64(parseInt16(raw[offset+2*i], raw[offset+2*i+1])) * parameter.scale
+			} else {
+				values[i] = float64(parseUint16(raw[offset+2*i], raw[offset+2*i+1])) * parameter.scale
+			}
+		}
+		*parameter.statistic = PMStatistic{Average: values[0], Min: values[1], Max: values[2]}
+	}
+	return c
+}
+
+// PreFECBER returns the pre-FEC bit error rate of the current PM interval, 0 if no bits were received
+func (c *CoherentPM) PreFECBER() float64 {
+	if c.RxBits == 0 {
+		return 0
+	}
+	return float64(c.RxCorrectedBits) / float64(c.RxBits)
+}
+
+// IsCoherent returns true for modules with a tunable laser, i.e. coherent modules such as 400ZR
+func (e *EEPROM) IsCoherent() bool {
+	return e.MediaInterfaceTechnology == MediaInterfaceTechnologyCBandTunable ||
+		e.MediaInterfaceTechnology == MediaInterfaceTechnologyLBandTunable
+}
@@ -0,0 +1,62 @@
+package cmis
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// DataPathState state of a host lane's data path state machine, as defined in CMIS 5.2 section 6.3.3 and table 8-77
+type DataPathState byte
+
+const (
+	// DataPathStateDeactivated data path is deactivated, the transmitter is off
+	DataPathStateDeactivated DataPathState = 0x01
+	// DataPathStateInit data path is being initialized
+	DataPathStateInit DataPathState = 0x02
+	// DataPathStateDeinit data path is being deinitialized
+	DataPathStateDeinit DataPathState = 0x03
+	// DataPathStateActivated data path is activated, the transmitter is on
+	DataPathStateActivated DataPathState = 0x04
+	// DataPathStateTxTurnOn transmitter is being turned on
+	DataPathStateTxTurnOn DataPathState = 0x05
+	// DataPathStateTxTurnOff transmitter is being turned off
+	DataPathStateTxTurnOff DataPathState = 0x06
+	// DataPathStateInitialized data path is initialized, the transmitter is off
+	DataPathStateInitialized DataPathState = 0x07
+)
+
+func (d DataPathState) String() string {
+	str, found := map[DataPathState]string{
+		DataPathStateDeactivated: "DPDeactivated",
+		DataPathStateInit:        "DPInit",
+		DataPathStateDeinit:      "DPDeinit",
+		DataPathStateActivated:   "DPActivated",
+		DataPathStateTxTurnOn:    "DPTxTurnOn",
+		DataPathStateTxTurnOff:   "DPTxTurnOff",
+		DataPathStateInitialized: "DPInitialized",
+	}[d]
+	if found {
+		return str
+	}
+	return "Reserved"
+}
+
+// MarshalJSON implements the encoding/json/Marshaler interface's MarshalJSON function
+func (d DataPathState) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{
+		"ascii": d.String(),
+		"hex":   fmt.Sprintf("%#02X", byte(d)),
+	})
+}
+
+// DataPathStates data path state per host lane (upper page 11h bytes 128-131)
+type DataPathStates [MaxLanes]DataPathState
+
+// NewDataPathStates parses [4]byte, one nibble per lane starting with the lower nibble, into a new DataPathStates instance
+func NewDataPathStates(raw [4]byte) *DataPathStates {
+	d := &DataPathStates{}
+	for lane := 0; lane < MaxLanes; lane++ {
+		d[lane] = DataPathState(raw[lane/2] >> (4 * (lane % 2)) & 0x0F)
+	}
+	return d
+}
@@ -0,0 +1,364 @@
+package cmis
+
+import (
+	"errors"
+	"github.com/wobcom/go-ethtool/eeprom"
+	"github.com/wobcom/go-ethtool/eeprom/sff8024"
+)
+
+/* Memory offsets as defined in CMIS Rev 5.2 (April 27, 2022)
+ * Upper pages are laid out linearly, i.e. upper page n starts at 0x80 + n * 0x80 */
+const (
+	/* Lower Page 00h (Table 8-4) */
+
+	// Identifier (See SFF-8024 Transceiver Management)
+	identifierOffset = 0x00
+	// CMIS revision, major revision in the upper nibble
+	revisionComplianceOffset = 0x01
+	// Memory model (flat or paged) and configuration characteristics
+	memoryCharacteristicsOffset = 0x02
+	// Module state in bits 3-1
+	moduleStateOffset = 0x03
+	// Latched module flags, bit 0 is set if the module state changed
+	moduleFlagsOffset = 0x08
+	// Latched temperature (bits 3-0) and supply voltage (bits 7-4) monitor flags
+	temperatureFlagsOffset = 0x09
+	// Module monitors (temperature, supply voltage, aux monitors)
+	moduleMonitorsOffset = 0x0E
+	// Reason of the module being in ModuleStateFault
+	moduleFaultCauseOffset = 0x29
+	// Media type, determines the table media interface IDs refer to
+	mediaTypeOffset = 0x55
+	// Application descriptors 1 to 8 (4 bytes each)
+	applicationsStartOffset = 0x56
+	applicationsEndOffset   = 0x75
+
+	/* Upper Page 00h (Table 8-24) */
+
+	// Identifier Type of module (See SFF-8024 Transceiver Management)
+	// Note: Should read the same as identifierOffset
+	identifierOffset1 = 0x80
+
+	// Module vendor name (ASCII)
+	vendorNameStartOffset = 0x81
+	vendorNameEndOffset   = 0x90
+
+	// Module vendor IEEE company ID
+	vendorOuiOffset = 0x91
+
+	// Part number provided by module vendor (ASCII)
+	vendorPnStartOffset = 0x94
+	vendorPnEndOffset   = 0xA3
+
+	// Revision level for part number provided by vendor (ASCII)
+	vendorRevStartOffset = 0xA4
+	vendorRevEndOffset   = 0xA5
+
+	// Vendor serial number (ASCII)
+	vendorSnStartOffset = 0xA6
+	vendorSnEndOffset   = 0xB5
+
+	// Manufacturing date code
+	vendorDateCodeStartOffset = 0xB6
+	vendorDateCodeEndOffset   = 0xBD
+
+	// Common Language Equipment Identification code
+	cleiCodeStartOffset = 0xBE
+	cleiCodeEndOffset   = 0xC7
+
+	// Module power class in bits 7-5
+	modulePowerClassOffset = 0xC8
+
+	// Maximum power consumption in multiples of 0.25 W
+	maxPowerOffset = 0xC9
+
+	// Cable assembly length (bits 7-6 multiplier, bits 5-0 base value in m)
+	cableAssemblyLengthOffset = 0xCA
+
+	// Code for media connector type (See SFF-8024 Transceiver Management)
+	connectorTypeOffset = 0xCB
+
+	// Copper cable attenuation in dB at 5 GHz, 7 GHz, 12.9 GHz and 25.8 GHz
+	copperAttenuation5GHzOffset    = 0xCC
+	copperAttenuation7GHzOffset    = 0xCD
+	copperAttenuation12_9GHzOffset = 0xCE
+	copperAttenuation25_8GHzOffset = 0xCF
+
+	// Media lanes not supported by the module, one bit per lane
+	mediaLaneInformationOffset = 0xD2
+
+	// Media interface technology (Table 8-40)
+	mediaInterfaceTechnologyOffset = 0xD4
+
+	// Check code over bytes 128-221 of upper page 00h
+	page00hChecksumOffset = 0xDE
+
+	/* Upper Page 01h (Optional, not available for flat memory modules) */
+	page01hOffset = 0x100
+	// Check code over bytes 130-254 of upper page 01h
+	page01hChecksumOffset = page01hOffset + 0x7F
+	/* Upper Page 02h (Optional) */
+	thresholdsOffset = 0x180
+	// Check code over bytes 128-254 of upper page 02h
+	page02hChecksumOffset = thresholdsOffset + 0x7F
+	/* Upper Page 10h (Optional) */
+	laneControlsOffset = 0x880
+	// Staged control set 0 application select codes, one byte per host lane
+	stagedApplicationsOffset = laneControlsOffset + 0x11
+	/* Upper Page 11h (Optional) */
+	page11hOffset = 0x900
+	// Data path state per host lane, one nibble per lane
+	dataPathStatesOffset = page11hOffset
+	// Latched lane flags, one bit per lane
+	laneFlagsOffset    = page11hOffset + 0x06
+	laneMonitorsOffset = page11hOffset + 0x1A
+	// Active control set application select codes, one byte per host lane
+	activeApplicationsOffset = page11hOffset + 0x4E
+	page11hEndOffset         = page11hOffset + 0x80
+	/* Upper Page 12h (Optional, coherent modules) */
+	tunableLaserOffset = 0x980
+	/* Upper Pages 20h-2Fh (Optional) */
+	vdmOffset    = 0x1080
+	vdmEndOffset = vdmOffset + vdmLength
+	/* Upper Pages 34h-35h (Optional, coherent modules) */
+	coherentPMOffset    = 0x1A80
+	lowerAndPage00hSize = 0x100
+)
+
+// EEPROM implementation is based on CMIS Rev 5.2, covering QSFP-DD, OSFP, QSFP112 and other CMIS modules
+type EEPROM struct {
+	/* Lower Page */
+	Identifier        sff8024.Identifier
+	Revision          Revision
+	FlatMemory        bool
+	SteppedConfigOnly bool
+	ModuleState       ModuleState
+	// Latched flag, set if the module state changed since the flags have last been read
+	ModuleStateChanged bool
+	ModuleFaultCause   ModuleFaultCause
+	// Latched monitor flags, set if a threshold has been crossed since the flags have last been read
+	TemperatureFlags AlarmFlags
+	VoltageFlags     AlarmFlags
+	ModuleMonitors   *ModuleMonitors
+	MediaType        MediaType
+	// Advertised applications, including those of upper page 01h if available
+	Applications []Application
+
+	/* Upper Page 00h */
+	// Identifier "shall" be the same as Identifier
+	Identifier1 sff8024.Identifier
+	VendorName  string
+	VendorOUI   eeprom.OUI
+	VendorPN    string
+	VendorRev   string
+	VendorSN    string
+	DateCode    string
+	CLEICode    string
+	// Power class 1 to 8 as defined by CMIS
+	ModulePowerClass byte
+	// Maximum power consumption in watts
+	MaxPower float64
+	// Cable assembly length in meters, 0 for separable media
+	CableAssemblyLength      float64
+	ConnectorType            sff8024.ConnectorType
+	CopperAttenuation5GHz    byte
+	CopperAttenuation7GHz    byte
+	CopperAttenuation12_9GHz byte
+	CopperAttenuation25_8GHz byte
+	MediaLanesSupported      [MaxLanes]bool
+	MediaInterfaceTechnology MediaInterfaceTechnology
+
+	/* Upper Page 01h (optional) */
+	Advertising *Advertising
+
+	/* Upper Page 02h (optional) */
+	Thresholds *Thresholds
+
+	/* Upper Page 10h (optional) */
+	LaneControls *LaneControls
+	// Applications of staged control set 0, applied on the next data path initialization
+	StagedApplications *LaneApplications
+
+	/* Upper Page 11h (optional) */
+	DataPathStates *DataPathStates
+	LaneFlags      *LaneFlags
+	LaneMonitors   *LaneMonitors
+	// Applications of the active control set, see GetActiveDataPaths
+	ActiveApplications *LaneApplications
+
+	/* Upper Page 12h (optional, coherent modules) */
+	TunableLaser *TunableLaser
+
+	/* Upper Pages 20h-2Fh (optional) */
+	VDM *VDM
+
+	/* Upper Pages 34h-35h (optional, coherent modules) */
+	CoherentPM *CoherentPM
+
+	// Checksum errors found while parsing leniently
+	ChecksumErrors []*eeprom.ChecksumError
+	// Anomalies found while parsing, the affected fields have been decoded on a best-effort basis
+	ParseWarnings []eeprom.ParseWarning
+	// Names of the quirks applied after parsing, see eeprom.ApplyQuirks
+	AppliedQuirks []string
+	// Diagnostics reported as not available due to a quirk
+	IgnoredDiagnostics map[eeprom.Diagnostic]bool
+}
+
+// stringFields ASCII fields checked for anomalies while parsing
+var stringFields = []eeprom.StringField{
+	{Name: "VendorName", Start: vendorNameStartOffset, End: vendorNameEndOffset},
+	{Name: "VendorPN", Start: vendorPnStartOffset, End: vendorPnEndOffset},
+	{Name: "VendorRev", Start: vendorRevStartOffset, End: vendorRevEndOffset},
+	{Name: "VendorSN", Start: vendorSnStartOffset, End: vendorSnEndOffset},
+	{Name: "CLEICode", Start: cleiCodeStartOffset, End: cleiCodeEndOffset},
+}
+
+func init() {
+	eeprom.RegisterDecoder(eeprom.TypeCMIS, decode)
+}
+
+// decode parses raw into an eeprom.EEPROM, registered with eeprom.RegisterDecoder
+func decode(raw []byte, options *eeprom.ParseOptions) (eeprom.EEPROM, error) {
+	e, err := NewEEPROMWithOptions(raw, options)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// NewEEPROM parses a byte slice of at least length 256 into a new EEPROM instance.
+// Upper pages 01h, 02h, 10h, 11h, 20h-2Fh and, for coherent modules, 12h and 34h-35h are parsed
+// if raw is long enough to contain them. Checksum errors are recorded in ChecksumErrors.
+func NewEEPROM(raw []byte) (*EEPROM, error) {
+	return NewEEPROMWithOptions(raw, nil)
+}
+
+// NewEEPROMWithOptions parses a byte slice of at least length 256 into a new EEPROM instance,
+// failing on checksum errors if options are strict
+func NewEEPROMWithOptions(raw []byte, options *eeprom.ParseOptions) (*EEPROM, error) {
+	if len(raw) < lowerAndPage00hSize {
+		return nil, errors.New("CMIS requires EEPROM to be at least of 256 bytes length")
+	}
+
+	e := &EEPROM{
+		/* Lower Page */
+		Identifier:         sff8024.Identifier(raw[identifierOffset]),
+		Revision:           NewRevision(raw[revisionComplianceOffset]),
+		FlatMemory:         raw[memoryCharacteristicsOffset]&(1<<7) > 0,
+		SteppedConfigOnly:  raw[memoryCharacteristicsOffset]&(1<<6) > 0,
+		ModuleState:        ModuleState(raw[moduleStateOffset] >> 1 & 0x07),
+		ModuleStateChanged: raw[moduleFlagsOffset]&(1<<0) > 0,
+		ModuleFaultCause:   ModuleFaultCause(raw[moduleFaultCauseOffset]),
+		TemperatureFlags:   NewAlarmFlags(raw[temperatureFlagsOffset]),
+		VoltageFlags:       NewAlarmFlags(raw[temperatureFlagsOffset] >> 4),
+		ModuleMonitors: NewModuleMonitors([12]byte{
+			raw[moduleMonitorsOffset+0],
+			raw[moduleMonitorsOffset+1],
+			raw[moduleMonitorsOffset+2],
+			raw[moduleMonitorsOffset+3],
+			raw[moduleMonitorsOffset+4],
+			raw[moduleMonitorsOffset+5],
+			raw[moduleMonitorsOffset+6],
+			raw[moduleMonitorsOffset+7],
+			raw[moduleMonitorsOffset+8],
+			raw[moduleMonitorsOffset+9],
+			raw[moduleMonitorsOffset+10],
+			raw[moduleMonitorsOffset+11],
+		}),
+		MediaType: MediaType(raw[mediaTypeOffset]),
+
+		/* Upper Page 00h */
+		Identifier1: sff8024.Identifier(raw[identifierOffset1]),
+		VendorName:  parseString(raw[vendorNameStartOffset : vendorNameEndOffset+1]),
+		VendorOUI: eeprom.NewOUI([3]byte{
+			raw[vendorOuiOffset+0],
+			raw[vendorOuiOffset+1],
+			raw[vendorOuiOffset+2],
+		}),
+		VendorPN:                 parseString(raw[vendorPnStartOffset : vendorPnEndOffset+1]),
+		VendorRev:                parseString(raw[vendorRevStartOffset : vendorRevEndOffset+1]),
+		VendorSN:                 parseString(raw[vendorSnStartOffset : vendorSnEndOffset+1]),
+		DateCode:                 parseString(raw[vendorDateCodeStartOffset : vendorDateCodeEndOffset+1]),
+		CLEICode:                 parseString(raw[cleiCodeStartOffset : cleiCodeEndOffset+1]),
+		ModulePowerClass:         raw[modulePowerClassOffset]>>5 + 1,
+		MaxPower:                 float64(raw[maxPowerOffset]) * 0.25,
+		CableAssemblyLength:      parseLength(raw[cableAssemblyLengthOffset], [4]float64{0.1, 1, 10, 100}),
+		ConnectorType:            sff8024.ConnectorType(raw[connectorTypeOffset]),
+		CopperAttenuation5GHz:    raw[copperAttenuation5GHzOffset],
+		CopperAttenuation7GHz:    raw[copperAttenuation7GHzOffset],
+		CopperAttenuation12_9GHz: raw[copperAttenuation12_9GHzOffset],
+		CopperAttenuation25_8GHz: raw[copperAttenuation25_8GHzOffset],
+		MediaInterfaceTechnology: MediaInterfaceTechnology(raw[mediaInterfaceTechnologyOffset]),
+	}
+	for lane := 0; lane < MaxLanes; lane++ {
+		e.MediaLanesSupported[lane] = raw[mediaLaneInformationOffset]&(1<<lane) == 0
+	}
+
+	e.ParseWarnings = eeprom.CheckStringFields(raw, stringFields)
+	if warning := eeprom.CheckDateCode("DateCode", vendorDateCodeStartOffset, raw[vendorDateCodeStartOffset:vendorDateCodeEndOffset+1]); warning != nil {
+		e.ParseWarnings = append(e.ParseWarnings, *warning)
+	}
+
+	if err := eeprom.CheckChecksum(&e.ChecksumErrors, "page 00h bytes 128-221", raw[identifierOffset1:page00hChecksumOffset], raw[page00hChecksumOffset], options); err != nil {
+		return nil, err
+	}
+
+	applications := *(*[32]byte)(raw[applicationsStartOffset : applicationsEndOffset+1])
+	// flat memory modules only implement lower page and upper page 00h
+	if e.FlatMemory {
+		e.Applications = NewApplications(applications, nil, e.MediaType)
+		return e, nil
+	}
+
+	biasMultiplier := 1.0
+	/* Upper Page 01h (Optional) */
+	if len(raw) >= page01hOffset+0x80 {
+		if err := eeprom.CheckChecksum(&e.ChecksumErrors, "page 01h bytes 130-254", raw[page01hOffset+0x02:page01hChecksumOffset], raw[page01hChecksumOffset], options); err != nil {
+			return nil, err
+		}
+		e.Advertising = NewAdvertising(*(*[128]byte)(raw[page01hOffset : page01hOffset+0x80]))
+		biasMultiplier = e.Advertising.MonitorsImplemented.TxBiasMultiplier
+		e.Applications = NewApplications(applications, (*[128]byte)(raw[page01hOffset:page01hOffset+0x80]), e.MediaType)
+	} else {
+		e.Applications = NewApplications(applications, nil, e.MediaType)
+	}
+	/* Upper Page 02h (Optional) */
+	if len(raw) >= thresholdsOffset+0x80 {
+		if err := eeprom.CheckChecksum(&e.ChecksumErrors, "page 02h bytes 128-254", raw[thresholdsOffset:page02hChecksumOffset], raw[page02hChecksumOffset], options); err != nil {
+			return nil, err
+		}
+	}
+	if len(raw) >= thresholdsOffset+72 {
+		e.Thresholds = NewThresholds(*(*[72]byte)(raw[thresholdsOffset : thresholdsOffset+72]), biasMultiplier)
+	}
+	/* Upper Pages 10h and 11h (Optional) */
+	if len(raw) >= page11hEndOffset {
+		e.LaneControls = NewLaneControls(*(*[11]byte)(raw[laneControlsOffset : laneControlsOffset+11]))
+		e.StagedApplications = NewLaneApplications(*(*[8]byte)(raw[stagedApplicationsOffset : stagedApplicationsOffset+8]))
+		e.DataPathStates = NewDataPathStates(*(*[4]byte)(raw[dataPathStatesOffset : dataPathStatesOffset+4]))
+		e.LaneFlags = NewLaneFlags(*(*[19]byte)(raw[laneFlagsOffset : laneFlagsOffset+19]))
+		e.LaneMonitors = NewLaneMonitors(*(*[48]byte)(raw[laneMonitorsOffset : laneMonitorsOffset+48]), biasMultiplier)
+		e.ActiveApplications = NewLaneApplications(*(*[8]byte)(raw[activeApplicationsOffset : activeApplicationsOffset+8]))
+	}
+
+	/* Upper Page 12h (Optional) */
+	if e.IsCoherent() && len(raw) >= tunableLaserOffset+0x80 {
+		e.TunableLaser = NewTunableLaser(*(*[128]byte)(raw[tunableLaserOffset : tunableLaserOffset+0x80]))
+	}
+	/* Upper Pages 20h-2Fh (Optional) */
+	if e.Advertising != nil && e.Advertising.VDMSupported && len(raw) >= vdmEndOffset {
+		e.VDM = NewVDM(*(*[vdmLength]byte)(raw[vdmOffset:vdmEndOffset]))
+	}
+	/* Upper Pages 34h-35h (Optional) */
+	if e.IsCoherent() && len(raw) >= coherentPMOffset+0x100 {
+		e.CoherentPM = NewCoherentPM(*(*[256]byte)(raw[coherentPMOffset : coherentPMOffset+0x100]))
+	}
+
+	return e, nil
+}
+
+// IsCopper returns true for passive copper and active copper cable assemblies
+func (e *EEPROM) IsCopper() bool {
+	return e.MediaType == MediaTypePassiveCopper || e.MediaInterfaceTechnology.IsCopper()
+}
@@ -0,0 +1,167 @@
+package cmis
+
+import (
+	"github.com/wobcom/go-ethtool/eeprom"
+	"github.com/wobcom/go-ethtool/eeprom/sff8024"
+	"time"
+)
+
+// GetIdentifier implements eeprom.EEPROM interface's GetIdentifier function
+func (e *EEPROM) GetIdentifier() sff8024.Identifier {
+	return e.Identifier
+}
+
+// GetConnectorType implements eeprom.EEPROM interface's GetConnectorType function
+func (e *EEPROM) GetConnectorType() sff8024.ConnectorType {
+	return e.ConnectorType
+}
+
+// GetEncoding implements eeprom.EEPROM interface's GetEncoding function.
+// CMIS does not define a module wide encoding.
+func (e *EEPROM) GetEncoding() string {
+	return "Unspecified"
+}
+
+// GetPowerClass implements eeprom.EEPROM interface's GetPowerClass function.
+// The power class is derived from the module's maximum power, as CMIS power classes differ from SFF-8636 ones.
+func (e *EEPROM) GetPowerClass() eeprom.PowerClass {
+	for _, powerClass := range []eeprom.PowerClass{
+		eeprom.PowerClass1, eeprom.PowerClass2, eeprom.PowerClass3, eeprom.PowerClass4,
+		eeprom.PowerClass5, eeprom.PowerClass6, eeprom.PowerClass7,
+	} {
+		if e.MaxPower <= powerClass.GetMaxPower() {
+			return powerClass
+		}
+	}
+	return eeprom.PowerClass8
+}
+
+// GetSignalingRate implements eeprom.EEPROM interface's GetSignalingRate function.
+// CMIS does not define a nominal signaling rate, 0 is returned.
+func (e *EEPROM) GetSignalingRate() float64 {
+	return 0
+}
+
+// GetSupportedLinkLengths implements eeprom.EEPROM interface's GetSupportedLinkLengths function
+func (e *EEPROM) GetSupportedLinkLengths() map[string]float64 {
+	if e.IsCopper() || e.CableAssemblyLength > 0 {
+		return map[string]float64{
+			"copperOrDAC": e.CableAssemblyLength,
+		}
+	}
+	if e.Advertising == nil {
+		return map[string]float64{}
+	}
+	return map[string]float64{
+		"SMF": e.Advertising.LengthSMF,
+		"OM2": e.Advertising.LengthOM2,
+		"OM3": e.Advertising.LengthOM3,
+		"OM4": e.Advertising.LengthOM4,
+		"OM5": e.Advertising.LengthOM5,
+	}
+}
+
+// GetVendorName implements eeprom.EEPROM interface's GetVendorName function
+func (e *EEPROM) GetVendorName() string {
+	return e.VendorName
+}
+
+// GetVendorPN implements eeprom.EEPROM interface's GetVendorPN function
+func (e *EEPROM) GetVendorPN() string {
+	return e.VendorPN
+}
+
+// GetVendorRev implements eeprom.EEPROM interface's GetVendorRev function
+func (e *EEPROM) GetVendorRev() string {
+	return e.VendorRev
+}
+
+// GetVendorSN implements eeprom.EEPROM interface's GetVendorSN function
+func (e *EEPROM) GetVendorSN() string {
+	return e.VendorSN
+}
+
+// GetVendorOUI implements eeprom.EEPROM interface's GetVendorOUI function
+func (e *EEPROM) GetVendorOUI() eeprom.OUI {
+	return e.VendorOUI
+}
+
+// GetDateCode implements eeprom.EEPROM interface's GetDateCode function
+func (e *EEPROM) GetDateCode() time.Time {
+	t, _ := eeprom.ParseDateCode(e.DateCode)
+	return t
+}
+
+// GetParseWarnings implements eeprom.EEPROM interface's GetParseWarnings function
+func (e *EEPROM) GetParseWarnings() []eeprom.ParseWarning {
+	return e.ParseWarnings
+}
+
+// GetChecksumErrors implements eeprom.EEPROM interface's GetChecksumErrors function
+func (e *EEPROM) GetChecksumErrors() []*eeprom.ChecksumError {
+	return e.ChecksumErrors
+}
+
+// GetWavelength implements eeprom.EEPROM interface's GetWavelength function.
+// Tunable lasers report the wavelength of the first lane's current frequency.
+func (e *EEPROM) GetWavelength() float64 {
+	if e.TunableLaser != nil && e.TunableLaser[0].CurrentFrequency > 0 {
+		return e.TunableLaser[0].GetWavelength()
+	}
+	if e.Advertising == nil || e.IsCopper() {
+		return 0
+	}
+	return e.Advertising.Wavelength
+}
+
+// SupportsMonitoring implements eeprom.EEPROM interface's SupportsMonitoring function
+func (e *EEPROM) SupportsMonitoring() bool {
+	return e.Advertising != nil && (e.Advertising.MonitorsImplemented.TemperatureMonitoringImplemented ||
+		e.Advertising.MonitorsImplemented.SupplyVoltageMonitoringImplemented)
+}
+
+// GetModuleTemperature implements eeprom.EEPROM interface's GetModuleTemperature function
+func (e *EEPROM) GetModuleTemperature() (eeprom.Measurement, error) {
+	if e.IgnoredDiagnostics[eeprom.DiagnosticTemperature] {
+		return nil, eeprom.IgnoredDiagnosticError(eeprom.DiagnosticTemperature)
+	}
+	m := &Measurement{
+		Value:               e.ModuleMonitors.Temperature,
+		Unit:                "degrees celsius",
+		ThresholdsSupported: e.Thresholds != nil,
+		Flags:               &e.TemperatureFlags,
+	}
+
+	if e.Thresholds != nil {
+		m.Thresholds = &MeasurementThresholds{
+			HighAlarm:   e.Thresholds.Temperature.HighAlarm,
+			HighWarning: e.Thresholds.Temperature.HighWarning,
+			LowAlarm:    e.Thresholds.Temperature.LowAlarm,
+			LowWarning:  e.Thresholds.Temperature.LowWarning,
+		}
+	}
+	return m, nil
+}
+
+// GetModuleVoltage implements eeprom.EEPROM interface's GetModuleVoltage function
+func (e *EEPROM) GetModuleVoltage() (eeprom.Measurement, error) {
+	if e.IgnoredDiagnostics[eeprom.DiagnosticVoltage] {
+		return nil, eeprom.IgnoredDiagnosticError(eeprom.DiagnosticVoltage)
+	}
+	m := &Measurement{
+		Value:               e.ModuleMonitors.SupplyVoltage,
+		Unit:                "volts",
+		ThresholdsSupported: e.Thresholds != nil,
+		Flags:               &e.VoltageFlags,
+	}
+
+	if e.Thresholds != nil {
+		m.Thresholds = &MeasurementThresholds{
+			HighAlarm:   e.Thresholds.Voltage.HighAlarm,
+			HighWarning: e.Thresholds.Voltage.HighWarning,
+			LowAlarm:    e.Thresholds.Voltage.LowAlarm,
+			LowWarning:  e.Thresholds.Voltage.LowWarning,
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,385 @@
+package cmis
+
+import (
+	"encoding/json"
+	"github.com/wobcom/go-ethtool/eeprom"
+	"math"
+	"testing"
+	"time"
+)
+
+// getEEPROMRaw builds a 400G-DR4 QSFP-DD module with pages 01h, 02h, 10h and 11h
+func getEEPROMRaw() []byte {
+	raw := make([]byte, page11hEndOffset)
+	raw[identifierOffset] = 0x18
+	raw[revisionComplianceOffset] = 0x50
+	raw[moduleStateOffset] = byte(ModuleStateReady) << 1
+	// 45.5 degrees celsius, 3.3 V
+	copy(raw[moduleMonitorsOffset:], []byte{0x2D, 0x80, 0x80, 0xE8})
+	raw[mediaTypeOffset] = byte(MediaTypeSMF)
+
+	raw[identifierOffset1] = 0x18
+	copy(raw[vendorNameStartOffset:], "ACME OPTICS     ")
+	copy(raw[vendorOuiOffset:], []byte{0x00, 0x90, 0x65})
+	copy(raw[vendorPnStartOffset:], "QDD-400G-DR4    ")
+	copy(raw[vendorRevStartOffset:], "A0")
+	copy(raw[vendorSnStartOffset:], "SN12345678      ")
+	copy(raw[vendorDateCodeStartOffset:], "221231  ")
+	raw[modulePowerClassOffset] = 5 << 5
+	raw[maxPowerOffset] = 48
+	raw[connectorTypeOffset] = 0x0C
+	raw[mediaLaneInformationOffset] = 0xF0
+	raw[mediaInterfaceTechnologyOffset] = byte(MediaInterfaceTechnology1310nmEML)
+	// 400GAUI-8 / 400GBASE-DR4 and 100GAUI-2 / 100GBASE-DR breakout
+	copy(raw[applicationsStartOffset:], []byte{0x11, 0x1C, 0x84, 0x01, 0x0D, 0x14, 0x21, 0x55, 0xFF})
+
+	page01h := raw[page01hOffset:]
+	page01h[activeFirmwareVersionOffset] = 2
+	page01h[activeFirmwareVersionOffset+1] = 7
+	page01h[lengthSmfOffset] = 0b00000101
+	// 1311 nm
+	copy(page01h[wavelengthOffset:], []byte{0x66, 0x6C})
+	page01h[monitorsImplementedOffset] = 0x03
+	// bias, tx and rx power monitors, bias multiplier 2
+	page01h[monitorsImplementedOffset+1] = 0x0F
+	copy(page01h[0x30:], []byte{0x01, 0x0F})
+
+	// TxBias high alarm 50 mA (before multiplier)
+	copy(raw[thresholdsOffset+0x38:], []byte{0x61, 0xA8})
+
+	raw[laneControlsOffset] = 0x02
+	// lanes 1-4 activated, lanes 5-8 deactivated
+	copy(raw[dataPathStatesOffset:], []byte{0x44, 0x44, 0x11, 0x11})
+	// Rx loss of signal on lane 3
+	raw[laneFlagsOffset+0x0D] = 0x04
+	// 4x100G, one data path per host lane pair
+	for lane := 0; lane < MaxLanes; lane++ {
+		raw[activeApplicationsOffset+lane] = 2<<4 | byte(lane&^1)<<1
+	}
+	// lane 1 Tx power 1 mW, bias 20 mA (before multiplier), Rx power 0.5 mW
+	copy(raw[laneMonitorsOffset:], []byte{0x27, 0x10})
+	copy(raw[laneMonitorsOffset+0x10:], []byte{0x27, 0x10})
+	copy(raw[laneMonitorsOffset+0x20:], []byte{0x13, 0x88})
+	return raw
+}
+
+func TestParseEEPROM(t *testing.T) {
+	e, err := NewEEPROM(getEEPROMRaw())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	var _ eeprom.EEPROM = e
+
+	if e.GetIdentifier().String() != "QSFP-DD" {
+		t.Errorf("Unexpected identifier %s", e.GetIdentifier())
+	}
+	if e.Revision.String() != "5.0" {
+		t.Errorf("Unexpected revision %s", e.Revision)
+	}
+	if e.GetVendorName() != "ACME OPTICS" || e.GetVendorPN() != "QDD-400G-DR4" || e.GetVendorSN() != "SN12345678" {
+		t.Errorf("Unexpected vendor information %s %s %s", e.GetVendorName(), e.GetVendorPN(), e.GetVendorSN())
+	}
+	if e.GetVendorOUI().String() != "00:90:65" {
+		t.Errorf("Unexpected OUI %s", e.GetVendorOUI())
+	}
+	expectedDate, _ := time.Parse("060102", "221231")
+	if e.GetDateCode() != expectedDate {
+		t.Errorf("Unexpected date code %s", e.GetDateCode())
+	}
+	if e.GetConnectorType().String() != "MPO Parallel Optic" {
+		t.Errorf("Unexpected connector type %s", e.GetConnectorType())
+	}
+	if e.ModulePowerClass != 6 || e.MaxPower != 12 || e.GetPowerClass() != eeprom.PowerClass8 {
+		t.Errorf("Unexpected power class %d, max power %f", e.ModulePowerClass, e.MaxPower)
+	}
+	if e.Advertising.ActiveFirmwareVersion.String() != "2.7" {
+		t.Errorf("Unexpected firmware version %s", e.Advertising.ActiveFirmwareVersion)
+	}
+	if e.GetSupportedLinkLengths()["SMF"] != 500 {
+		t.Errorf("Unexpected SMF link length %f", e.GetSupportedLinkLengths()["SMF"])
+	}
+	if e.GetWavelength() != 1311 {
+		t.Errorf("Unexpected wavelength %f", e.GetWavelength())
+	}
+	if !e.SupportsMonitoring() {
+		t.Error("Expected monitoring support")
+	}
+	temperature, _ := e.GetModuleTemperature()
+	voltage, _ := e.GetModuleVoltage()
+	if temperature.GetValue() != 45.5 || voltage.GetValue() != 3.3 {
+		t.Errorf("Unexpected module monitors %f, %f", temperature.GetValue(), voltage.GetValue())
+	}
+	if !e.LaneControls[1].DataPathDeinit || e.LaneControls[0].DataPathDeinit {
+		t.Errorf("Unexpected lane controls %+v", e.LaneControls)
+	}
+
+	lasers := e.GetLasers()
+	if len(lasers) != 4 {
+		t.Fatalf("Expected 4 lasers, got %d", len(lasers))
+	}
+	bias, _ := lasers[0].GetBias()
+	if bias.GetValue() != 40 {
+		t.Errorf("Expected bias of 40 mA, got %f", bias.GetValue())
+	}
+	thresholds, err := bias.GetAlarmThresholds()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if thresholds.GetHighAlarm() != 100 {
+		t.Errorf("Expected bias high alarm of 100 mA, got %f", thresholds.GetHighAlarm())
+	}
+	txPower, _ := lasers[0].GetTxPower()
+	rxPower, _ := lasers[0].GetRxPower()
+	if txPower.GetValue() != 1 || rxPower.GetValue() != 0.5 {
+		t.Errorf("Unexpected power readings %f, %f", txPower.GetValue(), rxPower.GetValue())
+	}
+}
+
+func TestParseEEPROMFlatMemory(t *testing.T) {
+	raw := getEEPROMRaw()
+	raw[memoryCharacteristicsOffset] = 1 << 7
+	raw[mediaTypeOffset] = byte(MediaTypePassiveCopper)
+	raw[cableAssemblyLengthOffset] = 0b01000011
+
+	e, err := NewEEPROM(raw[:lowerAndPage00hSize])
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if e.Advertising != nil || e.LaneMonitors != nil {
+		t.Error("Flat memory modules must not have upper pages")
+	}
+	if e.GetSupportedLinkLengths()["copperOrDAC"] != 3 {
+		t.Errorf("Unexpected cable length %f", e.GetSupportedLinkLengths()["copperOrDAC"])
+	}
+	if len(e.GetLasers()) != 0 || e.SupportsMonitoring() {
+		t.Error("Passive copper cable must not report lasers or monitoring")
+	}
+}
+
+func TestParseEEPROMTooShort(t *testing.T) {
+	if _, err := NewEEPROM(make([]byte, 128)); err == nil {
+		t.Error("Expected an error for a 128 byte EEPROM")
+	}
+}
+
+func TestJsonMarshal(t *testing.T) {
+	e, err := NewEEPROM(getEEPROMRaw())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := json.Marshal(e); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestParseApplications(t *testing.T) {
+	e, err := NewEEPROM(getEEPROMRaw())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if len(e.Applications) != 2 {
+		t.Fatalf("Expected 2 applications, got %d", len(e.Applications))
+	}
+	breakout := e.Applications[1]
+	if breakout.ApplicationSelectCode != 2 || breakout.HostLaneCount != 2 || breakout.MediaLaneCount != 1 ||
+		breakout.HostLaneAssignmentOptions != 0x55 || breakout.MediaLaneAssignmentOptions != 0x0F {
+		t.Errorf("Unexpected application %+v", breakout)
+	}
+	if breakout.String() != "2: 100GAUI-2 C2M (Annex 135G) (2 host lanes) / 100GBASE-DR (Clause 140) (1 media lanes)" {
+		t.Errorf("Unexpected application string %s", breakout)
+	}
+	if spec, _ := breakout.MediaInterfaceID.Specification(); spec.LaneSignalingRate != 53.125 || spec.Modulation != "PAM4" {
+		t.Errorf("Unexpected media interface specification %+v", spec)
+	}
+
+	dataPaths := e.GetActiveDataPaths()
+	if len(dataPaths) != 4 {
+		t.Fatalf("Expected 4 data paths, got %d", len(dataPaths))
+	}
+	for index, dataPath := range dataPaths {
+		if int(dataPath.ID) != index*2 || len(dataPath.HostLanes) != 2 || dataPath.Application == nil ||
+			dataPath.Application.ApplicationSelectCode != 2 {
+			t.Errorf("Unexpected data path %+v", dataPath)
+		}
+	}
+}
+
+func TestModuleAndDataPathStates(t *testing.T) {
+	raw := getEEPROMRaw()
+	e, err := NewEEPROM(raw)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	var _ eeprom.FaultReporter = e
+
+	if e.ModuleState != ModuleStateReady || len(e.GetModuleFaults()) != 0 {
+		t.Errorf("Unexpected module state %s, faults %v", e.ModuleState, e.GetModuleFaults())
+	}
+	if e.DataPathStates[0] != DataPathStateActivated || e.DataPathStates[5] != DataPathStateDeactivated {
+		t.Errorf("Unexpected data path states %v", e.DataPathStates)
+	}
+	laneFaults := e.GetLaneFaults()
+	if len(laneFaults) != 1 || len(laneFaults[2]) != 1 || laneFaults[2][0] != "Rx loss of signal" {
+		t.Errorf("Unexpected lane faults %v", laneFaults)
+	}
+
+	raw[moduleStateOffset] = byte(ModuleStateFault) << 1
+	raw[moduleFaultCauseOffset] = byte(ModuleFaultCauseTECRunaway)
+	e, err = NewEEPROM(raw)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if faults := e.GetModuleFaults(); len(faults) != 1 || faults[0] != "Module fault: TEC runaway" {
+		t.Errorf("Unexpected module faults %v", faults)
+	}
+}
+
+func TestModuleFlags(t *testing.T) {
+	raw := getEEPROMRaw()
+	// Vcc high warning and temperature low alarm, Aux1 and Aux2 flags in the following byte must not be decoded as Vcc flags
+	raw[temperatureFlagsOffset] = 0x42
+	raw[temperatureFlagsOffset+1] = 0xFF
+	e, err := NewEEPROM(raw)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	expectedVoltageFlags := AlarmFlags{HighWarning: true}
+	if e.VoltageFlags != expectedVoltageFlags {
+		t.Errorf("Unexpected voltage flags %+v", e.VoltageFlags)
+	}
+	expectedTemperatureFlags := AlarmFlags{LowAlarm: true}
+	if e.TemperatureFlags != expectedTemperatureFlags {
+		t.Errorf("Unexpected temperature flags %+v", e.TemperatureFlags)
+	}
+
+	voltage, err := e.GetModuleVoltage()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	flags, err := voltage.GetAlarmFlags()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !flags.GetHighWarning() || flags.GetHighAlarm() || flags.GetLowAlarm() || flags.GetLowWarning() {
+		t.Errorf("Unexpected voltage alarm flags %+v", flags)
+	}
+}
+
+func TestParseVDM(t *testing.T) {
+	raw := append(getEEPROMRaw(), make([]byte, vdmEndOffset-page11hEndOffset)...)
+	raw[page01hOffset+pagesSupportedOffset] = 1 << 6
+	vdm := raw[vdmOffset:]
+	// current pre-FEC BER on lane 1 using threshold set 0, laser temperature on lane 2 using threshold set 1
+	copy(vdm[vdmDescriptorsOffset:], []byte{0x00, byte(VDMObservablePreFECBERCurrentMedia), 0x11, byte(VDMObservableLaserTemperature)})
+	// 2.4e-4, 45 degrees celsius
+	copy(vdm[vdmSamplesOffset:], []byte{0x90, 0xF0, 0x2D, 0x00})
+	// high alarm 1.2e-3
+	copy(vdm[vdmThresholdsOffset:], []byte{0x98, 0x78})
+	// high alarm 75 degrees celsius
+	copy(vdm[vdmThresholdsOffset+vdmThresholdSetLength:], []byte{0x4B, 0x00})
+
+	e, err := NewEEPROM(raw)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if e.VDM == nil || e.VDM.Groups != 1 || len(e.VDM.Observables) != 2 {
+		t.Fatalf("Unexpected VDM %+v", e.VDM)
+	}
+
+	var ber eeprom.Measurement = &e.VDM.Find(VDMObservablePreFECBERCurrentMedia)[0]
+	thresholds, err := ber.GetAlarmThresholds()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if math.Abs(ber.GetValue()-2.4e-4) > 1e-12 || math.Abs(thresholds.GetHighAlarm()-1.2e-3) > 1e-12 {
+		t.Errorf("Unexpected pre-FEC BER %g, high alarm %g", ber.GetValue(), thresholds.GetHighAlarm())
+	}
+
+	laserTemperature := e.VDM.Find(VDMObservableLaserTemperature)[0]
+	if laserTemperature.Lane != 1 || laserTemperature.Value != 45 || laserTemperature.Thresholds.HighAlarm != 75 ||
+		laserTemperature.GetUnit() != "degrees celsius" {
+		t.Errorf("Unexpected laser temperature %+v", laserTemperature)
+	}
+}
+
+func TestNewCDBRequest(t *testing.T) {
+	request, err := NewCDBRequest(CDBCommandWriteFirmwareBlockLPL, []byte{0x00, 0x00, 0x00, 0x10, 0xAA}, 0)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expected := []byte{0x01, 0x03, 0x00, 0x00, 0x05, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0xAA}
+	// ones' complement of 0x01 + 0x03 + 0x05 + 0x10 + 0xAA
+	expected[5] = 0x3C
+	if string(request) != string(expected) {
+		t.Errorf("Unexpected request % x", request)
+	}
+
+	if _, err := NewCDBRequest(CDBCommandWriteFirmwareBlockLPL, make([]byte, CDBMaxLPLLength+1), 0); err == nil {
+		t.Error("Expected error for oversized local payload")
+	}
+}
+
+func TestNewFirmwareInfo(t *testing.T) {
+	rpl := make([]byte, 110)
+	// image A running and committed, image B invalid
+	rpl[0] = 0x43
+	rpl[1] = 0x03
+	copy(rpl[2:], []byte{0x02, 0x07, 0x01, 0x2C})
+	copy(rpl[6:], "release")
+
+	info, err := NewFirmwareInfo(rpl)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !info.ImageA.Running || !info.ImageA.Committed || info.ImageA.Version.String() != "2.7" ||
+		info.ImageA.Build != 300 || info.ImageA.ExtraInfo != "release" {
+		t.Errorf("Unexpected image A %+v", info.ImageA)
+	}
+	if !info.ImageB.Present || !info.ImageB.Invalid || info.ImageB.Running || info.Factory.Present {
+		t.Errorf("Unexpected image B %+v or factory image %+v", info.ImageB, info.Factory)
+	}
+}
+
+func TestParseCoherent(t *testing.T) {
+	raw := append(getEEPROMRaw(), make([]byte, coherentPMOffset+0x100-page11hEndOffset)...)
+	raw[mediaInterfaceTechnologyOffset] = byte(MediaInterfaceTechnologyCBandTunable)
+	raw[page01hOffset+pagesSupportedOffset] = 1 << 6
+	// 100 GHz grid, 193.1 THz
+	raw[tunableLaserOffset+gridSpacingOffset] = 0x50
+	copy(raw[tunableLaserOffset+currentFrequencyOffset:], []byte{0x0B, 0x82, 0x78, 0xE0})
+	raw[tunableLaserOffset+tuningStatusOffset] = 0x01
+	// OSNR of 35 dB on lane 1 with its high alarm flag set
+	vdm := raw[vdmOffset:]
+	copy(vdm[vdmDescriptorsOffset:], []byte{0x00, byte(VDMObservableOSNR)})
+	copy(vdm[vdmSamplesOffset:], []byte{0x01, 0x5E})
+	vdm[vdmFlagsOffset] = 0x01
+	// 10^12 bits received, 10^9 corrected, average OSNR 35 dB
+	pm := raw[coherentPMOffset:]
+	copy(pm[pmRxBitsOffset:], []byte{0x00, 0x00, 0x00, 0xE8, 0xD4, 0xA5, 0x10, 0x00})
+	copy(pm[pmRxCorrectedBitsOffset:], []byte{0x00, 0x00, 0x00, 0x00, 0x3B, 0x9A, 0xCA, 0x00})
+	copy(pm[pmLinkOffset+3*6:], []byte{0x01, 0x5E})
+
+	e, err := NewEEPROM(raw)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !e.IsCoherent() || e.TunableLaser == nil || e.CoherentPM == nil || e.VDM == nil {
+		t.Fatalf("Expected coherent module with tunable laser, PM and VDM pages")
+	}
+	laser := e.TunableLaser[0]
+	if laser.GridSpacing != 100 || laser.CurrentFrequency != 193100 || !laser.WavelengthUnlocked || laser.TuningInProgress {
+		t.Errorf("Unexpected tunable laser %+v", laser)
+	}
+	if math.Abs(e.GetWavelength()-1552.52) > 0.01 {
+		t.Errorf("Unexpected wavelength %f", e.GetWavelength())
+	}
+	osnr := e.VDM.Find(VDMObservableOSNR)
+	if len(osnr) != 1 || math.Abs(osnr[0].Value-35) > 1e-9 || !osnr[0].Flags.HighAlarm || osnr[0].Flags.LowAlarm {
+		t.Errorf("Unexpected OSNR observable %+v", osnr)
+	}
+	if math.Abs(e.CoherentPM.PreFECBER()-1e-3) > 1e-12 || math.Abs(e.CoherentPM.OSNR.Average-35) > 1e-9 {
+		t.Errorf("Unexpected PM, pre-FEC BER %g, OSNR %+v", e.CoherentPM.PreFECBER(), e.CoherentPM.OSNR)
+	}
+}
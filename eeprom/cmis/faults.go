@@ -0,0 +1,46 @@
+package cmis
+
+import (
+	"fmt"
+)
+
+// GetModuleFaults implements eeprom.FaultReporter interface's GetModuleFaults function
+func (e *EEPROM) GetModuleFaults() []string {
+	faults := []string{}
+	if e.ModuleState == ModuleStateFault {
+		faults = append(faults, fmt.Sprintf("Module fault: %s", e.ModuleFaultCause))
+	}
+	return faults
+}
+
+// GetLaneFaults implements eeprom.FaultReporter interface's GetLaneFaults function.
+// Lane faults are only reported if upper page 11h is available.
+func (e *EEPROM) GetLaneFaults() map[int][]string {
+	ret := make(map[int][]string)
+	if e.LaneFlags == nil {
+		return ret
+	}
+
+	for lane, flags := range e.LaneFlags {
+		faults := []string{}
+		for _, flag := range []struct {
+			set  bool
+			name string
+		}{
+			{flags.TxFault, "Tx fault"},
+			{flags.TxLOS, "Tx loss of signal"},
+			{flags.TxCDRLOL, "Tx CDR loss of lock"},
+			{flags.TxAdaptiveEqFault, "Tx adaptive equalization fault"},
+			{flags.RxLOS, "Rx loss of signal"},
+			{flags.RxCDRLOL, "Rx CDR loss of lock"},
+		} {
+			if flag.set {
+				faults = append(faults, flag.name)
+			}
+		}
+		if len(faults) > 0 {
+			ret[lane] = faults
+		}
+	}
+	return ret
+}
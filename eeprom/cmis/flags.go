@@ -0,0 +1,65 @@
+package cmis
+
+// AlarmFlags latched threshold crossing flags of a monitor, implements eeprom.AlarmFlags
+type AlarmFlags struct {
+	HighAlarm   bool
+	LowAlarm    bool
+	HighWarning bool
+	LowWarning  bool
+}
+
+// NewAlarmFlags parses the lower nibble of a byte (bit 0 high alarm, bit 1 low alarm, bit 2 high warning, bit 3 low warning)
+// into a new AlarmFlags instance, as used for module and VDM flags
+func NewAlarmFlags(raw byte) AlarmFlags {
+	return AlarmFlags{
+		HighAlarm:   raw&0x01 > 0,
+		LowAlarm:    raw&0x02 > 0,
+		HighWarning: raw&0x04 > 0,
+		LowWarning:  raw&0x08 > 0,
+	}
+}
+
+// GetHighAlarm implements eeprom.AlarmFlags interface's GetHighAlarm function
+func (a *AlarmFlags) GetHighAlarm() bool {
+	return a.HighAlarm
+}
+
+// GetHighWarning implements eeprom.AlarmFlags interface's GetHighWarning function
+func (a *AlarmFlags) GetHighWarning() bool {
+	return a.HighWarning
+}
+
+// GetLowAlarm implements eeprom.AlarmFlags interface's GetLowAlarm function
+func (a *AlarmFlags) GetLowAlarm() bool {
+	return a.LowAlarm
+}
+
+// GetLowWarning implements eeprom.AlarmFlags interface's GetLowWarning function
+func (a *AlarmFlags) GetLowWarning() bool {
+	return a.LowWarning
+}
+
+// GetTxFault implements eeprom.LaneFlags interface's GetTxFault function
+func (l *LaneFlag) GetTxFault() bool {
+	return l.TxFault
+}
+
+// GetTxLOS implements eeprom.LaneFlags interface's GetTxLOS function
+func (l *LaneFlag) GetTxLOS() bool {
+	return l.TxLOS
+}
+
+// GetTxLOL implements eeprom.LaneFlags interface's GetTxLOL function
+func (l *LaneFlag) GetTxLOL() bool {
+	return l.TxCDRLOL
+}
+
+// GetRxLOS implements eeprom.LaneFlags interface's GetRxLOS function
+func (l *LaneFlag) GetRxLOS() bool {
+	return l.RxLOS
+}
+
+// GetRxLOL implements eeprom.LaneFlags interface's GetRxLOL function
+func (l *LaneFlag) GetRxLOL() bool {
+	return l.RxCDRLOL
+}
@@ -0,0 +1,26 @@
+package cmis
+
+// LaneControls per lane data path and signal controls, as defined in CMIS 5.2 table 8-70 (upper page 10h)
+type LaneControls [MaxLanes]LaneControl
+
+// LaneControl controls of a single lane
+type LaneControl struct {
+	// Data path of this lane is requested to be deactivated
+	DataPathDeinit bool
+	// Media side transmitter output is disabled
+	TxOutputDisabled bool
+	// Host side receiver output is disabled
+	RxOutputDisabled bool
+}
+
+// NewLaneControls parses [11]byte into a new LaneControls instance
+func NewLaneControls(raw [11]byte) *LaneControls {
+	l := &LaneControls{}
+
+	for lane := 0; lane < MaxLanes; lane++ {
+		l[lane].DataPathDeinit = raw[0x00]&(1<<lane) > 0
+		l[lane].TxOutputDisabled = raw[0x02]&(1<<lane) > 0
+		l[lane].RxOutputDisabled = raw[0x0A]&(1<<lane) > 0
+	}
+	return l
+}
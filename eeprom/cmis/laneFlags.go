@@ -0,0 +1,67 @@
+package cmis
+
+// LaneFlags per lane latched flags, as defined in CMIS 5.2 table 8-78 (upper page 11h bytes 134-152).
+// The flags are cleared on read, so they reflect events since they have last been read.
+type LaneFlags [MaxLanes]LaneFlag
+
+// LaneFlag flags of a single lane
+type LaneFlag struct {
+	DataPathStateChanged bool
+	TxFault              bool
+	TxLOS                bool
+	TxCDRLOL             bool
+	TxAdaptiveEqFault    bool
+	RxLOS                bool
+	RxCDRLOL             bool
+	TxPower              AlarmFlags
+	TxBias               AlarmFlags
+	RxPower              AlarmFlags
+}
+
+var laneFlagsMemoryMap = map[uint]func(*LaneFlag, bool){
+	0x00: func(l *LaneFlag, flag bool) {
+		l.DataPathStateChanged = flag
+	},
+	0x01: func(l *LaneFlag, flag bool) {
+		l.TxFault = flag
+	},
+	0x02: func(l *LaneFlag, flag bool) {
+		l.TxLOS = flag
+	},
+	0x03: func(l *LaneFlag, flag bool) {
+		l.TxCDRLOL = flag
+	},
+	0x04: func(l *LaneFlag, flag bool) {
+		l.TxAdaptiveEqFault = flag
+	},
+	0x05: func(l *LaneFlag, flag bool) { l.TxPower.HighAlarm = flag },
+	0x06: func(l *LaneFlag, flag bool) { l.TxPower.LowAlarm = flag },
+	0x07: func(l *LaneFlag, flag bool) { l.TxPower.HighWarning = flag },
+	0x08: func(l *LaneFlag, flag bool) { l.TxPower.LowWarning = flag },
+	0x09: func(l *LaneFlag, flag bool) { l.TxBias.HighAlarm = flag },
+	0x0A: func(l *LaneFlag, flag bool) { l.TxBias.LowAlarm = flag },
+	0x0B: func(l *LaneFlag, flag bool) { l.TxBias.HighWarning = flag },
+	0x0C: func(l *LaneFlag, flag bool) { l.TxBias.LowWarning = flag },
+	0x0D: func(l *LaneFlag, flag bool) {
+		l.RxLOS = flag
+	},
+	0x0E: func(l *LaneFlag, flag bool) {
+		l.RxCDRLOL = flag
+	},
+	0x0F: func(l *LaneFlag, flag bool) { l.RxPower.HighAlarm = flag },
+	0x10: func(l *LaneFlag, flag bool) { l.RxPower.LowAlarm = flag },
+	0x11: func(l *LaneFlag, flag bool) { l.RxPower.HighWarning = flag },
+	0x12: func(l *LaneFlag, flag bool) { l.RxPower.LowWarning = flag },
+}
+
+// NewLaneFlags parses [19]byte, one bit per lane, into a new LaneFlags instance
+func NewLaneFlags(raw [19]byte) *LaneFlags {
+	l := &LaneFlags{}
+
+	for byteIndex, callback := range laneFlagsMemoryMap {
+		for lane := 0; lane < MaxLanes; lane++ {
+			callback(&l[lane], raw[byteIndex]&(1<<lane) > 0)
+		}
+	}
+	return l
+}
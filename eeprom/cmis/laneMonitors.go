@@ -0,0 +1,26 @@
+package cmis
+
+// MaxLanes number of lanes addressable by a single bank
+const MaxLanes = 8
+
+// LaneMonitors real-time monitoring of the media lanes, as defined in CMIS 5.2 table 8-81 (upper page 11h)
+type LaneMonitors [MaxLanes]LaneMonitor
+
+// LaneMonitor real-time monitoring data of a single media lane
+type LaneMonitor struct {
+	TxPower Power
+	Bias    float64
+	RxPower Power
+}
+
+// NewLaneMonitors parses [48]byte into a new LaneMonitors instance, the Tx bias is scaled by biasMultiplier
+func NewLaneMonitors(raw [48]byte, biasMultiplier float64) *LaneMonitors {
+	l := &LaneMonitors{}
+
+	for lane := 0; lane < MaxLanes; lane++ {
+		l[lane].TxPower = parsePower(raw[0x00+2*lane], raw[0x01+2*lane])
+		l[lane].Bias = parseCurrent(raw[0x10+2*lane], raw[0x11+2*lane]) * biasMultiplier
+		l[lane].RxPower = parsePower(raw[0x20+2*lane], raw[0x21+2*lane])
+	}
+	return l
+}
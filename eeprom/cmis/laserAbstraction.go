@@ -0,0 +1,192 @@
+package cmis
+
+import (
+	"errors"
+	"github.com/wobcom/go-ethtool/eeprom"
+)
+
+// Laser a helper struct for implementing eeprom.Laser interface
+type Laser struct {
+	RxPower *Measurement
+	TxPower *Measurement
+	Bias    *Measurement
+	Flags   *LaneFlag
+}
+
+// Measurement a helper struct for implementing eeprom.Laser interface
+type Measurement struct {
+	Value               float64
+	Unit                string
+	ThresholdsSupported bool
+	Thresholds          *MeasurementThresholds
+	Flags               *AlarmFlags
+}
+
+// MeasurementThresholds a helper struct for implementing eeprom.Laser interface
+type MeasurementThresholds struct {
+	HighAlarm   float64
+	HighWarning float64
+	LowAlarm    float64
+	LowWarning  float64
+}
+
+// SupportsMonitoring implements eeprom.Laser interface's SupportsMonitoring function
+func (l *Laser) SupportsMonitoring() bool {
+	return true
+}
+
+// GetBias implements eeprom.Laser interface's GetBias function
+func (l *Laser) GetBias() (eeprom.Measurement, error) {
+	if l.Bias == nil {
+		return nil, eeprom.IgnoredDiagnosticError(eeprom.DiagnosticBias)
+	}
+	return l.Bias, nil
+}
+
+// GetTxPower implements eeprom.Laser interface's GetTxPower function
+func (l *Laser) GetTxPower() (eeprom.Measurement, error) {
+	if l.TxPower == nil {
+		return nil, eeprom.IgnoredDiagnosticError(eeprom.DiagnosticTxPower)
+	}
+	return l.TxPower, nil
+}
+
+// GetRxPower implements eeprom.Laser interface's GetRxPower function
+func (l *Laser) GetRxPower() (eeprom.Measurement, error) {
+	if l.RxPower == nil {
+		return nil, eeprom.IgnoredDiagnosticError(eeprom.DiagnosticRxPower)
+	}
+	return l.RxPower, nil
+}
+
+// SupportsLaneFlags implements eeprom.Laser interface's SupportsLaneFlags function
+func (l *Laser) SupportsLaneFlags() bool {
+	return l.Flags != nil
+}
+
+// GetLaneFlags implements eeprom.Laser interface's GetLaneFlags function
+func (l *Laser) GetLaneFlags() (eeprom.LaneFlags, error) {
+	if !l.SupportsLaneFlags() {
+		return nil, errors.New("No lane flags available, upper page 11h is missing")
+	}
+	return l.Flags, nil
+}
+
+// GetValue implements eeprom.Measurement interface's GetValue function
+func (m *Measurement) GetValue() float64 {
+	return m.Value
+}
+
+// GetUnit implements eeprom.Measurement interface's GetUnit function
+func (m *Measurement) GetUnit() string {
+	return m.Unit
+}
+
+// SupportsThresholds implements eeprom.Measurement interface's SupportsThresholds function
+func (m *Measurement) SupportsThresholds() bool {
+	return m.ThresholdsSupported
+}
+
+// GetAlarmThresholds implements eeprom.Measurement interface's GetAlarmThresholds function
+func (m *Measurement) GetAlarmThresholds() (eeprom.AlarmThresholds, error) {
+	if !m.SupportsThresholds() {
+		return nil, errors.New("No thresholds implemented by this module")
+	}
+	return m.Thresholds, nil
+}
+
+// SupportsAlarmFlags implements eeprom.Measurement interface's SupportsAlarmFlags function
+func (m *Measurement) SupportsAlarmFlags() bool {
+	return m.Flags != nil
+}
+
+// GetAlarmFlags implements eeprom.Measurement interface's GetAlarmFlags function
+func (m *Measurement) GetAlarmFlags() (eeprom.AlarmFlags, error) {
+	if !m.SupportsAlarmFlags() {
+		return nil, errors.New("No alarm flags available")
+	}
+	return m.Flags, nil
+}
+
+// GetHighAlarm implements eeprom.Measurement interface's GetHighAlarm function
+func (m *MeasurementThresholds) GetHighAlarm() float64 {
+	return m.HighAlarm
+}
+
+// GetHighWarning implements eeprom.Measurement interface's GetHighWarning function
+func (m *MeasurementThresholds) GetHighWarning() float64 {
+	return m.HighWarning
+}
+
+// GetLowAlarm implements eeprom.Measurement interface's GetLowAlarm function
+func (m *MeasurementThresholds) GetLowAlarm() float64 {
+	return m.LowAlarm
+}
+
+// GetLowWarning implements eeprom.Measurement interface's GetLowWarning function
+func (m *MeasurementThresholds) GetLowWarning() float64 {
+	return m.LowWarning
+}
+
+// GetLasers implements eeprom.EEPROM interface's GetLasers function, one laser is returned per supported media lane
+func (e *EEPROM) GetLasers() []eeprom.Laser {
+	ret := []eeprom.Laser{}
+	if e.IsCopper() || e.LaneMonitors == nil {
+		return ret
+	}
+
+	for lane := 0; lane < MaxLanes; lane++ {
+		if !e.MediaLanesSupported[lane] {
+			continue
+		}
+		laser := &Laser{
+			RxPower: &Measurement{
+				Value:               float64(e.LaneMonitors[lane].RxPower),
+				ThresholdsSupported: e.Thresholds != nil,
+				Unit:                "milliwatts",
+			},
+			TxPower: &Measurement{
+				Value:               float64(e.LaneMonitors[lane].TxPower),
+				ThresholdsSupported: e.Thresholds != nil,
+				Unit:                "milliwatts",
+			},
+			Bias: &Measurement{
+				Value:               e.LaneMonitors[lane].Bias,
+				ThresholdsSupported: e.Thresholds != nil,
+				Unit:                "milliamperes",
+			},
+		}
+
+		if e.Thresholds != nil {
+			laser.RxPower.Thresholds = &MeasurementThresholds{
+				HighAlarm:   float64(e.Thresholds.RxPower.HighAlarm),
+				HighWarning: float64(e.Thresholds.RxPower.HighWarning),
+				LowAlarm:    float64(e.Thresholds.RxPower.LowAlarm),
+				LowWarning:  float64(e.Thresholds.RxPower.LowWarning),
+			}
+			laser.TxPower.Thresholds = &MeasurementThresholds{
+				HighAlarm:   float64(e.Thresholds.TxPower.HighAlarm),
+				HighWarning: float64(e.Thresholds.TxPower.HighWarning),
+				LowAlarm:    float64(e.Thresholds.TxPower.LowAlarm),
+				LowWarning:  float64(e.Thresholds.TxPower.LowWarning),
+			}
+			laser.Bias.Thresholds = &MeasurementThresholds{
+				HighAlarm:   e.Thresholds.TxBias.HighAlarm,
+				HighWarning: e.Thresholds.TxBias.HighWarning,
+				LowAlarm:    e.Thresholds.TxBias.LowAlarm,
+				LowWarning:  e.Thresholds.TxBias.LowWarning,
+			}
+		}
+		if e.LaneFlags != nil {
+			flags := &e.LaneFlags[lane]
+			laser.Flags = flags
+			laser.RxPower.Flags = &flags.RxPower
+			laser.TxPower.Flags = &flags.TxPower
+			laser.Bias.Flags = &flags.TxBias
+		}
+
+		e.ignoreDiagnostics(laser)
+		ret = append(ret, laser)
+	}
+	return ret
+}
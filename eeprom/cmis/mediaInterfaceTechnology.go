@@ -0,0 +1,100 @@
+package cmis
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MediaInterfaceTechnology transmitter technology or cable type, see CMIS 5.2 table 8-40
+type MediaInterfaceTechnology byte
+
+const (
+	// MediaInterfaceTechnology850nmVCSEL 850 nm VCSEL
+	MediaInterfaceTechnology850nmVCSEL MediaInterfaceTechnology = 0x00
+	// MediaInterfaceTechnology1310nmVCSEL 1310 nm VCSEL
+	MediaInterfaceTechnology1310nmVCSEL MediaInterfaceTechnology = 0x01
+	// MediaInterfaceTechnology1550nmVCSEL 1550 nm VCSEL
+	MediaInterfaceTechnology1550nmVCSEL MediaInterfaceTechnology = 0x02
+	// MediaInterfaceTechnology1310nmFP 1310 nm FP
+	MediaInterfaceTechnology1310nmFP MediaInterfaceTechnology = 0x03
+	// MediaInterfaceTechnology1310nmDFB 1310 nm DFB
+	MediaInterfaceTechnology1310nmDFB MediaInterfaceTechnology = 0x04
+	// MediaInterfaceTechnology1550nmDFB 1550 nm DFB
+	MediaInterfaceTechnology1550nmDFB MediaInterfaceTechnology = 0x05
+	// MediaInterfaceTechnology1310nmEML 1310 nm EML
+	MediaInterfaceTechnology1310nmEML MediaInterfaceTechnology = 0x06
+	// MediaInterfaceTechnology1550nmEML 1550 nm EML
+	MediaInterfaceTechnology1550nmEML MediaInterfaceTechnology = 0x07
+	// MediaInterfaceTechnologyOthers Others
+	MediaInterfaceTechnologyOthers MediaInterfaceTechnology = 0x08
+	// MediaInterfaceTechnology1490nmDFB 1490 nm DFB
+	MediaInterfaceTechnology1490nmDFB MediaInterfaceTechnology = 0x09
+	// MediaInterfaceTechnologyCopperUnequalized Copper cable unequalized
+	MediaInterfaceTechnologyCopperUnequalized MediaInterfaceTechnology = 0x0A
+	// MediaInterfaceTechnologyCopperPassiveEqualized Copper cable passive equalized
+	MediaInterfaceTechnologyCopperPassiveEqualized MediaInterfaceTechnology = 0x0B
+	// MediaInterfaceTechnologyCopperNearFarEndLimiting Copper cable, near and far end limiting active equalizers
+	MediaInterfaceTechnologyCopperNearFarEndLimiting MediaInterfaceTechnology = 0x0C
+	// MediaInterfaceTechnologyCopperFarEndLimiting Copper cable, far end limiting active equalizers
+	MediaInterfaceTechnologyCopperFarEndLimiting MediaInterfaceTechnology = 0x0D
+	// MediaInterfaceTechnologyCopperNearEndLimiting Copper cable, near end limiting active equalizers
+	MediaInterfaceTechnologyCopperNearEndLimiting MediaInterfaceTechnology = 0x0E
+	// MediaInterfaceTechnologyCopperLinear Copper cable, linear active equalizers
+	MediaInterfaceTechnologyCopperLinear MediaInterfaceTechnology = 0x0F
+	// MediaInterfaceTechnologyCBandTunable C-band tunable laser
+	MediaInterfaceTechnologyCBandTunable MediaInterfaceTechnology = 0x10
+	// MediaInterfaceTechnologyLBandTunable L-band tunable laser
+	MediaInterfaceTechnologyLBandTunable MediaInterfaceTechnology = 0x11
+	// MediaInterfaceTechnologyCopperNearFarEndLinear Copper cable, near and far end linear active equalizers
+	MediaInterfaceTechnologyCopperNearFarEndLinear MediaInterfaceTechnology = 0x12
+	// MediaInterfaceTechnologyCopperFarEndLinear Copper cable, far end linear active equalizers
+	MediaInterfaceTechnologyCopperFarEndLinear MediaInterfaceTechnology = 0x13
+	// MediaInterfaceTechnologyCopperNearEndLinear Copper cable, near end linear active equalizers
+	MediaInterfaceTechnologyCopperNearEndLinear MediaInterfaceTechnology = 0x14
+)
+
+func (m MediaInterfaceTechnology) String() string {
+	mapping := map[MediaInterfaceTechnology]string{
+		MediaInterfaceTechnology850nmVCSEL:               "850 nm VCSEL",
+		MediaInterfaceTechnology1310nmVCSEL:              "1310 nm VCSEL",
+		MediaInterfaceTechnology1550nmVCSEL:              "1550 nm VCSEL",
+		MediaInterfaceTechnology1310nmFP:                 "1310 nm FP",
+		MediaInterfaceTechnology1310nmDFB:                "1310 nm DFB",
+		MediaInterfaceTechnology1550nmDFB:                "1550 nm DFB",
+		MediaInterfaceTechnology1310nmEML:                "1310 nm EML",
+		MediaInterfaceTechnology1550nmEML:                "1550 nm EML",
+		MediaInterfaceTechnologyOthers:                   "Others",
+		MediaInterfaceTechnology1490nmDFB:                "1490 nm DFB",
+		MediaInterfaceTechnologyCopperUnequalized:        "Copper cable unequalized",
+		MediaInterfaceTechnologyCopperPassiveEqualized:   "Copper cable passive equalized",
+		MediaInterfaceTechnologyCopperNearFarEndLimiting: "Copper cable, near and far end limiting active equalizers",
+		MediaInterfaceTechnologyCopperFarEndLimiting:     "Copper cable, far end limiting active equalizers",
+		MediaInterfaceTechnologyCopperNearEndLimiting:    "Copper cable, near end limiting active equalizers",
+		MediaInterfaceTechnologyCopperLinear:             "Copper cable, linear active equalizers",
+		MediaInterfaceTechnologyCBandTunable:             "C-band tunable laser",
+		MediaInterfaceTechnologyLBandTunable:             "L-band tunable laser",
+		MediaInterfaceTechnologyCopperNearFarEndLinear:   "Copper cable, near and far end linear active equalizers",
+		MediaInterfaceTechnologyCopperFarEndLinear:       "Copper cable, far end linear active equalizers",
+		MediaInterfaceTechnologyCopperNearEndLinear:      "Copper cable, near end linear active equalizers",
+	}
+
+	str, found := mapping[m]
+	if found {
+		return str
+	}
+	return "Reserved"
+}
+
+// IsCopper returns true if the media interface is a copper cable
+func (m MediaInterfaceTechnology) IsCopper() bool {
+	return (m >= MediaInterfaceTechnologyCopperUnequalized && m <= MediaInterfaceTechnologyCopperLinear) ||
+		(m >= MediaInterfaceTechnologyCopperNearFarEndLinear && m <= MediaInterfaceTechnologyCopperNearEndLinear)
+}
+
+// MarshalJSON implements the encoding/json/Marshaler interface's MarshalJSON function
+func (m MediaInterfaceTechnology) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{
+		"ascii": m.String(),
+		"hex":   fmt.Sprintf("%#02X", byte(m)),
+	})
+}
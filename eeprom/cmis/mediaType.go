@@ -0,0 +1,56 @@
+package cmis
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MediaType the kind of media the module's media interface IDs refer to, see CMIS 5.2 table 8-12
+type MediaType byte
+
+const (
+	// MediaTypeUndefined Undefined
+	MediaTypeUndefined MediaType = 0x00
+	// MediaTypeMMF Optical interfaces: MMF
+	MediaTypeMMF MediaType = 0x01
+	// MediaTypeSMF Optical interfaces: SMF
+	MediaTypeSMF MediaType = 0x02
+	// MediaTypePassiveCopper Passive Cu
+	MediaTypePassiveCopper MediaType = 0x03
+	// MediaTypeActiveCable Active Cables
+	MediaTypeActiveCable MediaType = 0x04
+	// MediaTypeBaseT BASE-T
+	MediaTypeBaseT MediaType = 0x05
+)
+
+func (m MediaType) String() string {
+	mapping := map[MediaType]string{
+		MediaTypeUndefined:     "Undefined",
+		MediaTypeMMF:           "Optical interfaces: MMF",
+		MediaTypeSMF:           "Optical interfaces: SMF",
+		MediaTypePassiveCopper: "Passive Cu",
+		MediaTypeActiveCable:   "Active Cables",
+		MediaTypeBaseT:         "BASE-T",
+	}
+
+	str, found := mapping[m]
+	if found {
+		return str
+	} else if m >= 0x40 && m <= 0x8F {
+		return "Custom"
+	}
+	return "Reserved"
+}
+
+// IsOptical returns true for optical media
+func (m MediaType) IsOptical() bool {
+	return m == MediaTypeMMF || m == MediaTypeSMF
+}
+
+// MarshalJSON implements the encoding/json/Marshaler interface's MarshalJSON function
+func (m MediaType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{
+		"ascii": m.String(),
+		"hex":   fmt.Sprintf("%#02X", byte(m)),
+	})
+}
@@ -0,0 +1,28 @@
+package cmis
+
+// ModuleMonitors as defined in CMIS 5.2 table 8-10
+type ModuleMonitors struct {
+	Temperature   float64 `json:"temperature"`
+	SupplyVoltage float64 `json:"supplyVoltage"`
+}
+
+var moduleMonitorsMemoryMap = map[uint]func(*ModuleMonitors, byte, byte){
+	0x00: func(m *ModuleMonitors, msb byte, lsb byte) {
+		m.Temperature = parseTemperature(msb, lsb)
+	},
+	0x02: func(m *ModuleMonitors, msb byte, lsb byte) {
+		m.SupplyVoltage = parseVoltage(msb, lsb)
+	},
+	// 0x04-0x09 Aux1, Aux2 and Aux3 monitors, their meaning depends on the module
+	// 0x0A-0x0B custom monitor
+}
+
+// NewModuleMonitors parses [12]byte into a new ModuleMonitors instance
+func NewModuleMonitors(raw [12]byte) *ModuleMonitors {
+	m := &ModuleMonitors{}
+
+	for byteIndex, callback := range moduleMonitorsMemoryMap {
+		callback(m, raw[byteIndex], raw[byteIndex+1])
+	}
+	return m
+}
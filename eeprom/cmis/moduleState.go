@@ -0,0 +1,81 @@
+package cmis
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ModuleState state of the module state machine, as defined in CMIS 5.2 section 6.3.2 and table 8-6
+type ModuleState byte
+
+const (
+	// ModuleStateLowPower module is in low power mode, the management interface is available
+	ModuleStateLowPower ModuleState = 0x01
+	// ModuleStatePowerUp module is transitioning to high power mode
+	ModuleStatePowerUp ModuleState = 0x02
+	// ModuleStateReady module is in high power mode, data paths may be initialized
+	ModuleStateReady ModuleState = 0x03
+	// ModuleStatePowerDown module is transitioning to low power mode
+	ModuleStatePowerDown ModuleState = 0x04
+	// ModuleStateFault module detected a fault, see ModuleFaultCause
+	ModuleStateFault ModuleState = 0x05
+)
+
+func (m ModuleState) String() string {
+	str, found := map[ModuleState]string{
+		ModuleStateLowPower:  "ModuleLowPwr",
+		ModuleStatePowerUp:   "ModulePwrUp",
+		ModuleStateReady:     "ModuleReady",
+		ModuleStatePowerDown: "ModulePwrDn",
+		ModuleStateFault:     "ModuleFault",
+	}[m]
+	if found {
+		return str
+	}
+	return "Reserved"
+}
+
+// MarshalJSON implements the encoding/json/Marshaler interface's MarshalJSON function
+func (m ModuleState) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{
+		"ascii": m.String(),
+		"hex":   fmt.Sprintf("%#02X", byte(m)),
+	})
+}
+
+// ModuleFaultCause reason of the module being in ModuleStateFault, as defined in CMIS 5.2 table 8-15
+type ModuleFaultCause byte
+
+const (
+	// ModuleFaultCauseNone no fault detected or not supported
+	ModuleFaultCauseNone ModuleFaultCause = 0x00
+	// ModuleFaultCauseTECRunaway thermo electric cooler runaway
+	ModuleFaultCauseTECRunaway ModuleFaultCause = 0x01
+	// ModuleFaultCauseDataMemoryCorrupted data memory corrupted
+	ModuleFaultCauseDataMemoryCorrupted ModuleFaultCause = 0x02
+	// ModuleFaultCauseProgramMemoryCorrupted program memory corrupted
+	ModuleFaultCauseProgramMemoryCorrupted ModuleFaultCause = 0x03
+)
+
+func (m ModuleFaultCause) String() string {
+	str, found := map[ModuleFaultCause]string{
+		ModuleFaultCauseNone:                   "No fault detected or not supported",
+		ModuleFaultCauseTECRunaway:             "TEC runaway",
+		ModuleFaultCauseDataMemoryCorrupted:    "Data memory corrupted",
+		ModuleFaultCauseProgramMemoryCorrupted: "Program memory corrupted",
+	}[m]
+	if found {
+		return str
+	} else if m >= 0x20 && m <= 0x3F {
+		return "Custom"
+	}
+	return "Reserved"
+}
+
+// MarshalJSON implements the encoding/json/Marshaler interface's MarshalJSON function
+func (m ModuleFaultCause) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{
+		"ascii": m.String(),
+		"hex":   fmt.Sprintf("%#02X", byte(m)),
+	})
+}
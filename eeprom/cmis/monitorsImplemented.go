@@ -0,0 +1,44 @@
+package cmis
+
+// MonitorsImplemented indicates which monitors are implemented by the module, as defined in CMIS 5.2 table 8-46
+type MonitorsImplemented struct {
+	TemperatureMonitoringImplemented   bool
+	SupplyVoltageMonitoringImplemented bool
+	Aux1MonitoringImplemented          bool
+	Aux2MonitoringImplemented          bool
+	Aux3MonitoringImplemented          bool
+	CustomMonitoringImplemented        bool
+	TxBiasMonitoringImplemented        bool
+	TxPowerMonitoringImplemented       bool
+	RxPowerMonitoringImplemented       bool
+	// Multiplier to apply to Tx bias readings and thresholds
+	TxBiasMultiplier float64
+}
+
+// NewMonitorsImplemented parses [2]byte into a new MonitorsImplemented instance
+func NewMonitorsImplemented(raw [2]byte) *MonitorsImplemented {
+	return &MonitorsImplemented{
+		TemperatureMonitoringImplemented:   raw[0]&(1<<0) > 0,
+		SupplyVoltageMonitoringImplemented: raw[0]&(1<<1) > 0,
+		Aux1MonitoringImplemented:          raw[0]&(1<<2) > 0,
+		Aux2MonitoringImplemented:          raw[0]&(1<<3) > 0,
+		Aux3MonitoringImplemented:          raw[0]&(1<<4) > 0,
+		CustomMonitoringImplemented:        raw[0]&(1<<5) > 0,
+		TxBiasMonitoringImplemented:        raw[1]&(1<<0) > 0,
+		TxPowerMonitoringImplemented:       raw[1]&(1<<1) > 0,
+		RxPowerMonitoringImplemented:       raw[1]&(1<<2) > 0,
+		TxBiasMultiplier:                   parseTxBiasMultiplier(raw[1]),
+	}
+}
+
+func parseTxBiasMultiplier(raw byte) float64 {
+	switch (raw >> 3) & 0x03 {
+	case 0x01:
+		return 2
+	case 0x02:
+		return 4
+	default:
+		// 0x03 is reserved
+		return 1
+	}
+}
@@ -0,0 +1,29 @@
+package cmis
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Revision a version number made of a major and a minor part, e.g. the CMIS revision or a firmware version
+type Revision struct {
+	Major byte
+	Minor byte
+}
+
+// NewRevision parses a byte with the major revision in the upper and the minor revision in the lower nibble
+func NewRevision(raw byte) Revision {
+	return Revision{
+		Major: raw >> 4,
+		Minor: raw & 0x0F,
+	}
+}
+
+func (r Revision) String() string {
+	return fmt.Sprintf("%d.%d", r.Major, r.Minor)
+}
+
+// MarshalJSON implements the encoding/json/Marshaler interface's MarshalJSON function
+func (r Revision) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
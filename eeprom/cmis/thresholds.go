@@ -0,0 +1,65 @@
+package cmis
+
+// Thresholds as defined in CMIS 5.2 table 8-62 (upper page 02h)
+type Thresholds struct {
+	Temperature AlarmThresholds
+	Voltage     AlarmThresholds
+	TxPower     AlarmPowerThresholds
+	TxBias      AlarmThresholds
+	RxPower     AlarmPowerThresholds
+}
+
+// AlarmThresholds defines high/low alarm/warning thresholds
+type AlarmThresholds struct {
+	HighAlarm   float64
+	HighWarning float64
+	LowAlarm    float64
+	LowWarning  float64
+}
+
+// AlarmPowerThresholds defines high/low alarm/warning thresholds for the Power type
+type AlarmPowerThresholds struct {
+	HighAlarm   Power
+	HighWarning Power
+	LowAlarm    Power
+	LowWarning  Power
+}
+
+var thresholdMemoryMap = map[uint]func(*Thresholds, byte, byte){
+	0x00: func(t *Thresholds, msb byte, lsb byte) { t.Temperature.HighAlarm = parseTemperature(msb, lsb) },
+	0x02: func(t *Thresholds, msb byte, lsb byte) { t.Temperature.LowAlarm = parseTemperature(msb, lsb) },
+	0x04: func(t *Thresholds, msb byte, lsb byte) { t.Temperature.HighWarning = parseTemperature(msb, lsb) },
+	0x06: func(t *Thresholds, msb byte, lsb byte) { t.Temperature.LowWarning = parseTemperature(msb, lsb) },
+	0x08: func(t *Thresholds, msb byte, lsb byte) { t.Voltage.HighAlarm = parseVoltage(msb, lsb) },
+	0x0A: func(t *Thresholds, msb byte, lsb byte) { t.Voltage.LowAlarm = parseVoltage(msb, lsb) },
+	0x0C: func(t *Thresholds, msb byte, lsb byte) { t.Voltage.HighWarning = parseVoltage(msb, lsb) },
+	0x0E: func(t *Thresholds, msb byte, lsb byte) { t.Voltage.LowWarning = parseVoltage(msb, lsb) },
+	// 0x10-0x2F Aux1, Aux2, Aux3 and custom monitor thresholds
+	0x30: func(t *Thresholds, msb byte, lsb byte) { t.TxPower.HighAlarm = parsePower(msb, lsb) },
+	0x32: func(t *Thresholds, msb byte, lsb byte) { t.TxPower.LowAlarm = parsePower(msb, lsb) },
+	0x34: func(t *Thresholds, msb byte, lsb byte) { t.TxPower.HighWarning = parsePower(msb, lsb) },
+	0x36: func(t *Thresholds, msb byte, lsb byte) { t.TxPower.LowWarning = parsePower(msb, lsb) },
+	0x38: func(t *Thresholds, msb byte, lsb byte) { t.TxBias.HighAlarm = parseCurrent(msb, lsb) },
+	0x3A: func(t *Thresholds, msb byte, lsb byte) { t.TxBias.LowAlarm = parseCurrent(msb, lsb) },
+	0x3C: func(t *Thresholds, msb byte, lsb byte) { t.TxBias.HighWarning = parseCurrent(msb, lsb) },
+	0x3E: func(t *Thresholds, msb byte, lsb byte) { t.TxBias.LowWarning = parseCurrent(msb, lsb) },
+	0x40: func(t *Thresholds, msb byte, lsb byte) { t.RxPower.HighAlarm = parsePower(msb, lsb) },
+	0x42: func(t *Thresholds, msb byte, lsb byte) { t.RxPower.LowAlarm = parsePower(msb, lsb) },
+	0x44: func(t *Thresholds, msb byte, lsb byte) { t.RxPower.HighWarning = parsePower(msb, lsb) },
+	0x46: func(t *Thresholds, msb byte, lsb byte) { t.RxPower.LowWarning = parsePower(msb, lsb) },
+}
+
+// NewThresholds parses [72]byte into a new Thresholds instance, the Tx bias thresholds are scaled by biasMultiplier
+func NewThresholds(raw [72]byte, biasMultiplier float64) *Thresholds {
+	t := &Thresholds{}
+
+	for byteOffset, callback := range thresholdMemoryMap {
+		callback(t, raw[byteOffset], raw[byteOffset+1])
+	}
+	t.TxBias.HighAlarm *= biasMultiplier
+	t.TxBias.LowAlarm *= biasMultiplier
+	t.TxBias.HighWarning *= biasMultiplier
+	t.TxBias.LowWarning *= biasMultiplier
+
+	return t
+}
@@ -0,0 +1,335 @@
+package cmis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/wobcom/go-ethtool/eeprom"
+	"math"
+)
+
+/* Versatile diagnostics monitoring (VDM) as defined in CMIS 5.2 section 8.20.
+ * Offsets are relative to the start of upper page 20h, one group consists of a descriptor page (20h-23h),
+ * a sample page (24h-27h) and a threshold page (28h-2Bh) */
+const (
+	// Number of observables per group
+	vdmObservablesPerGroup = 64
+	// Maximum number of groups
+	vdmMaxGroups = 4
+	// Each observable is described by 2 bytes, its sample is 2 bytes long
+	vdmDescriptorsOffset = 0x000
+	vdmSamplesOffset     = 0x200
+	vdmThresholdsOffset  = 0x400
+	// Latched flags of all groups (page 2Ch), one nibble per observable
+	vdmFlagsOffset = 0x600
+	// Each threshold set consists of high alarm, low alarm, high warning and low warning, 2 bytes each
+	vdmThresholdSetLength = 8
+	// Number of supported groups minus one in bits 1-0 (page 2Fh byte 128)
+	vdmGroupsSupportedOffset = 0x780
+	// Length of pages 20h-2Fh
+	vdmLength = 0x800
+)
+
+// vdmFormat encoding of a VDM sample
+type vdmFormat byte
+
+const (
+	vdmFormatU16 vdmFormat = iota
+	vdmFormatS16
+	// 5 bit exponent and 11 bit mantissa, value = mantissa * 10^(exponent - 24)
+	vdmFormatF16
+)
+
+// VDMObservableType type of a VDM observable as defined in CMIS 5.2 table 8-172
+type VDMObservableType byte
+
+const (
+	// VDMObservableLaserAge laser age in percent of its end of life
+	VDMObservableLaserAge VDMObservableType = 0x01
+	// VDMObservableTECCurrent thermo electric cooler current in percent of its maximum
+	VDMObservableTECCurrent VDMObservableType = 0x02
+	// VDMObservableLaserFrequencyError laser frequency error in MHz
+	VDMObservableLaserFrequencyError VDMObservableType = 0x03
+	// VDMObservableLaserTemperature laser temperature in degrees celsius
+	VDMObservableLaserTemperature VDMObservableType = 0x04
+	// VDMObservableESNRMedia eSNR of the media input in dB
+	VDMObservableESNRMedia VDMObservableType = 0x05
+	// VDMObservableESNRHost eSNR of the host input in dB
+	VDMObservableESNRHost VDMObservableType = 0x06
+	// VDMObservableLTPMedia PAM4 level transition parameter of the media input in dB
+	VDMObservableLTPMedia VDMObservableType = 0x07
+	// VDMObservableLTPHost PAM4 level transition parameter of the host input in dB
+	VDMObservableLTPHost VDMObservableType = 0x08
+	// VDMObservablePreFECBERMinMedia minimum pre-FEC BER of the media input
+	VDMObservablePreFECBERMinMedia VDMObservableType = 0x09
+	// VDMObservablePreFECBERMinHost minimum pre-FEC BER of the host input
+	VDMObservablePreFECBERMinHost VDMObservableType = 0x0A
+	// VDMObservablePreFECBERMaxMedia maximum pre-FEC BER of the media input
+	VDMObservablePreFECBERMaxMedia VDMObservableType = 0x0B
+	// VDMObservablePreFECBERMaxHost maximum pre-FEC BER of the host input
+	VDMObservablePreFECBERMaxHost VDMObservableType = 0x0C
+	// VDMObservablePreFECBERAvgMedia average pre-FEC BER of the media input
+	VDMObservablePreFECBERAvgMedia VDMObservableType = 0x0D
+	// VDMObservablePreFECBERAvgHost average pre-FEC BER of the host input
+	VDMObservablePreFECBERAvgHost VDMObservableType = 0x0E
+	// VDMObservablePreFECBERCurrentMedia current pre-FEC BER of the media input
+	VDMObservablePreFECBERCurrentMedia VDMObservableType = 0x0F
+	// VDMObservablePreFECBERCurrentHost current pre-FEC BER of the host input
+	VDMObservablePreFECBERCurrentHost VDMObservableType = 0x10
+	// VDMObservableFERCMinMedia minimum frame error ratio (FERC) of the media input
+	VDMObservableFERCMinMedia VDMObservableType = 0x11
+	// VDMObservableFERCMinHost minimum frame error ratio (FERC) of the host input
+	VDMObservableFERCMinHost VDMObservableType = 0x12
+	// VDMObservableFERCMaxMedia maximum frame error ratio (FERC) of the media input
+	VDMObservableFERCMaxMedia VDMObservableType = 0x13
+	// VDMObservableFERCMaxHost maximum frame error ratio (FERC) of the host input
+	VDMObservableFERCMaxHost VDMObservableType = 0x14
+	// VDMObservableFERCAvgMedia average frame error ratio (FERC) of the media input
+	VDMObservableFERCAvgMedia VDMObservableType = 0x15
+	// VDMObservableFERCAvgHost average frame error ratio (FERC) of the host input
+	VDMObservableFERCAvgHost VDMObservableType = 0x16
+	// VDMObservableFERCCurrentMedia current frame error ratio (FERC) of the media input
+	VDMObservableFERCCurrentMedia VDMObservableType = 0x17
+	// VDMObservableFERCCurrentHost current frame error ratio (FERC) of the host input
+	VDMObservableFERCCurrentHost VDMObservableType = 0x18
+
+	/* Coherent observables as defined in OIF C-CMIS 1.2 table 32 */
+
+	// VDMObservableModulatorBiasXI modulator bias X/I in percent
+	VDMObservableModulatorBiasXI VDMObservableType = 0x80
+	// VDMObservableModulatorBiasXQ modulator bias X/Q in percent
+	VDMObservableModulatorBiasXQ VDMObservableType = 0x81
+	// VDMObservableModulatorBiasYI modulator bias Y/I in percent
+	VDMObservableModulatorBiasYI VDMObservableType = 0x82
+	// VDMObservableModulatorBiasYQ modulator bias Y/Q in percent
+	VDMObservableModulatorBiasYQ VDMObservableType = 0x83
+	// VDMObservableModulatorBiasXPhase modulator bias X phase in percent
+	VDMObservableModulatorBiasXPhase VDMObservableType = 0x84
+	// VDMObservableModulatorBiasYPhase modulator bias Y phase in percent
+	VDMObservableModulatorBiasYPhase VDMObservableType = 0x85
+	// VDMObservableCDShortLink chromatic dispersion, high granularity for short links, in ps/nm
+	VDMObservableCDShortLink VDMObservableType = 0x86
+	// VDMObservableCDLongLink chromatic dispersion, low granularity for long links, in ps/nm
+	VDMObservableCDLongLink VDMObservableType = 0x87
+	// VDMObservableDGD differential group delay in ps
+	VDMObservableDGD VDMObservableType = 0x88
+	// VDMObservableSOPMD second order polarization mode dispersion in ps^2
+	VDMObservableSOPMD VDMObservableType = 0x89
+	// VDMObservablePDL polarization dependent loss in dB
+	VDMObservablePDL VDMObservableType = 0x8A
+	// VDMObservableOSNR optical signal to noise ratio in dB
+	VDMObservableOSNR VDMObservableType = 0x8B
+	// VDMObservableESNR electrical signal to noise ratio in dB
+	VDMObservableESNR VDMObservableType = 0x8C
+	// VDMObservableCFO carrier frequency offset in MHz
+	VDMObservableCFO VDMObservableType = 0x8D
+	// VDMObservableEVM error vector magnitude of the modem in percent
+	VDMObservableEVM VDMObservableType = 0x8E
+	// VDMObservableTxPower Tx output power in dBm
+	VDMObservableTxPower VDMObservableType = 0x8F
+	// VDMObservableRxTotalPower Rx total input power in dBm
+	VDMObservableRxTotalPower VDMObservableType = 0x90
+	// VDMObservableRxSignalPower Rx channel input power in dBm
+	VDMObservableRxSignalPower VDMObservableType = 0x91
+	// VDMObservableSOPROC state of polarization rate of change in krad/s
+	VDMObservableSOPROC VDMObservableType = 0x92
+	// VDMObservableMER modulation error ratio in dB
+	VDMObservableMER VDMObservableType = 0x93
+)
+
+// vdmObservableTypeInfo how to decode and present an observable type
+type vdmObservableTypeInfo struct {
+	name   string
+	unit   string
+	format vdmFormat
+	scale  float64
+}
+
+var vdmObservableTypes = map[VDMObservableType]vdmObservableTypeInfo{
+	VDMObservableLaserAge:              {"Laser Age", "percent", vdmFormatU16, 1},
+	VDMObservableTECCurrent:            {"TEC Current", "percent", vdmFormatS16, 100.0 / 32767},
+	VDMObservableLaserFrequencyError:   {"Laser Frequency Error", "megahertz", vdmFormatS16, 10},
+	VDMObservableLaserTemperature:      {"Laser Temperature", "degrees celsius", vdmFormatS16, 1.0 / 256},
+	VDMObservableESNRMedia:             {"eSNR Media Input", "decibels", vdmFormatU16, 1.0 / 256},
+	VDMObservableESNRHost:              {"eSNR Host Input", "decibels", vdmFormatU16, 1.0 / 256},
+	VDMObservableLTPMedia:              {"PAM4 Level Transition Parameter Media Input", "decibels", vdmFormatU16, 1.0 / 256},
+	VDMObservableLTPHost:               {"PAM4 Level Transition Parameter Host Input", "decibels", vdmFormatU16, 1.0 / 256},
+	VDMObservablePreFECBERMinMedia:     {"Pre-FEC BER Minimum Media Input", "ratio", vdmFormatF16, 1},
+	VDMObservablePreFECBERMinHost:      {"Pre-FEC BER Minimum Host Input", "ratio", vdmFormatF16, 1},
+	VDMObservablePreFECBERMaxMedia:     {"Pre-FEC BER Maximum Media Input", "ratio", vdmFormatF16, 1},
+	VDMObservablePreFECBERMaxHost:      {"Pre-FEC BER Maximum Host Input", "ratio", vdmFormatF16, 1},
+	VDMObservablePreFECBERAvgMedia:     {"Pre-FEC BER Average Media Input", "ratio", vdmFormatF16, 1},
+	VDMObservablePreFECBERAvgHost:      {"Pre-FEC BER Average Host Input", "ratio", vdmFormatF16, 1},
+	VDMObservablePreFECBERCurrentMedia: {"Pre-FEC BER Current Value Media Input", "ratio", vdmFormatF16, 1},
+	VDMObservablePreFECBERCurrentHost:  {"Pre-FEC BER Current Value Host Input", "ratio", vdmFormatF16, 1},
+	VDMObservableFERCMinMedia:          {"Errored Frames Minimum Media Input", "ratio", vdmFormatF16, 1},
+	VDMObservableFERCMinHost:           {"Errored Frames Minimum Host Input", "ratio", vdmFormatF16, 1},
+	VDMObservableFERCMaxMedia:          {"Errored Frames Maximum Media Input", "ratio", vdmFormatF16, 1},
+	VDMObservableFERCMaxHost:           {"Errored Frames Maximum Host Input", "ratio", vdmFormatF16, 1},
+	VDMObservableFERCAvgMedia:          {"Errored Frames Average Media Input", "ratio", vdmFormatF16, 1},
+	VDMObservableFERCAvgHost:           {"Errored Frames Average Host Input", "ratio", vdmFormatF16, 1},
+	VDMObservableFERCCurrentMedia:      {"Errored Frames Current Value Media Input", "ratio", vdmFormatF16, 1},
+	VDMObservableFERCCurrentHost:       {"Errored Frames Current Value Host Input", "ratio", vdmFormatF16, 1},
+	VDMObservableModulatorBiasXI:       {"Modulator Bias X/I", "percent", vdmFormatU16, 100.0 / 65535},
+	VDMObservableModulatorBiasXQ:       {"Modulator Bias X/Q", "percent", vdmFormatU16, 100.0 / 65535},
+	VDMObservableModulatorBiasYI:       {"Modulator Bias Y/I", "percent", vdmFormatU16, 100.0 / 65535},
+	VDMObservableModulatorBiasYQ:       {"Modulator Bias Y/Q", "percent", vdmFormatU16, 100.0 / 65535},
+	VDMObservableModulatorBiasXPhase:   {"Modulator Bias X Phase", "percent", vdmFormatU16, 100.0 / 65535},
+	VDMObservableModulatorBiasYPhase:   {"Modulator Bias Y Phase", "percent", vdmFormatU16, 100.0 / 65535},
+	VDMObservableCDShortLink:           {"CD High Granularity, Short Link", "picoseconds per nanometer", vdmFormatS16, 1},
+	VDMObservableCDLongLink:            {"CD Low Granularity, Long Link", "picoseconds per nanometer", vdmFormatS16, 20},
+	VDMObservableDGD:                   {"DGD", "picoseconds", vdmFormatU16, 0.01},
+	VDMObservableSOPMD:                 {"SOPMD", "square picoseconds", vdmFormatU16, 0.01},
+	VDMObservablePDL:                   {"PDL", "decibels", vdmFormatU16, 0.1},
+	VDMObservableOSNR:                  {"OSNR", "decibels", vdmFormatU16, 0.1},
+	VDMObservableESNR:                  {"eSNR", "decibels", vdmFormatU16, 0.1},
+	VDMObservableCFO:                   {"CFO", "megahertz", vdmFormatS16, 1},
+	VDMObservableEVM:                   {"EVM Modem", "percent", vdmFormatU16, 100.0 / 65535},
+	VDMObservableTxPower:               {"Tx Power", "decibel milliwatts", vdmFormatS16, 0.01},
+	VDMObservableRxTotalPower:          {"Rx Total Power", "decibel milliwatts", vdmFormatS16, 0.01},
+	VDMObservableRxSignalPower:         {"Rx Signal Power", "decibel milliwatts", vdmFormatS16, 0.01},
+	VDMObservableSOPROC:                {"SOP ROC", "kiloradians per second", vdmFormatU16, 1},
+	VDMObservableMER:                   {"MER", "decibels", vdmFormatU16, 0.1},
+}
+
+func (v VDMObservableType) info() vdmObservableTypeInfo {
+	info, found := vdmObservableTypes[v]
+	if found {
+		return info
+	}
+	return vdmObservableTypeInfo{"Unknown", "", vdmFormatU16, 1}
+}
+
+func (v VDMObservableType) String() string {
+	return v.info().name
+}
+
+// Unit returns the unit of the observable's values
+func (v VDMObservableType) Unit() string {
+	return v.info().unit
+}
+
+// MarshalJSON implements the encoding/json/Marshaler interface's MarshalJSON function
+func (v VDMObservableType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{
+		"ascii": v.String(),
+		"hex":   fmt.Sprintf("%#02X", byte(v)),
+	})
+}
+
+// decode converts a raw sample or threshold of this observable type into its value
+func (v VDMObservableType) decode(msb byte, lsb byte) float64 {
+	info := v.info()
+	switch info.format {
+	case vdmFormatS16:
+		return float64(parseInt16(msb, lsb)) * info.scale
+	case vdmFormatF16:
+		raw := parseUint16(msb, lsb)
+		exponent := int(raw >> 11)
+		mantissa := float64(raw & 0x07FF)
+		return mantissa * math.Pow10(exponent-24) * info.scale
+	}
+	return float64(parseUint16(msb, lsb)) * info.scale
+}
+
+// VDMObservable a single VDM observable, implements eeprom.Measurement
+type VDMObservable struct {
+	Type VDMObservableType
+	// Lane or data path the observable refers to (0 for lane 1)
+	Lane           byte
+	ThresholdSetID byte
+	Value          float64
+	Thresholds     *MeasurementThresholds
+	// Latched flags, set if the value crossed a threshold since the flags have last been read
+	Flags VDMFlags
+}
+
+// VDMFlags threshold crossing flags of a VDM observable
+type VDMFlags = AlarmFlags
+
+// GetValue implements eeprom.Measurement interface's GetValue function
+func (v *VDMObservable) GetValue() float64 {
+	return v.Value
+}
+
+// GetUnit implements eeprom.Measurement interface's GetUnit function
+func (v *VDMObservable) GetUnit() string {
+	return v.Type.Unit()
+}
+
+// SupportsThresholds implements eeprom.Measurement interface's SupportsThresholds function
+func (v *VDMObservable) SupportsThresholds() bool {
+	return v.Thresholds != nil
+}
+
+// GetAlarmThresholds implements eeprom.Measurement interface's GetAlarmThresholds function
+func (v *VDMObservable) GetAlarmThresholds() (eeprom.AlarmThresholds, error) {
+	if !v.SupportsThresholds() {
+		return nil, errors.New("No thresholds implemented by this module")
+	}
+	return v.Thresholds, nil
+}
+
+// SupportsAlarmFlags implements eeprom.Measurement interface's SupportsAlarmFlags function
+func (v *VDMObservable) SupportsAlarmFlags() bool {
+	return true
+}
+
+// GetAlarmFlags implements eeprom.Measurement interface's GetAlarmFlags function
+func (v *VDMObservable) GetAlarmFlags() (eeprom.AlarmFlags, error) {
+	return &v.Flags, nil
+}
+
+// VDM observables of all supported VDM groups
+type VDM struct {
+	Groups      int
+	Observables []VDMObservable
+}
+
+// NewVDM parses upper pages 20h-2Fh into a new VDM instance, unused observables are skipped
+func NewVDM(raw [vdmLength]byte) *VDM {
+	v := &VDM{
+		Groups:      int(raw[vdmGroupsSupportedOffset]&0x03) + 1,
+		Observables: []VDMObservable{},
+	}
+
+	for group := 0; group < v.Groups && group < vdmMaxGroups; group++ {
+		for index := 0; index < vdmObservablesPerGroup; index++ {
+			descriptor := vdmDescriptorsOffset + group*0x80 + index*2
+			observableType := VDMObservableType(raw[descriptor+1])
+			if observableType == 0 {
+				continue
+			}
+			sample := vdmSamplesOffset + group*0x80 + index*2
+			observable := VDMObservable{
+				Type:           observableType,
+				Lane:           raw[descriptor] & 0x0F,
+				ThresholdSetID: raw[descriptor] >> 4,
+				Value:          observableType.decode(raw[sample], raw[sample+1]),
+			}
+			// 16 threshold sets of 8 bytes fill a threshold page
+			thresholds := vdmThresholdsOffset + group*0x80 + int(observable.ThresholdSetID)*vdmThresholdSetLength
+			observable.Thresholds = &MeasurementThresholds{
+				HighAlarm:   observableType.decode(raw[thresholds+0], raw[thresholds+1]),
+				LowAlarm:    observableType.decode(raw[thresholds+2], raw[thresholds+3]),
+				HighWarning: observableType.decode(raw[thresholds+4], raw[thresholds+5]),
+				LowWarning:  observableType.decode(raw[thresholds+6], raw[thresholds+7]),
+			}
+			flags := raw[vdmFlagsOffset+(group*vdmObservablesPerGroup+index)/2] >> (4 * (index % 2))
+			observable.Flags = NewAlarmFlags(flags)
+			v.Observables = append(v.Observables, observable)
+		}
+	}
+	return v
+}
+
+// Find returns the observables of the given type, ordered by group and position within the group
+func (v *VDM) Find(observableType VDMObservableType) []VDMObservable {
+	ret := []VDMObservable{}
+	for _, observable := range v.Observables {
+		if observable.Type == observableType {
+			ret = append(ret, observable)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,83 @@
+package eeprom
+
+import (
+	"fmt"
+	"github.com/wobcom/go-ethtool/eeprom/sff8024"
+)
+
+// Decoder parses a raw EEPROM image complying with a single standard
+type Decoder func(raw []byte, options *ParseOptions) (EEPROM, error)
+
+var decoders = map[Type]Decoder{}
+
+// RegisterDecoder registers the decoder for EEPROMs of the given type.
+// The packages implementing the standards register themselves on import, e.g. `import _ "github.com/wobcom/go-ethtool/eeprom/sff8472"`.
+func RegisterDecoder(eepromType Type, decoder Decoder) {
+	decoders[eepromType] = decoder
+}
+
+// Decode parses raw without an interface, see DecodeWithOptions
+func Decode(raw []byte, hint Type) (EEPROM, error) {
+	return DecodeWithOptions(raw, hint, nil)
+}
+
+// DecodeWithOptions parses raw with the decoder of the standard selected by DetectType and applies the matching quirks.
+// hint, e.g. the type reported by the driver, is used if raw does not identify a standard.
+func DecodeWithOptions(raw []byte, hint Type, options *ParseOptions) (EEPROM, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("Can not decode empty EEPROM")
+	}
+	eepromType := DetectType(raw, hint)
+	decoder, ok := decoders[eepromType]
+	if !ok {
+		return nil, fmt.Errorf("No decoder registered for EEPROM type %s (%#02x)", eepromType, uint32(eepromType))
+	}
+	e, err := decoder(raw, options)
+	if err != nil {
+		return nil, err
+	}
+	return ApplyQuirks(e, raw, options)
+}
+
+const (
+	// Byte of SFP modules indicating the diagnostic monitoring type, bit 6 is set if digital diagnostic monitoring is implemented
+	sff8472DiagnosticMonitoringTypeOffset = 0x5C
+	// Byte of SFP modules indicating the revision of SFF-8472 complied with, 0x00 if only SFF-8079 is implemented
+	sff8472ComplianceOffset = 0x5E
+	// Length of the SFF-8472 memory map including page A2h
+	sff8472DiagnosticsLength = 512
+	// Byte of QSFP modules indicating the revision of SFF-8436 or SFF-8636 complied with
+	sff8636RevisionComplianceOffset = 0x01
+	// Revision compliance of modules implementing SFF-8436 rev 4.8 or earlier
+	sff8436RevisionCompliance = 0x01
+)
+
+// DetectType returns the standard the EEPROM image raw complies with, based on the identifier at byte 0
+// and the revision compliance byte. Drivers report CMIS modules as SFF-8636 and some report SFF-8079
+// or SFF-8472 regardless of the module, so reported is only returned if raw does not identify a standard.
+// SFPs reported as SFF-8472 or implementing diagnostics are kept as SFF-8472 even without compliance revision.
+func DetectType(raw []byte, reported Type) Type {
+	if len(raw) == 0 {
+		return reported
+	}
+	switch identifier := sff8024.Identifier(raw[0]); {
+	case identifier.UsesCMIS():
+		return TypeCMIS
+	case identifier == sff8024.IdentifierQsfp || identifier == sff8024.IdentifierQsfpPlus || identifier == sff8024.IdentifierQsfp28:
+		if len(raw) > sff8636RevisionComplianceOffset && raw[sff8636RevisionComplianceOffset] == sff8436RevisionCompliance {
+			return TypeSFF8436
+		}
+		return TypeSFF8636
+	case identifier == sff8024.IdentifierSfp:
+		diagnosticsImplemented := len(raw) >= sff8472DiagnosticsLength && raw[sff8472DiagnosticMonitoringTypeOffset]&(1<<6) > 0
+		if reported == TypeSFF8472 || diagnosticsImplemented {
+			return TypeSFF8472
+		}
+		if len(raw) > sff8472ComplianceOffset && raw[sff8472ComplianceOffset] == 0x00 {
+			return TypeSFF8079
+		}
+		return TypeSFF8472
+	default:
+		return reported
+	}
+}
@@ -0,0 +1,43 @@
+package eeprom
+
+import (
+	"testing"
+)
+
+func TestDetectType(t *testing.T) {
+	sfp := func(length int, compliance byte, diagnosticMonitoringType byte) []byte {
+		raw := make([]byte, length)
+		raw[0] = 0x03
+		raw[sff8472DiagnosticMonitoringTypeOffset] = diagnosticMonitoringType
+		raw[sff8472ComplianceOffset] = compliance
+		return raw
+	}
+	qsfp := func(identifier byte, revisionCompliance byte) []byte {
+		raw := make([]byte, 256)
+		raw[0] = identifier
+		raw[sff8636RevisionComplianceOffset] = revisionCompliance
+		return raw
+	}
+
+	for _, test := range []struct {
+		name     string
+		raw      []byte
+		reported Type
+		expected Type
+	}{
+		{"SFP complying with SFF-8472 reported as SFF-8079", sfp(512, 0x08, 0x68), TypeSFF8079, TypeSFF8472},
+		{"SFP without compliance revision or diagnostics", sfp(256, 0x00, 0x00), TypeSFF8079, TypeSFF8079},
+		{"SFP without compliance revision reported as SFF-8472", sfp(512, 0x00, 0x00), TypeSFF8472, TypeSFF8472},
+		{"SFP without compliance revision implementing diagnostics", sfp(512, 0x00, 0x40), TypeSFF8079, TypeSFF8472},
+		{"SFP implementing diagnostics without page A2h", sfp(256, 0x00, 0x40), TypeSFF8079, TypeSFF8079},
+		{"QSFP28 reported as SFF-8079", qsfp(0x11, 0x08), TypeSFF8079, TypeSFF8636},
+		{"QSFP+ complying with SFF-8436", qsfp(0x0D, 0x01), TypeSFF8636, TypeSFF8436},
+		{"QSFP-DD reported as SFF-8636", qsfp(0x18, 0x05), TypeSFF8636, TypeCMIS},
+		{"Unknown identifier", []byte{0x00}, TypeSFF8472, TypeSFF8472},
+		{"Empty EEPROM", []byte{}, TypeSFF8636, TypeSFF8636},
+	} {
+		if eepromType := DetectType(test.raw, test.reported); eepromType != test.expected {
+			t.Errorf("%s: Expected %v, got %v", test.name, test.expected, eepromType)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package eeprom
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ethtoolHexLine matches a line of `ethtool -m <interface> hex on`, e.g. "0x0010:		08 03 00 1e 46 53 20 20"
+var ethtoolHexLine = regexp.MustCompile(`^\s*0x([0-9a-fA-F]+):\s*((?:[0-9a-fA-F]{2}\s*)*)$`)
+
+// ReadDumpFile reads an EEPROM dump from the file at path, see ReadDump
+func ReadDumpFile(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadDump(file)
+}
+
+// ReadDump reads an EEPROM dump in any of the supported formats:
+// the output of `ethtool -m <interface> hex on`, plain hex or raw binary, e.g. `ethtool -m <interface> raw on`
+func ReadDump(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	text := string(data)
+	if raw, err := ParseEthtoolHex(text); err == nil {
+		return raw, nil
+	}
+	if raw, err := ParseHex(text); err == nil {
+		return raw, nil
+	}
+	return data, nil
+}
+
+// ParseHex decodes plain hex, whitespace, colons and a leading "0x" are ignored
+func ParseHex(text string) ([]byte, error) {
+	text = strings.TrimPrefix(strings.TrimSpace(text), "0x")
+	text = strings.Map(func(r rune) rune {
+		if r == ':' || r == ' ' || r == '\t' || r == '\r' || r == '\n' {
+			return -1
+		}
+		return r
+	}, text)
+	if len(text) == 0 {
+		return nil, fmt.Errorf("No hex data found")
+	}
+	return hex.DecodeString(text)
+}
+
+// ParseEthtoolHex decodes the output of `ethtool -m <interface> hex on`.
+// Bytes are placed at the offsets given per line. Tables whose offsets restart, as printed for further pages, are appended.
+func ParseEthtoolHex(text string) ([]byte, error) {
+	raw := []byte{}
+	// offset of the current table in raw
+	tableOffset, previousOffset := 0, -1
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "Offset") || strings.HasPrefix(line, "------") {
+			continue
+		}
+		match := ethtoolHexLine.FindStringSubmatch(line)
+		if match == nil {
+			return nil, fmt.Errorf("Unexpected line in ethtool hex dump: %q", line)
+		}
+		offset, err := strconv.ParseInt(match[1], 16, 64)
+		if err != nil {
+			return nil, err
+		}
+		values, err := hex.DecodeString(strings.Join(strings.Fields(match[2]), ""))
+		if err != nil {
+			return nil, err
+		}
+		if int(offset) <= previousOffset {
+			tableOffset = len(raw) - int(offset)
+		}
+		previousOffset = int(offset)
+		position := tableOffset + int(offset)
+		if position+len(values) > len(raw) {
+			raw = append(raw, bytes.Repeat([]byte{0}, position+len(values)-len(raw))...)
+		}
+		copy(raw[position:], values)
+	}
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("No ethtool hex dump found")
+	}
+	return raw, nil
+}
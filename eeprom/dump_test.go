@@ -0,0 +1,99 @@
+package eeprom
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// getDumpData returns the contents of a module with lower page, upper page 00h and upper pages 01h-03h
+func getDumpData() []byte {
+	data := make([]byte, 0x280)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+	return data
+}
+
+// getEthtoolHexDump formats data like `ethtool -m <interface> hex on`, restarting the offsets at 0x80 for each further page
+func getEthtoolHexDump(data []byte) string {
+	dump := "Offset\t\tValues\n------\t\t------\n"
+	for offset := 0; offset < len(data); offset += 16 {
+		label := offset
+		if offset >= 0x100 {
+			label = 0x80 + offset%0x80
+			if label == 0x80 {
+				dump += "\nOffset\t\tValues\n------\t\t------\n"
+			}
+		}
+		dump += fmt.Sprintf("0x%04x:\t\t% x\n", label, data[offset:offset+16])
+	}
+	return dump
+}
+
+func TestParseEthtoolHex(t *testing.T) {
+	data := getDumpData()
+	raw, err := ParseEthtoolHex(getEthtoolHexDump(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(raw, data) {
+		t.Errorf("Unexpected dump contents %x", raw)
+	}
+
+	if _, err := ParseEthtoolHex("0x0000:\t\tzz 00"); err == nil {
+		t.Error("Expected invalid line to fail")
+	}
+	if _, err := ParseEthtoolHex(hex.EncodeToString(data)); err == nil {
+		t.Error("Expected plain hex to fail")
+	}
+}
+
+func TestParseHex(t *testing.T) {
+	raw, err := ParseHex("0x03:04 07\n20\t00\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(raw, []byte{0x03, 0x04, 0x07, 0x20, 0x00}) {
+		t.Errorf("Unexpected hex contents %x", raw)
+	}
+	if _, err := ParseHex(" \n"); err == nil {
+		t.Error("Expected empty hex to fail")
+	}
+}
+
+func TestReadDump(t *testing.T) {
+	data := getDumpData()
+	for name, input := range map[string]string{
+		"ethtool hex": getEthtoolHexDump(data),
+		"plain hex":   hex.EncodeToString(data),
+		"raw":         string(data),
+	} {
+		raw, err := ReadDump(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !bytes.Equal(raw, data) {
+			t.Errorf("%s: Unexpected dump contents %x", name, raw)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "dump.txt")
+	if err := os.WriteFile(path, []byte(getEthtoolHexDump(data)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	raw, err := ReadDumpFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(raw, data) {
+		t.Errorf("Unexpected dump file contents %x", raw)
+	}
+	if _, err := ReadDumpFile(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("Expected reading a missing file to fail")
+	}
+}
@@ -14,6 +14,8 @@ const (
 	TypeSFF8472 Type = 0x02
 	TypeSFF8636 Type = 0x03
 	TypeSFF8436 Type = 0x04
+	// TypeCMIS is not reported by the kernel, which reports CMIS modules as SFF-8636
+	TypeCMIS Type = 0x100
 )
 
 func (e Type) String() string {
@@ -22,6 +24,7 @@ func (e Type) String() string {
 		TypeSFF8472: "SFF-8472",
 		TypeSFF8636: "SFF-8636",
 		TypeSFF8436: "SFF-8436",
+		TypeCMIS:    "CMIS",
 	}[e]
 }
 
@@ -39,6 +42,8 @@ type EEPROM interface {
 	GetVendorSN() string
 	GetVendorOUI() OUI
 	GetDateCode() time.Time
+	GetParseWarnings() []ParseWarning
+	GetChecksumErrors() []*ChecksumError
 	GetWavelength() float64
 	GetLasers() []Laser
 	SupportsMonitoring() bool
@@ -52,14 +57,18 @@ type Laser interface {
 	GetBias() (Measurement, error)
 	GetTxPower() (Measurement, error)
 	GetRxPower() (Measurement, error)
+	SupportsLaneFlags() bool
+	GetLaneFlags() (LaneFlags, error)
 }
 
-// Measurement a value read from a sensor, may provide alarm thresholds
+// Measurement a value read from a sensor, may provide alarm thresholds and the alarm flags asserted by the module
 type Measurement interface {
 	GetValue() float64
 	GetUnit() string
 	SupportsThresholds() bool
 	GetAlarmThresholds() (AlarmThresholds, error)
+	SupportsAlarmFlags() bool
+	GetAlarmFlags() (AlarmFlags, error)
 }
 
 // AlarmThresholds warning / alarm thresholds for a given reading
@@ -69,3 +78,30 @@ type AlarmThresholds interface {
 	GetLowAlarm() float64
 	GetLowWarning() float64
 }
+
+// AlarmFlags warning / alarm flags asserted by the module for a given reading.
+// Depending on the standard these are latched, i.e. reflect threshold crossings since they have last been read.
+type AlarmFlags interface {
+	GetHighAlarm() bool
+	GetHighWarning() bool
+	GetLowAlarm() bool
+	GetLowWarning() bool
+}
+
+// LaneFlags loss of signal, loss of lock and fault flags of a lane, false if not implemented by the module
+type LaneFlags interface {
+	GetTxFault() bool
+	GetTxLOS() bool
+	GetTxLOL() bool
+	GetRxLOS() bool
+	GetRxLOL() bool
+}
+
+// FaultReporter is optionally implemented by EEPROMs of modules reporting fault flags, check using a type assertion
+type FaultReporter interface {
+	// GetModuleFaults returns descriptions of the module wide faults currently reported
+	GetModuleFaults() []string
+	// GetLaneFaults returns descriptions of the faults currently reported per lane (0 being the first lane),
+	// lanes without faults are omitted
+	GetLaneFaults() map[int][]string
+}
@@ -0,0 +1,32 @@
+package eeprom
+
+import (
+	"fmt"
+)
+
+// PutString writes value into the ASCII field raw, padded with spaces.
+// Fails if value is longer than the field or contains non printable ASCII characters.
+func PutString(field string, raw []byte, value string) error {
+	if len(value) > len(raw) {
+		return fmt.Errorf("%s %q exceeds the field length of %d bytes", field, value, len(raw))
+	}
+	for _, r := range value {
+		if r < 0x20 || r > 0x7E {
+			return fmt.Errorf("%s %q contains non printable ASCII characters", field, value)
+		}
+	}
+	for i := range raw {
+		raw[i] = ' '
+	}
+	copy(raw, value)
+	return nil
+}
+
+// SetBits sets or clears the bits of mask in b
+func SetBits(b *byte, mask byte, value bool) {
+	if value {
+		*b |= mask
+	} else {
+		*b &^= mask
+	}
+}
@@ -0,0 +1,28 @@
+package eeprom
+
+// knownQuirks workarounds for transceivers known to report broken EEPROM contents, registered by default.
+// Entries are declared as follows:
+//
+//	{
+//		Name:              "Example SFP-10G-LR reports Rx power in units of 0.01 uW",
+//		Match:             QuirkMatch{VendorName: "EXAMPLE", VendorPN: "SFP-10G-LR*"},
+//		Calibration:       map[Diagnostic]Calibration{DiagnosticRxPower: {Slope: 10}},
+//		IgnoreDiagnostics: []Diagnostic{DiagnosticVoltage},
+//	},
+var knownQuirks = []*Quirk{
+	{
+		// some drivers (e.g. sx_netdev) return garbage instead of the EEPROM, recognizable by the vendor name
+		Name: "Garbage EEPROM returned by the driver, vendor name starts with a slash or is invalid UTF-8",
+		Match: QuirkMatch{ParseWarnings: []ParseWarningMatch{
+			{Field: "VendorName", Reason: ParseWarningLeadingSlash},
+			{Field: "VendorName", Reason: ParseWarningInvalidUTF8},
+		}},
+		IgnoreDiagnostics: []Diagnostic{
+			DiagnosticTemperature,
+			DiagnosticVoltage,
+			DiagnosticBias,
+			DiagnosticTxPower,
+			DiagnosticRxPower,
+		},
+	},
+}
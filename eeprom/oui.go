@@ -16,14 +16,25 @@ func (o OUI) String() string {
 // MarshalJSON implements the encoding/json/Marshaler interface's MarshalJSON function
 func (o OUI) MarshalJSON() ([]byte, error) {
 	return json.Marshal(map[string]string{
-		"hex":   fmt.Sprintf("%x", uint32(o)&0xFFFFFF),
-		"ascii": o.String(),
+		"hex":          fmt.Sprintf("%x", uint32(o)&0xFFFFFF),
+		"ascii":        o.String(),
+		"organization": o.Lookup(),
 	})
 }
 
+// Lookup returns the name of the organization the OUI is assigned to, an empty string if unknown.
+// The embedded registry only covers common transceiver vendors, load the full IEEE registry
+// with LoadOUIRegistryFile or SetOUIRegistry to look up any other OUI.
+func (o OUI) Lookup() string {
+	return getOUIRegistry()[o]
+}
+
 // NewOUI parses [3]byte into an OUI instance
 func NewOUI(raw [3]byte) OUI {
 	return OUI(uint32(raw[0])<<16 | uint32(raw[1])<<8 | uint32(raw[2]))
 }
 
-// TODO provide a function to perform a lookup against a local copy of http://standards-oui.ieee.org/oui.txt
+// Encode encodes the OUI into its [3]byte representation as parsed by NewOUI
+func (o OUI) Encode() [3]byte {
+	return [3]byte{byte(o >> 16), byte(o >> 8), byte(o)}
+}
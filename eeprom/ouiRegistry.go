@@ -0,0 +1,170 @@
+package eeprom
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ouiCuratedCSV curated subset of the IEEE MA-L registry covering common transceiver and network equipment vendors,
+// in the CSV format written by OUIRegistry.WriteCSV
+//
+//go:embed ouiCurated.csv
+var ouiCuratedCSV []byte
+
+var (
+	ouiRegistry      OUIRegistry
+	ouiRegistryMutex sync.RWMutex
+)
+
+// ouiTxtLine matches an assignment of the IEEE oui.txt format, e.g. "00-90-65   (hex)		Finisar Corporation"
+var ouiTxtLine = regexp.MustCompile(`^([0-9A-Fa-f]{2})-([0-9A-Fa-f]{2})-([0-9A-Fa-f]{2})\s+\(hex\)\s+(.*)$`)
+
+// OUIRegistry maps IEEE company IDs to the names of the organizations they are assigned to
+type OUIRegistry map[OUI]string
+
+// SetOUIRegistry replaces the registry used by OUI.Lookup, nil restores the embedded registry
+func SetOUIRegistry(registry OUIRegistry) {
+	ouiRegistryMutex.Lock()
+	defer ouiRegistryMutex.Unlock()
+	ouiRegistry = registry
+}
+
+// LoadOUIRegistryFile replaces the registry used by OUI.Lookup with a local copy of the IEEE oui.txt or oui.csv
+func LoadOUIRegistryFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	registry, err := ParseOUIRegistry(file)
+	if err != nil {
+		return err
+	}
+	SetOUIRegistry(registry)
+	return nil
+}
+
+// getOUIRegistry returns the registry used by OUI.Lookup, parsing the embedded registry on first use
+func getOUIRegistry() OUIRegistry {
+	ouiRegistryMutex.RLock()
+	registry := ouiRegistry
+	ouiRegistryMutex.RUnlock()
+	if registry != nil {
+		return registry
+	}
+
+	ouiRegistryMutex.Lock()
+	defer ouiRegistryMutex.Unlock()
+	if ouiRegistry == nil {
+		// the embedded registry is written by ParseOUIRegistry's counterpart WriteCSV and can not fail to parse
+		ouiRegistry, _ = ParseOUIRegistry(bytes.NewReader(ouiCuratedCSV))
+	}
+	return ouiRegistry
+}
+
+// ParseOUIRegistry parses the IEEE MA-L registry in its oui.txt (http://standards-oui.ieee.org/oui/oui.txt)
+// or oui.csv (http://standards-oui.ieee.org/oui/oui.csv) format
+func ParseOUIRegistry(r io.Reader) (OUIRegistry, error) {
+	reader := bufio.NewReader(r)
+	firstLine, err := reader.Peek(256)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if bytes.Contains(firstLine, []byte("Assignment,")) {
+		return parseOUICSV(reader)
+	}
+	return parseOUITxt(reader)
+}
+
+// parseOUICSV parses the registry in CSV format, identifying the columns by the header
+func parseOUICSV(r io.Reader) (OUIRegistry, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("OUI registry is empty")
+	}
+	registryColumn, assignmentColumn, organizationColumn := -1, -1, -1
+	for i, column := range records[0] {
+		switch column {
+		case "Registry":
+			registryColumn = i
+		case "Assignment":
+			assignmentColumn = i
+		case "Organization Name":
+			organizationColumn = i
+		}
+	}
+	if assignmentColumn < 0 || organizationColumn < 0 {
+		return nil, fmt.Errorf("OUI registry lacks the Assignment or Organization Name column")
+	}
+
+	registry := OUIRegistry{}
+	for _, record := range records[1:] {
+		if registryColumn >= 0 && record[registryColumn] != "MA-L" {
+			continue
+		}
+		oui, err := strconv.ParseUint(record[assignmentColumn], 16, 24)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid OUI assignment %q: %v", record[assignmentColumn], err)
+		}
+		registry[OUI(oui)] = strings.TrimSpace(record[organizationColumn])
+	}
+	return registry, nil
+}
+
+// parseOUITxt parses the registry in the oui.txt format, only the "(hex)" lines are considered
+func parseOUITxt(r io.Reader) (OUIRegistry, error) {
+	registry := OUIRegistry{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		match := ouiTxtLine.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if match == nil {
+			continue
+		}
+		oui, err := strconv.ParseUint(match[1]+match[2]+match[3], 16, 24)
+		if err != nil {
+			return nil, err
+		}
+		registry[OUI(oui)] = strings.TrimSpace(match[4])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(registry) == 0 {
+		return nil, fmt.Errorf("No OUI assignments found")
+	}
+	return registry, nil
+}
+
+// WriteCSV writes the registry sorted by OUI in a reduced form of the IEEE CSV format, as embedded in this package
+func (r OUIRegistry) WriteCSV(w io.Writer) error {
+	ouis := make([]OUI, 0, len(r))
+	for oui := range r {
+		ouis = append(ouis, oui)
+	}
+	sort.Slice(ouis, func(i, j int) bool { return ouis[i] < ouis[j] })
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"Registry", "Assignment", "Organization Name"}); err != nil {
+		return err
+	}
+	for _, oui := range ouis {
+		if err := writer.Write([]string{"MA-L", fmt.Sprintf("%06X", uint32(oui)), r[oui]}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
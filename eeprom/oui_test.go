@@ -0,0 +1,119 @@
+package eeprom
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const ouiTxt = "OUI/MA-L\t\t\tOrganization\ncompany_id\t\t\tOrganization\n\n00-05-1E   (hex)\t\tBrocade\n00051E     (base 16)\t\tBrocade\n"
+
+func TestOUILookup(t *testing.T) {
+	t.Cleanup(func() { SetOUIRegistry(nil) })
+
+	if organization := OUI(0x00051E).Lookup(); organization != "Brocade Communications Systems LLC" {
+		t.Errorf("Unexpected organization %q of the embedded registry", organization)
+	}
+	if organization := OUI(0x123456).Lookup(); organization != "" {
+		t.Errorf("Expected no organization for an unassigned OUI, got %q", organization)
+	}
+
+	registry, err := ParseOUIRegistry(strings.NewReader(ouiTxt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetOUIRegistry(registry)
+	if organization := OUI(0x00051E).Lookup(); organization != "Brocade" {
+		t.Errorf("Unexpected organization %q of the replaced registry", organization)
+	}
+	if organization := OUI(0x009065).Lookup(); organization != "" {
+		t.Errorf("Expected no organization missing in the replaced registry, got %q", organization)
+	}
+
+	SetOUIRegistry(nil)
+	if organization := OUI(0x009065).Lookup(); organization != "Finisar Corporation" {
+		t.Errorf("Unexpected organization %q of the restored registry", organization)
+	}
+}
+
+func TestLoadOUIRegistryFile(t *testing.T) {
+	t.Cleanup(func() { SetOUIRegistry(nil) })
+	directory := t.TempDir()
+
+	txtPath := filepath.Join(directory, "oui.txt")
+	if err := os.WriteFile(txtPath, []byte(ouiTxt), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadOUIRegistryFile(txtPath); err != nil {
+		t.Fatal(err)
+	}
+	if organization := OUI(0x00051E).Lookup(); organization != "Brocade" {
+		t.Errorf("Unexpected organization %q loaded from oui.txt", organization)
+	}
+
+	csvPath := filepath.Join(directory, "oui.csv")
+	csv := "Registry,Assignment,Organization Name,Organization Address\nMA-L,009065,Finisar Corp.,\"1389 Moffett Park Dr, Sunnyvale\"\nMA-M,0090651,Not an MA-L assignment,\n"
+	if err := os.WriteFile(csvPath, []byte(csv), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadOUIRegistryFile(csvPath); err != nil {
+		t.Fatal(err)
+	}
+	if organization := OUI(0x009065).Lookup(); organization != "Finisar Corp." {
+		t.Errorf("Unexpected organization %q loaded from oui.csv", organization)
+	}
+	if organization := OUI(0x00051E).Lookup(); organization != "" {
+		t.Errorf("Expected the registry loaded from oui.txt to be replaced, got %q", organization)
+	}
+
+	if err := LoadOUIRegistryFile(filepath.Join(directory, "missing.txt")); err == nil {
+		t.Error("Expected loading a missing file to fail")
+	}
+	if organization := OUI(0x009065).Lookup(); organization != "Finisar Corp." {
+		t.Errorf("Expected a failed load to keep the registry, got %q", organization)
+	}
+}
+
+func TestOUIRegistryWriteCSV(t *testing.T) {
+	registry := OUIRegistry{0x009065: "Finisar Corporation", 0x00000C: "Cisco Systems, Inc"}
+	buffer := &bytes.Buffer{}
+	if err := registry.WriteCSV(buffer); err != nil {
+		t.Fatal(err)
+	}
+	expected := "Registry,Assignment,Organization Name\nMA-L,00000C,\"Cisco Systems, Inc\"\nMA-L,009065,Finisar Corporation\n"
+	if buffer.String() != expected {
+		t.Errorf("Unexpected CSV:\n%s", buffer.String())
+	}
+	parsed, err := ParseOUIRegistry(buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(parsed, registry) {
+		t.Errorf("Expected %v, got %v", registry, parsed)
+	}
+}
+
+func TestOUIMarshalJSON(t *testing.T) {
+	t.Cleanup(func() { SetOUIRegistry(nil) })
+
+	encoded, err := json.Marshal(OUI(0x009065))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(encoded) != `{"ascii":"00:90:65","hex":"9065","organization":"Finisar Corporation"}` {
+		t.Errorf("Unexpected JSON %s", encoded)
+	}
+
+	SetOUIRegistry(OUIRegistry{})
+	encoded, err = json.Marshal(OUI(0x009065))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(encoded) != `{"ascii":"00:90:65","hex":"9065","organization":""}` {
+		t.Errorf("Unexpected JSON %s for an unknown OUI", encoded)
+	}
+}
@@ -0,0 +1,94 @@
+package eeprom
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ParseWarning an anomaly found while parsing an EEPROM, the affected field has been decoded on a best-effort basis
+type ParseWarning struct {
+	// Field name of the affected field, e.g. "VendorName"
+	Field string `json:"field"`
+	// Offset of the field's first byte in the linear EEPROM layout
+	Offset int `json:"offset"`
+	// Raw bytes of the field
+	Raw    []byte `json:"raw"`
+	Reason string `json:"reason"`
+}
+
+func (p ParseWarning) String() string {
+	return fmt.Sprintf("%s at %#02x (%s): %s", p.Field, p.Offset, hex.EncodeToString(p.Raw), p.Reason)
+}
+
+// Reasons of ParseWarnings returned by CheckStringField
+const (
+	ParseWarningInvalidUTF8  = "Invalid UTF-8, decoded as hex"
+	ParseWarningNonPrintable = "Non printable ASCII characters"
+	ParseWarningLeadingSlash = "Leading slash, likely garbage returned by the driver"
+)
+
+// StringField an ASCII field of the EEPROM, e.g. vendor name or part number, spanning the bytes Start to End (inclusive)
+type StringField struct {
+	Name  string
+	Start int
+	End   int
+}
+
+// CheckStringFields returns a ParseWarning for each field of raw which is not plausible ASCII
+func CheckStringFields(raw []byte, fields []StringField) []ParseWarning {
+	warnings := []ParseWarning{}
+	for _, field := range fields {
+		if warning := CheckStringField(field.Name, field.Start, raw[field.Start:field.End+1]); warning != nil {
+			warnings = append(warnings, *warning)
+		}
+	}
+	return warnings
+}
+
+// CheckStringField returns a ParseWarning if raw is not plausible ASCII padded with spaces or null bytes, nil otherwise
+func CheckStringField(field string, offset int, raw []byte) *ParseWarning {
+	reason := ""
+	trimmed := bytes.Trim(raw, "\x00")
+	if !utf8.Valid(trimmed) {
+		reason = ParseWarningInvalidUTF8
+	} else if strings.IndexFunc(string(trimmed), func(r rune) bool { return r < 0x20 || r > 0x7E }) >= 0 {
+		reason = ParseWarningNonPrintable
+	} else if strings.HasPrefix(string(trimmed), "/") {
+		reason = ParseWarningLeadingSlash
+	}
+	if reason == "" {
+		return nil
+	}
+	return &ParseWarning{
+		Field:  field,
+		Offset: offset,
+		Raw:    append([]byte{}, raw...),
+		Reason: reason,
+	}
+}
+
+// ParseDateCode parses the YYMMDD date code at the start of dateCode, any following lot code is ignored
+func ParseDateCode(dateCode string) (time.Time, error) {
+	dateCode = strings.Trim(dateCode, " ")
+	if len(dateCode) > 6 {
+		dateCode = dateCode[:6]
+	}
+	return time.Parse("060102", dateCode)
+}
+
+// CheckDateCode returns a ParseWarning if raw does not start with a valid YYMMDD date code, nil otherwise
+func CheckDateCode(field string, offset int, raw []byte) *ParseWarning {
+	if _, err := ParseDateCode(string(raw)); err != nil {
+		return &ParseWarning{
+			Field:  field,
+			Offset: offset,
+			Raw:    append([]byte{}, raw...),
+			Reason: fmt.Sprintf("Invalid date code: %v", err),
+		}
+	}
+	return nil
+}
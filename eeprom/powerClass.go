@@ -24,6 +24,8 @@ const (
 	PowerClass6
 	// PowerClass7 up to 5.0 Watts
 	PowerClass7
+	// PowerClass8 more than 5.0 Watts, the maximum is specified by the module
+	PowerClass8
 )
 
 // GetMaxPower returns the maximum power in watts for a given PowerClass
@@ -40,6 +42,9 @@ func (p PowerClass) GetMaxPower() float64 {
 }
 
 func (p PowerClass) String() string {
+	if p == PowerClass8 {
+		return "Power Level 8 (more than 5.00 W)"
+	}
 	return fmt.Sprintf("Power Level %d (max %.2f W)", byte(p), p.GetMaxPower())
 }
 
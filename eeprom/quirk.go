@@ -0,0 +1,226 @@
+package eeprom
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Diagnostic a monitored quantity quirks can be applied to
+type Diagnostic string
+
+// Diagnostics of the module and its lasers
+const (
+	DiagnosticTemperature Diagnostic = "temperature"
+	DiagnosticVoltage     Diagnostic = "voltage"
+	DiagnosticBias        Diagnostic = "bias"
+	DiagnosticTxPower     Diagnostic = "txPower"
+	DiagnosticRxPower     Diagnostic = "rxPower"
+)
+
+// Calibration linear correction of a diagnostic's values and thresholds: Slope * value + Offset, a Slope of 0 is treated as 1
+type Calibration struct {
+	Slope  float64
+	Offset float64
+}
+
+// Apply returns the corrected value
+func (c Calibration) Apply(value float64) float64 {
+	slope := c.Slope
+	if slope == 0 {
+		slope = 1
+	}
+	return slope*value + c.Offset
+}
+
+// ThresholdValues alarm and warning thresholds in the unit of the diagnostic, milliwatts for power
+type ThresholdValues struct {
+	HighAlarm   float64
+	HighWarning float64
+	LowAlarm    float64
+	LowWarning  float64
+}
+
+// QuirkMatch identifies the affected modules, empty fields match any module.
+// The vendor fields are compared case-insensitively and may end in "*" to match a prefix.
+type QuirkMatch struct {
+	VendorName string
+	// VendorOUI formatted as "00:90:65"
+	VendorOUI string
+	VendorPN  string
+	VendorRev string
+	// ParseWarnings matches modules with a ParseWarning matching any of the given ones, if set
+	ParseWarnings []ParseWarningMatch
+}
+
+// ParseWarningMatch identifies a ParseWarning by its field and reason, empty fields match any warning
+type ParseWarningMatch struct {
+	Field  string
+	Reason string
+}
+
+// Matches returns true if warning is matched by all non-empty fields
+func (m ParseWarningMatch) Matches(warning ParseWarning) bool {
+	return (m.Field == "" || m.Field == warning.Field) && (m.Reason == "" || m.Reason == warning.Reason)
+}
+
+// Matches returns true if e is matched by all non-empty fields
+func (m QuirkMatch) Matches(e EEPROM) bool {
+	return matchQuirkField(m.VendorName, e.GetVendorName()) &&
+		matchQuirkField(m.VendorOUI, e.GetVendorOUI().String()) &&
+		matchQuirkField(m.VendorPN, e.GetVendorPN()) &&
+		matchQuirkField(m.VendorRev, e.GetVendorRev()) &&
+		matchParseWarnings(m.ParseWarnings, e.GetParseWarnings())
+}
+
+func matchParseWarnings(matches []ParseWarningMatch, warnings []ParseWarning) bool {
+	if len(matches) == 0 {
+		return true
+	}
+	for _, match := range matches {
+		for _, warning := range warnings {
+			if match.Matches(warning) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchQuirkField(pattern string, value string) bool {
+	if pattern == "" {
+		return true
+	}
+	value = strings.ToLower(strings.TrimSpace(value))
+	pattern = strings.ToLower(pattern)
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(value, strings.TrimSuffix(pattern, "*"))
+	}
+	return value == pattern
+}
+
+// Quirk workaround for a known-broken transceiver model
+type Quirk struct {
+	// Name describing the quirk, reported once applied
+	Name  string
+	Match QuirkMatch
+	// ForceType parses matching modules according to the given standard instead of the detected one, if set
+	ForceType Type
+	// Calibration corrects values and thresholds of the given diagnostics
+	Calibration map[Diagnostic]Calibration
+	// Thresholds replace the thresholds of the given diagnostics, applied after Calibration
+	Thresholds map[Diagnostic]ThresholdValues
+	// IgnoreDiagnostics broken diagnostics, reported as not available
+	IgnoreDiagnostics []Diagnostic
+}
+
+// CorrectValue returns value of diagnostic with the quirk's calibration applied
+func (q *Quirk) CorrectValue(diagnostic Diagnostic, value float64) float64 {
+	if calibration, ok := q.Calibration[diagnostic]; ok {
+		return calibration.Apply(value)
+	}
+	return value
+}
+
+// CorrectThresholds returns thresholds of diagnostic with the quirk's calibration applied or replaced by the quirk's thresholds
+func (q *Quirk) CorrectThresholds(diagnostic Diagnostic, thresholds ThresholdValues) ThresholdValues {
+	if override, ok := q.Thresholds[diagnostic]; ok {
+		return override
+	}
+	return ThresholdValues{
+		HighAlarm:   q.CorrectValue(diagnostic, thresholds.HighAlarm),
+		HighWarning: q.CorrectValue(diagnostic, thresholds.HighWarning),
+		LowAlarm:    q.CorrectValue(diagnostic, thresholds.LowAlarm),
+		LowWarning:  q.CorrectValue(diagnostic, thresholds.LowWarning),
+	}
+}
+
+// Ignores returns true if diagnostic is broken and should be reported as not available
+func (q *Quirk) Ignores(diagnostic Diagnostic) bool {
+	for _, ignored := range q.IgnoreDiagnostics {
+		if ignored == diagnostic {
+			return true
+		}
+	}
+	return false
+}
+
+// IgnoredDiagnosticError returned for diagnostics ignored due to a quirk
+func IgnoredDiagnosticError(diagnostic Diagnostic) error {
+	return fmt.Errorf("Diagnostic %s is ignored due to a quirk of this module", diagnostic)
+}
+
+// QuirkApplier is optionally implemented by EEPROMs supporting quirks, check using a type assertion
+type QuirkApplier interface {
+	// ApplyQuirk corrects the parsed EEPROM according to quirk and records it as applied
+	ApplyQuirk(quirk *Quirk)
+	// GetAppliedQuirks returns the names of the applied quirks
+	GetAppliedQuirks() []string
+}
+
+var (
+	quirks      = append([]*Quirk{}, knownQuirks...)
+	quirksMutex sync.RWMutex
+)
+
+// RegisterQuirk adds quirk to the registry consulted by ApplyQuirks
+func RegisterQuirk(quirk *Quirk) {
+	quirksMutex.Lock()
+	defer quirksMutex.Unlock()
+	quirks = append(quirks, quirk)
+}
+
+// UnregisterQuirk removes quirk registered by RegisterQuirk or by default from the registry
+func UnregisterQuirk(quirk *Quirk) {
+	quirksMutex.Lock()
+	defer quirksMutex.Unlock()
+	for i, registered := range quirks {
+		if registered == quirk {
+			quirks = append(quirks[:i:i], quirks[i+1:]...)
+			return
+		}
+	}
+}
+
+// GetQuirks returns the registered quirks matching e
+func GetQuirks(e EEPROM) []*Quirk {
+	quirksMutex.RLock()
+	defer quirksMutex.RUnlock()
+	matching := []*Quirk{}
+	for _, quirk := range quirks {
+		if quirk.Match.Matches(e) {
+			matching = append(matching, quirk)
+		}
+	}
+	return matching
+}
+
+// ApplyQuirks applies the registered quirks matching e, which has been parsed from raw.
+// If a quirk forces a different standard, raw is parsed again using the registered decoder of that standard.
+func ApplyQuirks(e EEPROM, raw []byte, options *ParseOptions) (EEPROM, error) {
+	matching := GetQuirks(e)
+	for _, quirk := range matching {
+		if quirk.ForceType == 0 {
+			continue
+		}
+		decoder, ok := decoders[quirk.ForceType]
+		if !ok {
+			return nil, fmt.Errorf("No decoder registered for EEPROM type %s forced by quirk %q", quirk.ForceType, quirk.Name)
+		}
+		forced, err := decoder(raw, options)
+		if err != nil {
+			return nil, err
+		}
+		e = forced
+		break
+	}
+
+	applier, ok := e.(QuirkApplier)
+	if !ok {
+		return e, nil
+	}
+	for _, quirk := range matching {
+		applier.ApplyQuirk(quirk)
+	}
+	return e, nil
+}
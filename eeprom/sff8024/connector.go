@@ -47,6 +47,14 @@ const (
 	ConnectorNoSeparable ConnectorType = 0x23
 	// ConnectorMxc2x16 MXC 2x16
 	ConnectorMxc2x16 ConnectorType = 0x24
+	// ConnectorCs CS optical connector
+	ConnectorCs ConnectorType = 0x25
+	// ConnectorSn SN (previously Mini CS) optical connector
+	ConnectorSn ConnectorType = 0x26
+	// ConnectorMpo2x12 MPO 2x12
+	ConnectorMpo2x12 ConnectorType = 0x27
+	// ConnectorMpo1x16 MPO 1x16
+	ConnectorMpo1x16 ConnectorType = 0x28
 	// ConnectorVendorStart Start of vendor specific connector types
 	ConnectorVendorStart ConnectorType = 0x80
 	// ConnectorVendorEnd End of vendor specific connector types
@@ -74,6 +82,10 @@ func (c ConnectorType) String() string {
 		ConnectorRj45:        "RJ45",
 		ConnectorNoSeparable: "No separable connector",
 		ConnectorMxc2x16:     "MXC 2x16",
+		ConnectorCs:          "CS optical connector",
+		ConnectorSn:          "SN optical connector",
+		ConnectorMpo2x12:     "MPO 2x12",
+		ConnectorMpo1x16:     "MPO 1x16",
 	}
 
 	str, found := mapping[c]
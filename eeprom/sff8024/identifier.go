@@ -57,6 +57,26 @@ const (
 	IdentifierCdfpStyle3 Identifier = 0x16
 	// IdentifierMicroQsfp MicroQSFP
 	IdentifierMicroQsfp Identifier = 0x17
+	// IdentifierQsfpDd QSFP-DD Double Density 8X Pluggable Transceiver
+	IdentifierQsfpDd Identifier = 0x18
+	// IdentifierOsfp OSFP 8X Pluggable Transceiver
+	IdentifierOsfp Identifier = 0x19
+	// IdentifierSfpDd SFP-DD Double Density 2X Pluggable Transceiver with SFP-DD Management Interface Specification
+	IdentifierSfpDd Identifier = 0x1A
+	// IdentifierDsfp DSFP Dual Small Form Factor Pluggable Transceiver
+	IdentifierDsfp Identifier = 0x1B
+	// IdentifierMiniLink4x x4 MiniLink/OcuLink
+	IdentifierMiniLink4x Identifier = 0x1C
+	// IdentifierMiniLink8x x8 MiniLink
+	IdentifierMiniLink8x Identifier = 0x1D
+	// IdentifierQsfpCmis QSFP+ or later with Common Management Interface Specification (CMIS)
+	IdentifierQsfpCmis Identifier = 0x1E
+	// IdentifierSfpDdCmis SFP-DD Double Density 2X Pluggable Transceiver with Common Management Interface Specification (CMIS)
+	IdentifierSfpDdCmis Identifier = 0x1F
+	// IdentifierSfpPlusCmis SFP+ and later with Common Management Interface Specification (CMIS)
+	IdentifierSfpPlusCmis Identifier = 0x20
+	// IdentifierOsfpXd OSFP-XD with Common Management Interface Specification (CMIS)
+	IdentifierOsfpXd Identifier = 0x21
 	// IdentifierVendorStart Start of vendor specific identifier range
 	IdentifierVendorStart Identifier = 0x80
 	// IdentifierVendorEnd End of vendor specific identifier range
@@ -65,30 +85,40 @@ const (
 
 func (i Identifier) String() string {
 	mapping := map[Identifier]string{
-		IdentifierUnknown:    "No module present, unknown, or unspecified",
-		IdentifierGbic:       "GBIC",
-		IdentifierSoldered:   "Module soldered to motherboard",
-		IdentifierSfp:        "SFP",
-		Identifier300PinXbi:  "300 pin XBI",
-		IdentifierXenpak:     "XENPAK",
-		IdentifierXfp:        "XFP",
-		IdentifierXff:        "XFF",
-		IdentifierXfpE:       "XFP-E",
-		IdentifierXpak:       "XPAK",
-		IdentifierX2:         "X2",
-		IdentifierDwdmSfp:    "DWDM-SFP",
-		IdentifierQsfp:       "QSFP",
-		IdentifierQsfpPlus:   "QSFP+",
-		IdentifierCxp:        "CXP",
-		IdentifierHd4x:       "Shielded Mini Multilane HD 4X",
-		IdentifierHd8x:       "Shielded Mini Multilane HD 8X",
-		IdentifierQsfp28:     "QSFP28",
-		IdentifierCxp2:       "CXP2/CXP28",
-		IdentifierCdfp:       "CDFP Style 1/Style 2",
-		IdentifierHd4xFanout: "Shielded Mini Multilane HD 4X Fanout Cable",
-		IdentifierHd8xFanout: "Shielded Mini Multilane HD 8X Fanout Cable",
-		IdentifierCdfpStyle3: "CDFP Style 3",
-		IdentifierMicroQsfp:  "MicroQSFP",
+		IdentifierUnknown:     "No module present, unknown, or unspecified",
+		IdentifierGbic:        "GBIC",
+		IdentifierSoldered:    "Module soldered to motherboard",
+		IdentifierSfp:         "SFP",
+		Identifier300PinXbi:   "300 pin XBI",
+		IdentifierXenpak:      "XENPAK",
+		IdentifierXfp:         "XFP",
+		IdentifierXff:         "XFF",
+		IdentifierXfpE:        "XFP-E",
+		IdentifierXpak:        "XPAK",
+		IdentifierX2:          "X2",
+		IdentifierDwdmSfp:     "DWDM-SFP",
+		IdentifierQsfp:        "QSFP",
+		IdentifierQsfpPlus:    "QSFP+",
+		IdentifierCxp:         "CXP",
+		IdentifierHd4x:        "Shielded Mini Multilane HD 4X",
+		IdentifierHd8x:        "Shielded Mini Multilane HD 8X",
+		IdentifierQsfp28:      "QSFP28",
+		IdentifierCxp2:        "CXP2/CXP28",
+		IdentifierCdfp:        "CDFP Style 1/Style 2",
+		IdentifierHd4xFanout:  "Shielded Mini Multilane HD 4X Fanout Cable",
+		IdentifierHd8xFanout:  "Shielded Mini Multilane HD 8X Fanout Cable",
+		IdentifierCdfpStyle3:  "CDFP Style 3",
+		IdentifierMicroQsfp:   "MicroQSFP",
+		IdentifierQsfpDd:      "QSFP-DD",
+		IdentifierOsfp:        "OSFP",
+		IdentifierSfpDd:       "SFP-DD",
+		IdentifierDsfp:        "DSFP",
+		IdentifierMiniLink4x:  "x4 MiniLink/OcuLink",
+		IdentifierMiniLink8x:  "x8 MiniLink",
+		IdentifierQsfpCmis:    "QSFP+ or later with CMIS",
+		IdentifierSfpDdCmis:   "SFP-DD with CMIS",
+		IdentifierSfpPlusCmis: "SFP+ or later with CMIS",
+		IdentifierOsfpXd:      "OSFP-XD",
 	}
 
 	str, found := mapping[i]
@@ -102,6 +132,16 @@ func (i Identifier) String() string {
 	}
 }
 
+// UsesCMIS returns true if modules of this type are managed according to the Common Management Interface Specification (CMIS)
+func (i Identifier) UsesCMIS() bool {
+	switch i {
+	case IdentifierQsfpDd, IdentifierOsfp, IdentifierQsfpCmis, IdentifierSfpDdCmis, IdentifierSfpPlusCmis, IdentifierOsfpXd:
+		return true
+	default:
+		return false
+	}
+}
+
 // MarshalJSON implements the encoding/json/Marshaler interface's MarshalJSON function
 func (i Identifier) MarshalJSON() ([]byte, error) {
 	return json.Marshal(map[string]string{
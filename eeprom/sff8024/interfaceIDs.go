@@ -0,0 +1,297 @@
+package sff8024
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// InterfaceSpecification properties of a host electrical or module media interface as listed in SFF-8024 tables 4-5 to 4-10
+type InterfaceSpecification struct {
+	Name      string
+	LaneCount int
+	// Signaling rate per lane in GBd
+	LaneSignalingRate float64
+	Modulation        string
+}
+
+func (i InterfaceSpecification) String() string {
+	return i.Name
+}
+
+const (
+	modulationNRZ     = "NRZ"
+	modulationPAM4    = "PAM4"
+	modulationDP16QAM = "DP-16QAM"
+)
+
+// interfaceIDEndOfList marks the end of a CMIS application list
+const interfaceIDEndOfList = 0xFF
+
+func lookupInterfaceSpecification(table map[byte]InterfaceSpecification, id byte) (InterfaceSpecification, bool) {
+	spec, found := table[id]
+	if found {
+		return spec, true
+	}
+	name := "Reserved"
+	switch {
+	case id == 0x00:
+		name = "Undefined"
+	case id == interfaceIDEndOfList:
+		name = "End of list"
+	case id >= 0xC0 && id <= 0xFE:
+		name = "Custom"
+	}
+	return InterfaceSpecification{Name: name}, false
+}
+
+func marshalInterfaceID(id byte, spec InterfaceSpecification) ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"ascii":             spec.Name,
+		"hex":               fmt.Sprintf("%#02x", id),
+		"laneCount":         spec.LaneCount,
+		"laneSignalingRate": spec.LaneSignalingRate,
+		"modulation":        spec.Modulation,
+	})
+}
+
+// HostInterfaceID host electrical interface code as defined in SFF-8024 table 4-5
+type HostInterfaceID byte
+
+var hostInterfaces = map[byte]InterfaceSpecification{
+	0x01: {"1000BASE-CX (Clause 39)", 1, 1.25, modulationNRZ},
+	0x02: {"XAUI (Clause 47)", 4, 3.125, modulationNRZ},
+	0x03: {"XFI (SFF INF-8071i)", 1, 10.3125, modulationNRZ},
+	0x04: {"SFI (SFF-8431)", 1, 10.3125, modulationNRZ},
+	0x05: {"25GAUI C2M (Annex 109B)", 1, 25.78125, modulationNRZ},
+	0x06: {"XLAUI C2M (Annex 83B)", 4, 10.3125, modulationNRZ},
+	0x07: {"XLPPI (Annex 86A)", 4, 10.3125, modulationNRZ},
+	0x08: {"LAUI-2 C2M (Annex 135C)", 2, 25.78125, modulationNRZ},
+	0x09: {"50GAUI-2 C2M (Annex 135E)", 2, 26.5625, modulationNRZ},
+	0x0A: {"50GAUI-1 C2M (Annex 135G)", 1, 26.5625, modulationPAM4},
+	0x0B: {"CAUI-4 C2M (Annex 83E)", 4, 25.78125, modulationNRZ},
+	0x0C: {"100GAUI-4 C2M (Annex 135E)", 4, 26.5625, modulationNRZ},
+	0x0D: {"100GAUI-2 C2M (Annex 135G)", 2, 26.5625, modulationPAM4},
+	0x0E: {"200GAUI-8 C2M (Annex 120C)", 8, 26.5625, modulationNRZ},
+	0x0F: {"200GAUI-4 C2M (Annex 120E)", 4, 26.5625, modulationPAM4},
+	0x10: {"400GAUI-16 C2M (Annex 120C)", 16, 26.5625, modulationNRZ},
+	0x11: {"400GAUI-8 C2M (Annex 120E)", 8, 26.5625, modulationPAM4},
+	0x13: {"10GBASE-CX4 (Clause 54)", 4, 3.125, modulationNRZ},
+	0x14: {"25GBASE-CR CA-25G-L (Clause 110)", 1, 25.78125, modulationNRZ},
+	0x15: {"25GBASE-CR or 25GBASE-CR-S CA-25G-S (Clause 110)", 1, 25.78125, modulationNRZ},
+	0x16: {"25GBASE-CR or 25GBASE-CR-S CA-25G-N (Clause 110)", 1, 25.78125, modulationNRZ},
+	0x17: {"40GBASE-CR4 (Clause 85)", 4, 10.3125, modulationNRZ},
+	0x18: {"50GBASE-CR (Clause 136)", 1, 26.5625, modulationPAM4},
+	0x1A: {"100GBASE-CR4 (Clause 92)", 4, 25.78125, modulationNRZ},
+	0x1B: {"100GBASE-CR2 (Clause 136)", 2, 26.5625, modulationPAM4},
+	0x1C: {"200GBASE-CR4 (Clause 136)", 4, 26.5625, modulationPAM4},
+	0x1D: {"400G CR8 (ETC)", 8, 26.5625, modulationPAM4},
+	0x4B: {"100GAUI-1-S C2M (Annex 120G)", 1, 53.125, modulationPAM4},
+	0x4C: {"100GAUI-1-L C2M (Annex 120G)", 1, 53.125, modulationPAM4},
+	0x4D: {"200GAUI-2-S C2M (Annex 120G)", 2, 53.125, modulationPAM4},
+	0x4E: {"200GAUI-2-L C2M (Annex 120G)", 2, 53.125, modulationPAM4},
+	0x4F: {"400GAUI-4-S C2M (Annex 120G)", 4, 53.125, modulationPAM4},
+	0x50: {"400GAUI-4-L C2M (Annex 120G)", 4, 53.125, modulationPAM4},
+	0x51: {"800G S C2M (ETC)", 8, 53.125, modulationPAM4},
+	0x52: {"800G L C2M (ETC)", 8, 53.125, modulationPAM4},
+}
+
+// Specification returns the interface's properties, false if the ID is unknown
+func (h HostInterfaceID) Specification() (InterfaceSpecification, bool) {
+	return lookupInterfaceSpecification(hostInterfaces, byte(h))
+}
+
+// IsEndOfList returns true if the ID terminates a CMIS application list
+func (h HostInterfaceID) IsEndOfList() bool {
+	return byte(h) == interfaceIDEndOfList
+}
+
+func (h HostInterfaceID) String() string {
+	spec, _ := h.Specification()
+	return spec.Name
+}
+
+// MarshalJSON implements the encoding/json/Marshaler interface's MarshalJSON function
+func (h HostInterfaceID) MarshalJSON() ([]byte, error) {
+	spec, _ := h.Specification()
+	return marshalInterfaceID(byte(h), spec)
+}
+
+// MMFMediaInterfaceID multimode fiber media interface code as defined in SFF-8024 table 4-6
+type MMFMediaInterfaceID byte
+
+var mmfMediaInterfaces = map[byte]InterfaceSpecification{
+	0x01: {"10GBASE-SW (Clause 52)", 1, 9.95328, modulationNRZ},
+	0x02: {"10GBASE-SR (Clause 52)", 1, 10.3125, modulationNRZ},
+	0x03: {"25GBASE-SR (Clause 112)", 1, 25.78125, modulationNRZ},
+	0x04: {"40GBASE-SR4 (Clause 86)", 4, 10.3125, modulationNRZ},
+	0x05: {"40GE SWDM4 MSA Spec", 4, 10.3125, modulationNRZ},
+	0x06: {"40GE BiDi", 2, 20.625, modulationNRZ},
+	0x07: {"50GBASE-SR (Clause 138)", 1, 26.5625, modulationPAM4},
+	0x08: {"100GBASE-SR10 (Clause 86)", 10, 10.3125, modulationNRZ},
+	0x09: {"100GBASE-SR4 (Clause 95)", 4, 25.78125, modulationNRZ},
+	0x0A: {"100GE SWDM4 MSA Spec", 4, 25.78125, modulationNRZ},
+	0x0B: {"100GE BiDi", 2, 26.5625, modulationPAM4},
+	0x0C: {"100GBASE-SR2 (Clause 138)", 2, 26.5625, modulationPAM4},
+	0x0D: {"100G-SR (ETC)", 1, 53.125, modulationPAM4},
+	0x0E: {"200GBASE-SR4 (Clause 138)", 4, 26.5625, modulationPAM4},
+	0x0F: {"400GBASE-SR16 (Clause 123)", 16, 26.5625, modulationNRZ},
+	0x10: {"400GBASE-SR8 (Clause 138)", 8, 26.5625, modulationPAM4},
+	0x11: {"400G-SR4 (ETC)", 4, 53.125, modulationPAM4},
+	0x12: {"800G-SR8 (ETC)", 8, 53.125, modulationPAM4},
+}
+
+// Specification returns the interface's properties, false if the ID is unknown
+func (m MMFMediaInterfaceID) Specification() (InterfaceSpecification, bool) {
+	return lookupInterfaceSpecification(mmfMediaInterfaces, byte(m))
+}
+
+func (m MMFMediaInterfaceID) String() string {
+	spec, _ := m.Specification()
+	return spec.Name
+}
+
+// MarshalJSON implements the encoding/json/Marshaler interface's MarshalJSON function
+func (m MMFMediaInterfaceID) MarshalJSON() ([]byte, error) {
+	spec, _ := m.Specification()
+	return marshalInterfaceID(byte(m), spec)
+}
+
+// SMFMediaInterfaceID single mode fiber media interface code as defined in SFF-8024 table 4-7
+type SMFMediaInterfaceID byte
+
+var smfMediaInterfaces = map[byte]InterfaceSpecification{
+	0x01: {"10GBASE-LW (Clause 52)", 1, 9.95328, modulationNRZ},
+	0x02: {"10GBASE-EW (Clause 52)", 1, 9.95328, modulationNRZ},
+	0x03: {"10G-ZW", 1, 9.95328, modulationNRZ},
+	0x04: {"10GBASE-LR (Clause 52)", 1, 10.3125, modulationNRZ},
+	0x05: {"10GBASE-ER (Clause 52)", 1, 10.3125, modulationNRZ},
+	0x06: {"10G-ZR", 1, 10.3125, modulationNRZ},
+	0x07: {"25GBASE-LR (Clause 114)", 1, 25.78125, modulationNRZ},
+	0x08: {"25GBASE-ER (Clause 114)", 1, 25.78125, modulationNRZ},
+	0x09: {"40GBASE-LR4 (Clause 87)", 4, 10.3125, modulationNRZ},
+	0x0A: {"40GBASE-FR (Clause 89)", 1, 41.25, modulationNRZ},
+	0x0B: {"50GBASE-FR (Clause 139)", 1, 26.5625, modulationPAM4},
+	0x0C: {"50GBASE-LR (Clause 139)", 1, 26.5625, modulationPAM4},
+	0x0D: {"100GBASE-LR4 (Clause 88)", 4, 25.78125, modulationNRZ},
+	0x0E: {"100GBASE-ER4 (Clause 88)", 4, 25.78125, modulationNRZ},
+	0x0F: {"100G PSM4 MSA Spec", 4, 25.78125, modulationNRZ},
+	0x10: {"100G CWDM4 MSA Spec", 4, 25.78125, modulationNRZ},
+	0x11: {"100G 4WDM-10 MSA Spec", 4, 25.78125, modulationNRZ},
+	0x12: {"100G 4WDM-20 MSA Spec", 4, 25.78125, modulationNRZ},
+	0x13: {"100G 4WDM-40 MSA Spec", 4, 25.78125, modulationNRZ},
+	0x14: {"100GBASE-DR (Clause 140)", 1, 53.125, modulationPAM4},
+	0x15: {"100G-FR/100GBASE-FR1 (Clause 140)", 1, 53.125, modulationPAM4},
+	0x16: {"100G-LR/100GBASE-LR1 (Clause 140)", 1, 53.125, modulationPAM4},
+	0x17: {"200GBASE-DR4 (Clause 121)", 4, 26.5625, modulationPAM4},
+	0x18: {"200GBASE-FR4 (Clause 122)", 4, 26.5625, modulationPAM4},
+	0x19: {"200GBASE-LR4 (Clause 122)", 4, 26.5625, modulationPAM4},
+	0x1A: {"400GBASE-FR8 (Clause 122)", 8, 26.5625, modulationPAM4},
+	0x1B: {"400GBASE-LR8 (Clause 122)", 8, 26.5625, modulationPAM4},
+	0x1C: {"400GBASE-DR4 (Clause 124)", 4, 53.125, modulationPAM4},
+	0x1D: {"400G-FR4/400GBASE-FR4 (Clause 151)", 4, 53.125, modulationPAM4},
+	0x1E: {"400G-LR4-10", 4, 53.125, modulationPAM4},
+	0x1F: {"8GFC-SM (FC-PI-4)", 1, 8.5, modulationNRZ},
+	0x20: {"10GFC-SM (10GFC)", 1, 10.51875, modulationNRZ},
+	0x21: {"16GFC-SM (FC-PI-5)", 1, 14.025, modulationNRZ},
+	0x22: {"32GFC-SM (FC-PI-6)", 1, 28.05, modulationNRZ},
+	0x23: {"64GFC-SM (FC-PI-7)", 1, 28.9, modulationPAM4},
+	0x24: {"128GFC-PSM4 (FC-PI-6P)", 4, 28.05, modulationNRZ},
+	0x25: {"256GFC-PSM4 (FC-PI-7P)", 4, 28.9, modulationPAM4},
+	0x26: {"128GFC-CWDM4 (FC-PI-6P)", 4, 28.05, modulationNRZ},
+	0x27: {"256GFC-CWDM4 (FC-PI-7P)", 4, 28.9, modulationPAM4},
+	0x3E: {"400ZR, DWDM, amplified", 1, 59.84375, modulationDP16QAM},
+	0x3F: {"400ZR, Single Wavelength, Unamplified", 1, 59.84375, modulationDP16QAM},
+}
+
+// Specification returns the interface's properties, false if the ID is unknown
+func (s SMFMediaInterfaceID) Specification() (InterfaceSpecification, bool) {
+	return lookupInterfaceSpecification(smfMediaInterfaces, byte(s))
+}
+
+func (s SMFMediaInterfaceID) String() string {
+	spec, _ := s.Specification()
+	return spec.Name
+}
+
+// MarshalJSON implements the encoding/json/Marshaler interface's MarshalJSON function
+func (s SMFMediaInterfaceID) MarshalJSON() ([]byte, error) {
+	spec, _ := s.Specification()
+	return marshalInterfaceID(byte(s), spec)
+}
+
+// PassiveCopperMediaInterfaceID passive copper cable media interface code as defined in SFF-8024 table 4-8
+type PassiveCopperMediaInterfaceID byte
+
+var passiveCopperMediaInterfaces = map[byte]InterfaceSpecification{
+	0x01: {Name: "Copper cable"},
+	0xBF: {Name: "Passive Loopback module"},
+}
+
+// Specification returns the interface's properties, false if the ID is unknown
+func (p PassiveCopperMediaInterfaceID) Specification() (InterfaceSpecification, bool) {
+	return lookupInterfaceSpecification(passiveCopperMediaInterfaces, byte(p))
+}
+
+func (p PassiveCopperMediaInterfaceID) String() string {
+	spec, _ := p.Specification()
+	return spec.Name
+}
+
+// MarshalJSON implements the encoding/json/Marshaler interface's MarshalJSON function
+func (p PassiveCopperMediaInterfaceID) MarshalJSON() ([]byte, error) {
+	spec, _ := p.Specification()
+	return marshalInterfaceID(byte(p), spec)
+}
+
+// ActiveCableMediaInterfaceID active cable assembly media interface code as defined in SFF-8024 table 4-9
+type ActiveCableMediaInterfaceID byte
+
+var activeCableMediaInterfaces = map[byte]InterfaceSpecification{
+	0x01: {Name: "Active Cable assembly with BER < 1e-12"},
+	0x02: {Name: "Active Cable assembly with BER < 5e-5"},
+	0x03: {Name: "Active Cable assembly with BER < 2.6e-4"},
+	0x04: {Name: "Active Cable assembly with BER < 1e-6"},
+	0xBF: {Name: "Active Loopback module"},
+}
+
+// Specification returns the interface's properties, false if the ID is unknown
+func (a ActiveCableMediaInterfaceID) Specification() (InterfaceSpecification, bool) {
+	return lookupInterfaceSpecification(activeCableMediaInterfaces, byte(a))
+}
+
+func (a ActiveCableMediaInterfaceID) String() string {
+	spec, _ := a.Specification()
+	return spec.Name
+}
+
+// MarshalJSON implements the encoding/json/Marshaler interface's MarshalJSON function
+func (a ActiveCableMediaInterfaceID) MarshalJSON() ([]byte, error) {
+	spec, _ := a.Specification()
+	return marshalInterfaceID(byte(a), spec)
+}
+
+// BaseTMediaInterfaceID BASE-T media interface code as defined in SFF-8024 table 4-10
+type BaseTMediaInterfaceID byte
+
+var baseTMediaInterfaces = map[byte]InterfaceSpecification{
+	0x01: {"1000BASE-T (Clause 40)", 4, 0.125, "PAM5"},
+	0x02: {"2.5GBASE-T (Clause 126)", 4, 0.2, "PAM16"},
+	0x03: {"5GBASE-T (Clause 126)", 4, 0.4, "PAM16"},
+	0x04: {"10GBASE-T (Clause 55)", 4, 0.8, "PAM16"},
+}
+
+// Specification returns the interface's properties, false if the ID is unknown
+func (b BaseTMediaInterfaceID) Specification() (InterfaceSpecification, bool) {
+	return lookupInterfaceSpecification(baseTMediaInterfaces, byte(b))
+}
+
+func (b BaseTMediaInterfaceID) String() string {
+	spec, _ := b.Specification()
+	return spec.Name
+}
+
+// MarshalJSON implements the encoding/json/Marshaler interface's MarshalJSON function
+func (b BaseTMediaInterfaceID) MarshalJSON() ([]byte, error) {
+	spec, _ := b.Specification()
+	return marshalInterfaceID(byte(b), spec)
+}
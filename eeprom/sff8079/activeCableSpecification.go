@@ -62,3 +62,19 @@ func NewActiveCableSpecifications(raw [2]byte) ActiveCableSpecifications {
 	a[ActiveCableSpecificationSFF8431AppendixE] = raw[0]&(1<<0) > 0
 	return a
 }
+
+// Encode encodes the active cable specifications into their [2]byte representation as parsed by NewActiveCableSpecifications
+func (a ActiveCableSpecifications) Encode() [2]byte {
+	raw := [2]byte{}
+	for bitOffset, activeCableSpecification := range []ActiveCableSpecification{
+		ActiveCableSpecificationSFF8431AppendixE,
+		ActiveCableSpecificationFCPI4AppendixH,
+		ActiveCableSpecificationSFF8431Limiting,
+		ActiveCableSpecificationFCPI4Limiting,
+	} {
+		if a[activeCableSpecification] {
+			raw[0] |= 1 << bitOffset
+		}
+	}
+	return raw
+}
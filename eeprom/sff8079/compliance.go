@@ -355,3 +355,16 @@ func NewCompliance(raw [8]byte) Compliance {
 func (c Compliance) IsSFPCableImplementation() bool {
 	return c[ComplianceFlagActiveCable] || c[ComplianceFlagPassiveCable]
 }
+
+// Encode encodes the compliance into its [8]byte representation as parsed by NewCompliance
+func (c Compliance) Encode() [8]byte {
+	raw := [8]byte{}
+	for byteOffset, bitMap := range complianceMemoryMap {
+		for bitOffset, complianceFlag := range bitMap {
+			if c[complianceFlag] {
+				raw[byteOffset] |= 1 << bitOffset
+			}
+		}
+	}
+	return raw
+}
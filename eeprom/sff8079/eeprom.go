@@ -0,0 +1,189 @@
+package sff8079
+
+import (
+	"errors"
+	"github.com/wobcom/go-ethtool/eeprom"
+	"github.com/wobcom/go-ethtool/eeprom/sff8024"
+	"strings"
+)
+
+/* Memory offsets */
+const (
+	/* Base ID Fields */
+	identifierOffset            = 0x00 /* Type of transceiver */
+	extendedIdentifierOffset    = 0x01 /* Extended identifier of type of transceiver */
+	connectorOffset             = 0x02 /* Code for connector type */
+	transceiverComplianceOffset = 0x03 /* Code for electronic compatibility or optical compatibility */
+	encodingOffset              = 0x0B /* Code for serial encoding algorithm */
+	baurateNominalOffset        = 0x0C /* Nominal bit rate, units of 100 MBits/sec */
+	rateIdentifierOffset        = 0x0D /* Type of rate select functionality */
+	lengthSMFkmOffset           = 0x0E /* Link length supported for 9/125 um fiber, units of km */
+	lengthSMF100mOffset         = 0x0F /* Link length supported for 9/125 um fiber, units of 100 m */
+	lengthOM2Offset             = 0x10 /* Link length supported for 50/125 um fiber, units of 10 m */
+	lengthOM1Offset             = 0x11 /* Link length supported for 62.5/125 um fiber, units of 10 m */
+	lengthCopperOffset          = 0x12 /* Link length supported for copper, units of meters */
+	vendorStartOffset           = 0x14 /* SFP vendor name (ASCII) */
+	vendorEndOffset             = 0x23
+	vendorOuiOffset             = 0x25 /* SFP vendor IEEE company ID */
+	vendorPnStartOffset         = 0x28 /* Part number provided by SFP vendor (ASCII) */
+	vendorPnEndOffset           = 0x37
+	vendorRevStartOffset        = 0x38 /* Revision level for part number provided by vendor (ASCII) */
+	vendorRevEndOffset          = 0x3B
+	wavelengthOffset            = 0x3C /* Laser wavelength (Passive/Active Cable Specification Compliance) */
+	baseChecksumOffset          = 0x3F /* Byte 63 contains the low order 8 bits of the sum of bytes 0-62 */
+	/* Extended ID Fields */
+	optionsOffset            = 0x40 /* Indicates which optional SFP signals are implemented */
+	upperBitrateMarginOffset = 0x42 /* Upper bit rate margin, units of % */
+	lowerBitrateMarginOffset = 0x43 /* Lower bit rate margin, units of % */
+	vendorSnStartOffset      = 0x44 /* Serial number provided by vendor (ASCII) */
+	vendorSnEndOffset        = 0x53
+	dateCodeStartOffset      = 0x54 /* Vendor's manufacturing date code */
+	dateCodeEndOffset        = 0x5B
+	checksumOffset           = 0x5F /* Byte 95 contains the low order 8 bits of the sum of bytes 64-94 */
+	/* Vendor Specific ID Fields */
+	vendorSpecificStartOffset = 0x60
+	vendorSpecificEndOffset   = 0x7F
+)
+
+// EEPROM implementation is based on SFF-8079 Rev. 1.7, covering modules without digital diagnostics
+type EEPROM struct {
+	Raw []byte
+	/* Base ID Fields */
+	Identifier                sff8024.Identifier
+	ExtendedIdentifier        ExtendedIdentifier
+	ConnectorType             sff8024.ConnectorType
+	TransceiverCompliance     Compliance
+	Encoding                  Encoding
+	SignalingRate             float64
+	RateIdentifier            RateIdentifier
+	LengthSMFKm               float64
+	LengthSMF                 float64
+	LengthOM2                 float64
+	LengthOM1                 float64
+	LengthCopper              float64
+	VendorName                string
+	VendorOUI                 eeprom.OUI
+	VendorPN                  string
+	VendorRev                 string
+	Wavelength                float64
+	PassiveCableSpecification PassiveCableSpecifications /* used if 0x08 Bit 2 set */
+	ActiveCableSpecification  ActiveCableSpecifications  /* used if 0x08 Bit 3 set */
+	/* Extended ID Fields */
+	Options            *Options
+	UpperBitrateMargin uint8
+	LowerBitrateMargin uint8
+	VendorSN           string
+	DateCode           string
+	/* Vendor Specific ID Fields */
+	VendorSpecific []byte
+	// Checksum errors found while parsing leniently
+	ChecksumErrors []*eeprom.ChecksumError
+	// Anomalies found while parsing, the affected fields have been decoded on a best-effort basis
+	ParseWarnings []eeprom.ParseWarning
+	// Names of the quirks applied after parsing, see eeprom.ApplyQuirks
+	AppliedQuirks []string
+}
+
+// stringFields ASCII fields checked for anomalies while parsing
+var stringFields = []eeprom.StringField{
+	{Name: "VendorName", Start: vendorStartOffset, End: vendorEndOffset},
+	{Name: "VendorPN", Start: vendorPnStartOffset, End: vendorPnEndOffset},
+	{Name: "VendorRev", Start: vendorRevStartOffset, End: vendorRevEndOffset},
+	{Name: "VendorSN", Start: vendorSnStartOffset, End: vendorSnEndOffset},
+}
+
+func init() {
+	eeprom.RegisterDecoder(eeprom.TypeSFF8079, decode)
+}
+
+// decode parses raw into an eeprom.EEPROM, registered with eeprom.RegisterDecoder
+func decode(raw []byte, options *eeprom.ParseOptions) (eeprom.EEPROM, error) {
+	e, err := NewEEPROMWithOptions(raw, options)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// NewEEPROM parses a byte slice of at least 128 size into a new sff8079.EERPOM instance,
+// checksum errors are recorded in ChecksumErrors
+func NewEEPROM(raw []byte) (*EEPROM, error) {
+	return NewEEPROMWithOptions(raw, nil)
+}
+
+// NewEEPROMWithOptions parses a byte slice of at least 128 size into a new sff8079.EERPOM instance,
+// failing on checksum errors if options are strict
+func NewEEPROMWithOptions(raw []byte, options *eeprom.ParseOptions) (*EEPROM, error) {
+	if len(raw) < 128 {
+		return nil, errors.New("Required at least 128 bytes to comply with SFF8079")
+	}
+
+	e := &EEPROM{
+		Raw: raw,
+		/* Base ID Fields */
+		Identifier:         sff8024.Identifier(raw[identifierOffset]),
+		ExtendedIdentifier: ExtendedIdentifier(raw[extendedIdentifierOffset]),
+		ConnectorType:      sff8024.ConnectorType(raw[connectorOffset]),
+		TransceiverCompliance: NewCompliance([8]byte{
+			raw[transceiverComplianceOffset+0],
+			raw[transceiverComplianceOffset+1],
+			raw[transceiverComplianceOffset+2],
+			raw[transceiverComplianceOffset+3],
+			raw[transceiverComplianceOffset+4],
+			raw[transceiverComplianceOffset+5],
+			raw[transceiverComplianceOffset+6],
+			raw[transceiverComplianceOffset+7],
+		}),
+		Encoding:       Encoding(raw[encodingOffset]),
+		SignalingRate:  float64(raw[baurateNominalOffset]) * 100 * 1000000,
+		RateIdentifier: RateIdentifier(raw[rateIdentifierOffset]),
+		LengthSMFKm:    float64(raw[lengthSMFkmOffset]),
+		LengthSMF:      float64(raw[lengthSMF100mOffset]) * 100,
+		LengthOM2:      float64(raw[lengthOM2Offset]) * 10,
+		LengthOM1:      float64(raw[lengthOM1Offset]) * 10,
+		LengthCopper:   float64(raw[lengthCopperOffset]),
+		VendorName:     strings.Trim(parseString(raw[vendorStartOffset:vendorEndOffset+1]), " "),
+		VendorOUI: eeprom.NewOUI([3]byte{
+			raw[vendorOuiOffset+0],
+			raw[vendorOuiOffset+1],
+			raw[vendorOuiOffset+2],
+		}),
+		VendorPN:  strings.Trim(parseString(raw[vendorPnStartOffset:vendorPnEndOffset+1]), " "),
+		VendorRev: strings.Trim(parseString(raw[vendorRevStartOffset:vendorRevEndOffset+1]), " "),
+		Wavelength: parseWavelength(
+			raw[wavelengthOffset+0],
+			raw[wavelengthOffset+1],
+		),
+		PassiveCableSpecification: NewPassiveCableSpecifications([2]byte{
+			raw[wavelengthOffset+0],
+			raw[wavelengthOffset+1],
+		}),
+		ActiveCableSpecification: NewActiveCableSpecifications([2]byte{
+			raw[wavelengthOffset+0],
+			raw[wavelengthOffset+1],
+		}),
+		Options: NewOptions([2]byte{
+			raw[optionsOffset+0],
+			raw[optionsOffset+1],
+		}),
+		UpperBitrateMargin: uint8(raw[upperBitrateMarginOffset]),
+		LowerBitrateMargin: uint8(raw[lowerBitrateMarginOffset]),
+		VendorSN:           strings.Trim(parseString(raw[vendorSnStartOffset:vendorSnEndOffset+1]), " "),
+		DateCode:           strings.Trim(parseString(raw[dateCodeStartOffset:dateCodeEndOffset+1]), " "),
+		VendorSpecific:     raw[vendorSpecificStartOffset : vendorSpecificEndOffset+1],
+	}
+
+	e.ParseWarnings = eeprom.CheckStringFields(raw, stringFields)
+	if warning := eeprom.CheckDateCode("DateCode", dateCodeStartOffset, raw[dateCodeStartOffset:dateCodeEndOffset+1]); warning != nil {
+		e.ParseWarnings = append(e.ParseWarnings, *warning)
+	}
+
+	if err := eeprom.CheckChecksum(&e.ChecksumErrors, "CC_BASE (bytes 0-62)", raw[identifierOffset:baseChecksumOffset], raw[baseChecksumOffset], options); err != nil {
+		return nil, err
+	}
+	if err := eeprom.CheckChecksum(&e.ChecksumErrors, "CC_EXT (bytes 64-94)", raw[optionsOffset:checksumOffset], raw[checksumOffset], options); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
@@ -0,0 +1,117 @@
+package sff8079
+
+import (
+	"errors"
+	"github.com/wobcom/go-ethtool/eeprom"
+	"github.com/wobcom/go-ethtool/eeprom/sff8024"
+	"time"
+)
+
+// GetIdentifier implements eeprom.EEPROM interface's GetIdentifier function
+func (e *EEPROM) GetIdentifier() sff8024.Identifier {
+	return e.Identifier
+}
+
+// GetConnectorType implements eeprom.EEPROM interface's GetConnectorType function
+func (e *EEPROM) GetConnectorType() sff8024.ConnectorType {
+	return e.ConnectorType
+}
+
+// GetEncoding implements eeprom.EEPROM interface's GetEncoding function
+func (e *EEPROM) GetEncoding() string {
+	return e.Encoding.String()
+}
+
+// GetPowerClass implements eeprom.EEPROM interface's GetPowerClass function
+func (e *EEPROM) GetPowerClass() eeprom.PowerClass {
+	return e.Options.GetPowerClass()
+}
+
+// GetSignalingRate implements eeprom.EEPROM interface's GetSignalingRate function
+func (e *EEPROM) GetSignalingRate() float64 {
+	return e.SignalingRate
+}
+
+// GetSupportedLinkLengths implements eeprom.EEPROM interface's GetSupportedLinkLengths function
+func (e *EEPROM) GetSupportedLinkLengths() map[string]float64 {
+	if e.TransceiverCompliance.IsSFPCableImplementation() {
+		return map[string]float64{
+			"copperOrDAC": e.LengthCopper,
+		}
+	}
+	return map[string]float64{
+		"SMF(km)": e.LengthSMFKm,
+		"SMF(m)":  e.LengthSMF,
+		"OM1":     e.LengthOM1,
+		"OM2":     e.LengthOM2,
+	}
+}
+
+// GetVendorName implements eeprom.EEPROM interface's GetVendorName function
+func (e *EEPROM) GetVendorName() string {
+	return e.VendorName
+}
+
+// GetVendorPN implements eeprom.EEPROM interface's GetVendorPN function
+func (e *EEPROM) GetVendorPN() string {
+	return e.VendorPN
+}
+
+// GetVendorRev implements eeprom.EEPROM interface's GetVendorRev function
+func (e *EEPROM) GetVendorRev() string {
+	return e.VendorRev
+}
+
+// GetVendorSN implements eeprom.EEPROM interface's GetVendorSN function
+func (e *EEPROM) GetVendorSN() string {
+	return e.VendorSN
+}
+
+// GetVendorOUI implements eeprom.EEPROM interface's GetVendorOUI function
+func (e *EEPROM) GetVendorOUI() eeprom.OUI {
+	return e.VendorOUI
+}
+
+// GetDateCode implements eeprom.EEPROM interface's GetDateCode function
+func (e *EEPROM) GetDateCode() time.Time {
+	t, _ := eeprom.ParseDateCode(e.DateCode)
+	return t
+}
+
+// GetParseWarnings implements eeprom.EEPROM interface's GetParseWarnings function
+func (e *EEPROM) GetParseWarnings() []eeprom.ParseWarning {
+	return e.ParseWarnings
+}
+
+// GetChecksumErrors implements eeprom.EEPROM interface's GetChecksumErrors function
+func (e *EEPROM) GetChecksumErrors() []*eeprom.ChecksumError {
+	return e.ChecksumErrors
+}
+
+// GetWavelength implements eeprom.EEPROM interface's GetWavelength function
+func (e *EEPROM) GetWavelength() float64 {
+	return e.Wavelength
+}
+
+// GetLasers implements eeprom.EEPROM interface's GetLasers function
+func (e *EEPROM) GetLasers() []eeprom.Laser {
+	if e.TransceiverCompliance.IsSFPCableImplementation() {
+		return []eeprom.Laser{}
+	}
+	return []eeprom.Laser{&Laser{}}
+}
+
+// SupportsMonitoring implements eeprom.EEPROM interface's SupportsMonitoring function, SFF-8079 does not define digital diagnostics
+func (e *EEPROM) SupportsMonitoring() bool {
+	return false
+}
+
+// GetModuleTemperature implements eeprom.EEPROM interface's GetModuleTemperature function
+func (e *EEPROM) GetModuleTemperature() (eeprom.Measurement, error) {
+	return nil, errors.New("Monitoring not implemented by module")
+}
+
+// GetModuleVoltage implements eeprom.EEPROM interface's GetModuleVoltage function
+func (e *EEPROM) GetModuleVoltage() (eeprom.Measurement, error) {
+	return nil, errors.New("Monitoring not implemented by module")
+}
@@ -0,0 +1,68 @@
+package sff8079
+
+import (
+	"encoding/hex"
+	"github.com/wobcom/go-ethtool/eeprom"
+	"testing"
+	"time"
+)
+
+func getEEPROMfromHex(t *testing.T, hexRaw string) *EEPROM {
+	rawData, err := hex.DecodeString(hexRaw)
+	if err != nil {
+		t.Errorf("Decode hex failed: %v", err)
+	}
+
+	eeprom, err := NewEEPROM(rawData)
+	if err != nil {
+		t.Errorf("EEPROM decode failed: %v", err)
+	}
+
+	return eeprom
+}
+
+func getEEPROM(t *testing.T) *EEPROM {
+	hexRaw := "0304000000000800000000010d0000000000640042524f434144452020202020202020200000051e35372d313030303034322d30322020202020202000000013001200004637385456554d2020202020202020203138313132312020000000a10000000000000000000000000000000000000000000000000000000000000000ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff4637385456554d202020202020202020a7c90f6053fefc039588e656bbe938ab"
+	return getEEPROMfromHex(t, hexRaw)
+}
+
+func assertString(t *testing.T, got string, expected string, function string) {
+	if got != expected {
+		t.Errorf("%s returned %s, expected %s", function, got, expected)
+	}
+}
+
+func TestParseEEPROM(t *testing.T) {
+	e := getEEPROM(t)
+	assertString(t, e.GetVendorName(), "BROCADE", "e.GetVendorName()")
+	assertString(t, e.GetVendorPN(), "57-1000042-02", "e.GetVendorPN()")
+	assertString(t, e.GetVendorSN(), "F78TVUM", "e.GetVendorSN()")
+	assertString(t, e.GetVendorOUI().String(), "00:05:1E", "e.GetVendorOUI()")
+	assertString(t, e.GetEncoding(), "8B/10B", "e.GetEncoding()")
+	if e.GetSignalingRate() != 1.3e9 {
+		t.Errorf("e.GetSignalingRate() returned %f, expected 1.3e9", e.GetSignalingRate())
+	}
+	if !e.GetDateCode().Equal(time.Date(2018, 11, 21, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("e.GetDateCode() returned %v", e.GetDateCode())
+	}
+	if len(e.ChecksumErrors) != 0 {
+		t.Errorf("Unexpected checksum errors %v", e.ChecksumErrors)
+	}
+	if e.SupportsMonitoring() {
+		t.Error("e.SupportsMonitoring() returned true for a module without diagnostics")
+	}
+	lasers := e.GetLasers()
+	if len(lasers) != 1 || lasers[0].SupportsMonitoring() {
+		t.Errorf("Expected a single laser without monitoring, got %v", lasers)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	decoded, err := eeprom.Decode(getEEPROM(t).Raw, eeprom.TypeSFF8079)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := decoded.(*EEPROM); !ok {
+		t.Errorf("Expected SFF-8079 EEPROM, got %T", decoded)
+	}
+}
@@ -0,0 +1,39 @@
+package sff8079
+
+import (
+	"errors"
+	"github.com/wobcom/go-ethtool/eeprom"
+)
+
+// Laser a helper struct for implementing eeprom.Laser interface, modules complying with SFF-8079 do not implement monitoring
+type Laser struct{}
+
+// SupportsMonitoring implements eeprom.Laser interface's SupportsMonitoring function
+func (l *Laser) SupportsMonitoring() bool {
+	return false
+}
+
+// GetBias implements eeprom.Laser interface's GetBias function
+func (l *Laser) GetBias() (eeprom.Measurement, error) {
+	return nil, errors.New("This module does not implement monitoring")
+}
+
+// GetTxPower implements eeprom.Laser interface's GetTxPower function
+func (l *Laser) GetTxPower() (eeprom.Measurement, error) {
+	return nil, errors.New("This module does not implement monitoring")
+}
+
+// GetRxPower implements eeprom.Laser interface's GetRxPower function
+func (l *Laser) GetRxPower() (eeprom.Measurement, error) {
+	return nil, errors.New("This module does not implement monitoring")
+}
+
+// SupportsLaneFlags implements eeprom.Laser interface's SupportsLaneFlags function
+func (l *Laser) SupportsLaneFlags() bool {
+	return false
+}
+
+// GetLaneFlags implements eeprom.Laser interface's GetLaneFlags function
+func (l *Laser) GetLaneFlags() (eeprom.LaneFlags, error) {
+	return nil, errors.New("No lane flags implemented by this module")
+}
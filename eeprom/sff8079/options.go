@@ -64,3 +64,19 @@ func NewOptions(raw [2]byte) *Options {
 	}
 	return o
 }
+
+// Encode encodes the options into their [2]byte representation as parsed by NewOptions
+func (o *Options) Encode() [2]byte {
+	raw := [2]byte{}
+	for byteOffset, bitMap := range optionsMemoryMap {
+		for bitOffset, callback := range bitMap {
+			// clearing the field only changes the options if it has been set
+			probe := *o
+			callback(&probe, false)
+			if probe != *o {
+				raw[byteOffset] |= 1 << bitOffset
+			}
+		}
+	}
+	return raw
+}
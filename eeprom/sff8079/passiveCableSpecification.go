@@ -54,3 +54,15 @@ func NewPassiveCableSpecifications(raw [2]byte) PassiveCableSpecifications {
 	p[PassiveCableSpecificationSFF8431AppendixE] = raw[0]&(1<<0) > 0
 	return p
 }
+
+// Encode encodes the passive cable specifications into their [2]byte representation as parsed by NewPassiveCableSpecifications
+func (p PassiveCableSpecifications) Encode() [2]byte {
+	raw := [2]byte{}
+	if p[PassiveCableSpecificationFCPI4AppendixH] {
+		raw[0] |= 1 << 1
+	}
+	if p[PassiveCableSpecificationSFF8431AppendixE] {
+		raw[0] |= 1 << 0
+	}
+	return raw
+}
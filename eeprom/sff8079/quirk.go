@@ -0,0 +1,15 @@
+package sff8079
+
+import (
+	"github.com/wobcom/go-ethtool/eeprom"
+)
+
+// ApplyQuirk implements eeprom.QuirkApplier interface's ApplyQuirk function, SFF-8079 does not define diagnostics to correct
+func (e *EEPROM) ApplyQuirk(quirk *eeprom.Quirk) {
+	e.AppliedQuirks = append(e.AppliedQuirks, quirk.Name)
+}
+
+// GetAppliedQuirks implements eeprom.QuirkApplier interface's GetAppliedQuirks function
+func (e *EEPROM) GetAppliedQuirks() []string {
+	return e.AppliedQuirks
+}
@@ -0,0 +1,14 @@
+package sff8079
+
+import (
+	"bytes"
+	"github.com/wobcom/go-ethtool/util"
+)
+
+func parseString(raw []byte) string {
+	return util.GetValidUtf8String(bytes.Trim(raw, "\x00"))
+}
+
+func parseWavelength(msb byte, lsb byte) float64 {
+	return float64(uint16(msb)<<8 | uint16(lsb))
+}
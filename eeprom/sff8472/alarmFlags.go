@@ -1,5 +1,7 @@
 package sff8472
 
+import "github.com/wobcom/go-ethtool/eeprom"
+
 // AlarmFlags as defined in SFF-8472
 type AlarmFlags struct {
 	Temperature      AlarmFlagStatus
@@ -48,3 +50,17 @@ func NewAlarmFlags(raw [2]byte) *AlarmFlags {
 	}
 	return a
 }
+
+// Encode encodes the alarm flags into their [2]byte representation as parsed by NewAlarmFlags
+func (a *AlarmFlags) Encode() [2]byte {
+	raw := [2]byte{}
+	for byteOffset, bitMap := range alarmFlagsMemoryMap {
+		for bitOffset, callback := range bitMap {
+			// clearing the flag only changes the alarm flags if it has been set
+			probe := *a
+			callback(&probe, false)
+			eeprom.SetBits(&raw[byteOffset], 1<<bitOffset, probe != *a)
+		}
+	}
+	return raw
+}
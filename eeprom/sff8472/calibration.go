@@ -18,8 +18,8 @@ func (d *Diagnostics) calibrate(e *ExternalCalibrationConstants) *Diagnostics {
 func (t *Thresholds) calibrate(e *ExternalCalibrationConstants) *Thresholds {
 	return &Thresholds{
 		Temperature: t.Temperature.calibrate(e.TemperatureSlope, e.TemperatureOffset),
-		Voltage:     t.Voltage.calibrate(e.TemperatureSlope, e.TemperatureOffset),
-		Bias:        t.Bias.calibrate(e.TemperatureSlope, e.TemperatureOffset),
+		Voltage:     t.Voltage.calibrate(e.VoltageSlope, e.VoltageOffset),
+		Bias:        t.Bias.calibrate(e.BiasSlope, e.BiasOffset),
 		TxPower:     t.TxPower.calibrateTxPower(e.TxPowerSlope, e.TxPowerOffset),
 		RxPower:     t.RxPower.calibrateRxPower(e.RxPwr),
 	}
@@ -68,3 +68,74 @@ func calibrateRxPower(uncalibratedValue Power, rxPwr [5]float64) Power {
 	rxPwrAD := float64(uncalibratedValue)
 	return Power(rxPwr[4]*rxPwrAD + rxPwr[3]*rxPwrAD + rxPwr[2]*rxPwrAD + rxPwr[1]*rxPwrAD + rxPwr[0])
 }
+
+// uncalibrate reverts calibrate, used for encoding the raw values of externally calibrated modules
+func (d *Diagnostics) uncalibrate(e *ExternalCalibrationConstants) *Diagnostics {
+	return &Diagnostics{
+		Temperature: uncalibrateValue(d.Temperature, e.TemperatureSlope, e.TemperatureOffset),
+		Voltage:     uncalibrateValue(d.Voltage, e.VoltageSlope, e.VoltageOffset),
+		Bias:        uncalibrateValue(d.Bias, e.BiasSlope, e.BiasOffset),
+		TxPower:     Power(uncalibrateValue(float64(d.TxPower), e.TxPowerSlope, e.TxPowerOffset)),
+		RxPower:     uncalibrateRxPower(d.RxPower, e.RxPwr),
+	}
+}
+
+// uncalibrate reverts calibrate, used for encoding the raw values of externally calibrated modules
+func (t *Thresholds) uncalibrate(e *ExternalCalibrationConstants) *Thresholds {
+	return &Thresholds{
+		Temperature: t.Temperature.uncalibrate(e.TemperatureSlope, e.TemperatureOffset),
+		Voltage:     t.Voltage.uncalibrate(e.VoltageSlope, e.VoltageOffset),
+		Bias:        t.Bias.uncalibrate(e.BiasSlope, e.BiasOffset),
+		TxPower:     t.TxPower.uncalibrateTxPower(e.TxPowerSlope, e.TxPowerOffset),
+		RxPower:     t.RxPower.uncalibrateRxPower(e.RxPwr),
+	}
+}
+
+func (a *AlarmThresholds) uncalibrate(slope float64, offset float64) *AlarmThresholds {
+	if a == nil {
+		return nil
+	}
+	return &AlarmThresholds{
+		HighAlarm:   uncalibrateValue(a.HighAlarm, slope, offset),
+		HighWarning: uncalibrateValue(a.HighWarning, slope, offset),
+		LowAlarm:    uncalibrateValue(a.LowAlarm, slope, offset),
+		LowWarning:  uncalibrateValue(a.LowWarning, slope, offset),
+	}
+}
+
+func (a *AlarmThresholdsPower) uncalibrateTxPower(slope float64, offset float64) *AlarmThresholdsPower {
+	if a == nil {
+		return nil
+	}
+	return &AlarmThresholdsPower{
+		HighAlarm:   Power(uncalibrateValue(float64(a.HighAlarm), slope, offset)),
+		HighWarning: Power(uncalibrateValue(float64(a.HighWarning), slope, offset)),
+		LowAlarm:    Power(uncalibrateValue(float64(a.LowAlarm), slope, offset)),
+		LowWarning:  Power(uncalibrateValue(float64(a.LowWarning), slope, offset)),
+	}
+}
+
+func (a *AlarmThresholdsPower) uncalibrateRxPower(rxPwr [5]float64) *AlarmThresholdsPower {
+	if a == nil {
+		return nil
+	}
+	return &AlarmThresholdsPower{
+		HighAlarm:   uncalibrateRxPower(a.HighAlarm, rxPwr),
+		HighWarning: uncalibrateRxPower(a.HighWarning, rxPwr),
+		LowAlarm:    uncalibrateRxPower(a.LowAlarm, rxPwr),
+		LowWarning:  uncalibrateRxPower(a.LowWarning, rxPwr),
+	}
+}
+
+// inverse of calibrateValue, a slope of 0 maps every value to 0
+func uncalibrateValue(calibratedValue float64, slope float64, offset float64) float64 {
+	if slope == 0 {
+		return 0
+	}
+	return (calibratedValue - offset) / slope
+}
+
+// inverse of calibrateRxPower
+func uncalibrateRxPower(calibratedValue Power, rxPwr [5]float64) Power {
+	return Power(uncalibrateValue(float64(calibratedValue), rxPwr[4]+rxPwr[3]+rxPwr[2]+rxPwr[1], rxPwr[0]))
+}
@@ -2,6 +2,7 @@ package sff8472
 
 import (
 	"encoding/json"
+	"github.com/wobcom/go-ethtool/eeprom"
 )
 
 // DiagnosticMonitoringType as of SFF-8472
@@ -43,3 +44,13 @@ func NewDiagnosticMonitoringType(raw byte) *DiagnosticMonitoringType {
 		ReceivedPowerMeasurementType:    ReceivedPowerMeasurementType(raw&(1<<3) > 0),
 	}
 }
+
+// Encode encodes the diagnostic monitoring type into its byte as parsed by NewDiagnosticMonitoringType
+func (d *DiagnosticMonitoringType) Encode() byte {
+	raw := byte(0)
+	eeprom.SetBits(&raw, 1<<6, d.DiagnosticMonitoringImplemented)
+	eeprom.SetBits(&raw, 1<<5, d.InternallyCalibrated)
+	eeprom.SetBits(&raw, 1<<4, d.ExternallyCalibrated)
+	eeprom.SetBits(&raw, 1<<3, bool(d.ReceivedPowerMeasurementType))
+	return raw
+}
@@ -25,3 +25,18 @@ func NewDiagnostics(raw [10]byte) *Diagnostics {
 	}
 	return d
 }
+
+// Encode encodes the diagnostics into their [10]byte representation as parsed by NewDiagnostics
+func (d *Diagnostics) Encode() [10]byte {
+	raw := [10]byte{}
+	for byteOffset, value := range map[uint][2]byte{
+		0x00: encodeTemperature(d.Temperature),
+		0x02: encodeVoltage(d.Voltage),
+		0x04: encodeCurrent(d.Bias),
+		0x06: encodePower(d.TxPower),
+		0x08: encodePower(d.RxPower),
+	} {
+		copy(raw[byteOffset:], value[:])
+	}
+	return raw
+}
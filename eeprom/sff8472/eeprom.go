@@ -2,20 +2,15 @@ package sff8472
 
 import (
 	"errors"
-	"fmt"
 	"github.com/wobcom/go-ethtool/eeprom"
 	"github.com/wobcom/go-ethtool/eeprom/sff8024"
 	"github.com/wobcom/go-ethtool/eeprom/sff8079"
+	"math"
 	"strings"
 )
 
 // ChecksumError occurs when the data read from the EEPROM does not a have a valid checksum as defined in SFF8472
-type ChecksumError struct{}
-
-func (c *ChecksumError) Error() string { return "Invalid checksum" }
-
-// NewChecksumError returns a new ChecksumError instance
-func NewChecksumError() *ChecksumError { return &ChecksumError{} }
+type ChecksumError = eeprom.ChecksumError
 
 /* Memory offsets */
 const (
@@ -37,12 +32,13 @@ const (
 	vendorStartOffset            = 0x14 /* SFP vendor name (ASCII) */
 	vendorEndOffset              = 0x23
 	transceiverComplianceOffset1 = 0x24 /* Code for electronic or optical compatibility */
-	vendorOuiOffset              = 0x23
+	vendorOuiOffset              = 0x25 /* SFP vendor IEEE company ID */
 	vendorPnStartOffset          = 0x28 /* Part number provided by SFP vendor (ASCII) */
 	vendorPnEndOfffset           = 0x37
 	vendorRevStartOffset         = 0x38 /* Revision level for part number provided by vendor (ASCII) */
 	vendorRevEndOffset           = 0x3B
 	wavelengthOffset             = 0x3C /* Laser wavelength (Passive/Active Cable Specification Compliance) */
+	baseChecksumOffset           = 0x3F /* Byte 63 contains the low order 8 bits of the sum of bytes 0-62 */
 	/* Extended ID Fields */
 	optionsOffset                  = 0x40 /* Indicates which optional transceiver signals are implemented */
 	uppertBitrateMarginOffset      = 0x42 /* Upper bit rate margin, units of % */
@@ -54,7 +50,7 @@ const (
 	diagnosticMonitoringTypeOffset = 0x5C /* Indicates which type of diagnostic monitoring is implemented (if any) in the transceiver (see Table 8-5) */
 	enhancedOptionsOffset          = 0x5D /*  Indicates which optional enhanced features are implemented (if any) in the transceiver (see Table 8-6) */
 	complianceOffset               = 0x5E /* Indicates which revision of SFF-8472 the transceiver complies with. (see Table 8-8). */
-	checksumOffset                 = 0x5F /* Byte 95 contains the low order 8 bits of the sum of bytes 0-94 */
+	checksumOffset                 = 0x5F /* Byte 95 contains the low order 8 bits of the sum of bytes 64-94 */
 
 	/* Page A2h */
 	/* Diagnostic and control/status fields */
@@ -62,6 +58,7 @@ const (
 	optionalThresholds = 0x128 /* Thresholds for optional Laser Temperature and TEC Current alarms
 	and warnings (see Table 9-5) */
 	externalCalibrationConstantsOffset = 0x138 /* Diagnostic calibration constants for optional External Calibration (see Table 9-6) */
+	dmiChecksumOffset                  = 0x15F /* Byte 95 contains the low order 8 bits of the sum of bytes 0-94 of A2h */
 	diagnosticsOffset                  = 0x160 /* Diagnostic Monitor Data (internally or externally calibrated) (see Table 9-11) */
 	optionalDiagnosticsOffset          = 0x16A /*  Monitor Data for Optional Laser temperature and TEC current (see Table 9-11) */
 	statusControlOffset                = 0x16E /* Optional Status and Control Bits (see Table 9-11) */
@@ -122,10 +119,46 @@ type EEPROM struct {
 	WarningFlags                 *WarningFlags
 	ExtendedStatusControl        *ExtendedStatusControl
 	UserData                     []byte
+	// Checksum errors found while parsing leniently
+	ChecksumErrors []*ChecksumError
+	// Anomalies found while parsing, the affected fields have been decoded on a best-effort basis
+	ParseWarnings []eeprom.ParseWarning
+	// Names of the quirks applied after parsing, see eeprom.ApplyQuirks
+	AppliedQuirks []string
+	// Diagnostics reported as not available due to a quirk
+	IgnoredDiagnostics map[eeprom.Diagnostic]bool
+}
+
+// stringFields ASCII fields checked for anomalies while parsing
+var stringFields = []eeprom.StringField{
+	{Name: "VendorName", Start: vendorStartOffset, End: vendorEndOffset},
+	{Name: "VendorPN", Start: vendorPnStartOffset, End: vendorPnEndOfffset},
+	{Name: "VendorRev", Start: vendorRevStartOffset, End: vendorRevEndOffset},
+	{Name: "VendorSN", Start: vendorSnStartOffset, End: vendorSnEndOffset},
 }
 
-// NewEEPROM parses a byte slice of at least 256 size into a new sff8472.EERPOM instance
+func init() {
+	eeprom.RegisterDecoder(eeprom.TypeSFF8472, decode)
+}
+
+// decode parses raw into an eeprom.EEPROM, registered with eeprom.RegisterDecoder
+func decode(raw []byte, options *eeprom.ParseOptions) (eeprom.EEPROM, error) {
+	e, err := NewEEPROMWithOptions(raw, options)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// NewEEPROM parses a byte slice of at least 256 size into a new sff8472.EERPOM instance,
+// checksum errors are recorded in ChecksumErrors
 func NewEEPROM(raw []byte) (*EEPROM, error) {
+	return NewEEPROMWithOptions(raw, nil)
+}
+
+// NewEEPROMWithOptions parses a byte slice of at least 256 size into a new sff8472.EERPOM instance,
+// failing on checksum errors if options are strict
+func NewEEPROMWithOptions(raw []byte, options *eeprom.ParseOptions) (*EEPROM, error) {
 	if len(raw) < 256 {
 		return nil, errors.New("Required at least 256 bytes to comply with SFF8472")
 	}
@@ -194,14 +227,21 @@ func NewEEPROM(raw []byte) (*EEPROM, error) {
 		Compliance:               Compliance(raw[complianceOffset]),
 	}
 
+	e.ParseWarnings = eeprom.CheckStringFields(raw, stringFields)
+	if warning := eeprom.CheckDateCode("DateCode", dateCodeStartOffset, raw[dateCodeStartOffset:dateCodeEndOffset+1]); warning != nil {
+		e.ParseWarnings = append(e.ParseWarnings, *warning)
+	}
+
+	if err := eeprom.CheckChecksum(&e.ChecksumErrors, "CC_BASE (A0h bytes 0-62)", raw[identifierOffset:baseChecksumOffset], raw[baseChecksumOffset], options); err != nil {
+		return nil, err
+	}
+	if err := eeprom.CheckChecksum(&e.ChecksumErrors, "CC_EXT (A0h bytes 64-94)", raw[optionsOffset:checksumOffset], raw[checksumOffset], options); err != nil {
+		return nil, err
+	}
+
 	if len(raw) >= 512 {
-		sum := uint(0)
-		for i := 0x100; i < 0x15F; i++ {
-			sum += uint(raw[i])
-		}
-		if byte(sum&0xFF) != raw[0x15F] {
-			fmt.Printf("Expected checksum %02X, got %X\n", raw[checksumOffset], sum)
-			return nil, NewChecksumError()
+		if err := eeprom.CheckChecksum(&e.ChecksumErrors, "CC_DMI (A2h bytes 0-94)", raw[thresholdsOffset:dmiChecksumOffset], raw[dmiChecksumOffset], options); err != nil {
+			return nil, err
 		}
 		e.Thresholds = NewThresholds([40]byte{
 			raw[thresholdsOffset+0],
@@ -357,3 +397,146 @@ func NewEEPROM(raw []byte) (*EEPROM, error) {
 
 	return e, nil
 }
+
+// Encode encodes the EEPROM into a byte image as parsed by NewEEPROM, including valid check codes.
+// The image includes page A2h (512 bytes) if Thresholds are set, otherwise only page A0h (256 bytes).
+// Thresholds and diagnostics of externally calibrated modules are converted back into their uncalibrated values.
+func (e *EEPROM) Encode() ([]byte, error) {
+	size := 256
+	if e.Thresholds != nil {
+		size = 512
+	}
+	raw := make([]byte, size)
+
+	/* Page A0h */
+	/* Base ID Fields */
+	raw[identifierOffset] = byte(e.Identifier)
+	raw[extendedIdentifierOffset] = byte(e.ExtendedIdentifier)
+	raw[connectorOffset] = byte(e.ConnectorType)
+	compliance := e.TransceiverCompliance.Encode()
+	copy(raw[transceiverComplianceOffset:], compliance[:])
+	raw[encodingOffset] = byte(e.Encoding)
+	raw[rateIdentifierOffset] = byte(e.RateIdentifier)
+	raw[lengthSMFkmOffset] = encodeLength(e.LengthSMFKm, 1)
+	raw[lengthSMF100mOffset] = encodeLength(e.LengthSMF, 100)
+	raw[lengthOM2Offset] = encodeLength(e.LengthOM2, 10)
+	raw[lengthOM1Offset] = encodeLength(e.LengthOM1, 10)
+	raw[lengthOM4orCopperOffset] = encodeLength(e.LengthOM4OrDAC, 1)
+	raw[lengthOM3Offset] = encodeLength(e.LengthOM3, 10)
+	for _, field := range []struct {
+		name  string
+		raw   []byte
+		value string
+	}{
+		{"VendorName", raw[vendorStartOffset : vendorEndOffset+1], e.VendorName},
+		{"VendorPN", raw[vendorPnStartOffset : vendorPnEndOfffset+1], e.VendorPN},
+		{"VendorRev", raw[vendorRevStartOffset : vendorRevEndOffset+1], e.VendorRev},
+		{"VendorSN", raw[vendorSnStartOffset : vendorSnEndOffset+1], e.VendorSN},
+		{"DateCode", raw[dateCodeStartOffset : dateCodeEndOffset+1], e.DateCode},
+	} {
+		if err := eeprom.PutString(field.name, field.raw, field.value); err != nil {
+			return nil, err
+		}
+	}
+	oui := e.VendorOUI.Encode()
+	copy(raw[vendorOuiOffset:], oui[:])
+	if e.TransceiverCompliance.IsSFPCableImplementation() {
+		raw[lengthOM4orCopperOffset] = encodeLength(e.LengthOM4OrDAC, 0.1)
+		cableSpecification := e.PassiveCableSpecification.Encode()
+		if e.TransceiverCompliance[sff8079.ComplianceFlagActiveCable] {
+			cableSpecification = e.ActiveCableSpecification.Encode()
+		}
+		copy(raw[wavelengthOffset:], cableSpecification[:])
+	} else {
+		wavelength := encodeWavelength(e.Wavelength)
+		copy(raw[wavelengthOffset:], wavelength[:])
+	}
+
+	/* Extended ID Fields */
+	if e.Options != nil {
+		options := e.Options.Encode()
+		copy(raw[optionsOffset:], options[:])
+	}
+	raw[uppertBitrateMarginOffset] = e.UpperBitrateMargin
+	raw[lowerBitrateMarginOffset] = e.LowerBitrateMargin
+	// signaling rates above 25.4 GBd are encoded in the bitrate margin bytes
+	signalingRate := math.Round(e.SignalingRate / (100 * 1000000))
+	if signalingRate > 0xFE {
+		raw[baurateNominalOffset] = 0xFF
+		extendedSignalingRate := encodeUint16(signalingRate)
+		copy(raw[uppertBitrateMarginOffset:], extendedSignalingRate[:])
+	} else {
+		raw[baurateNominalOffset] = byte(math.Max(0, signalingRate))
+	}
+	if e.DiagnosticMonitoringType != nil {
+		raw[diagnosticMonitoringTypeOffset] = e.DiagnosticMonitoringType.Encode()
+	}
+	if e.EnhancedOptions != nil {
+		raw[enhancedOptionsOffset] = e.EnhancedOptions.Encode()
+	}
+	raw[complianceOffset] = byte(e.Compliance)
+	raw[baseChecksumOffset] = eeprom.Checksum(raw[identifierOffset:baseChecksumOffset])
+	raw[checksumOffset] = eeprom.Checksum(raw[optionsOffset:checksumOffset])
+
+	if size < 512 {
+		return raw, nil
+	}
+
+	/* Page A2h */
+	thresholds, diagnostics := e.Thresholds, e.Diagnostics
+	if e.DiagnosticMonitoringType != nil && e.DiagnosticMonitoringType.ExternallyCalibrated && e.ExternalCalibrationConstants != nil {
+		thresholds = thresholds.uncalibrate(e.ExternalCalibrationConstants)
+		if diagnostics != nil {
+			diagnostics = diagnostics.uncalibrate(e.ExternalCalibrationConstants)
+		}
+	}
+	encodedThresholds := thresholds.Encode()
+	copy(raw[thresholdsOffset:], encodedThresholds[:])
+	if e.OptionalThresholds != nil {
+		encodedOptionalThresholds := e.OptionalThresholds.Encode()
+		copy(raw[optionalThresholds:], encodedOptionalThresholds[:])
+	}
+	if e.ExternalCalibrationConstants != nil {
+		calibrationConstants := e.ExternalCalibrationConstants.Encode()
+		copy(raw[externalCalibrationConstantsOffset:], calibrationConstants[:])
+	}
+	raw[dmiChecksumOffset] = eeprom.Checksum(raw[thresholdsOffset:dmiChecksumOffset])
+	if diagnostics != nil {
+		encodedDiagnostics := diagnostics.Encode()
+		copy(raw[diagnosticsOffset:], encodedDiagnostics[:])
+	}
+	if e.OptionalDiagnostics != nil {
+		optionalDiagnostics := e.OptionalDiagnostics.Encode()
+		copy(raw[optionalDiagnosticsOffset:], optionalDiagnostics[:])
+	}
+	if e.StatusControl != nil {
+		raw[statusControlOffset] = e.StatusControl.Encode()
+	}
+	if e.AlarmFlags != nil {
+		alarmFlags := e.AlarmFlags.Encode()
+		copy(raw[alarmFlagsOffset:], alarmFlags[:])
+	}
+	// reserved values are kept as parsed, unlike the validating Encode functions used for writes
+	if e.InputEqualizationControl != nil {
+		raw[InputEqualizationControlOffset] = byte(e.InputEqualizationControl.HighRate)<<4 | byte(e.InputEqualizationControl.LowRate)&0x0F
+	}
+	if e.OutputEmphasisControl != nil {
+		raw[OutputEmphasisControlOffset] = byte(e.OutputEmphasisControl.HighRate)<<4 | byte(e.OutputEmphasisControl.LowRate)&0x0F
+	}
+	if e.WarningFlags != nil {
+		warningFlags := e.WarningFlags.Encode()
+		copy(raw[warningFlagsOffset:], warningFlags[:])
+	}
+	if e.ExtendedStatusControl != nil {
+		extendedStatusControl := e.ExtendedStatusControl.Encode()
+		copy(raw[extendedStatusControlOffset:], extendedStatusControl[:])
+	}
+	copy(raw[userEepromStartOffset:userEepromEndOffset], e.UserData)
+
+	return raw, nil
+}
+
+// encodeLength encodes a link length given in units of unit meters (or kilometers), clamped to a byte
+func encodeLength(length float64, unit float64) byte {
+	return byte(math.Max(0, math.Min(0xFF, math.Round(length/unit))))
+}
@@ -4,7 +4,6 @@ import (
 	"errors"
 	"github.com/wobcom/go-ethtool/eeprom"
 	"github.com/wobcom/go-ethtool/eeprom/sff8024"
-	"strings"
 	"time"
 )
 
@@ -77,11 +76,20 @@ func (e *EEPROM) GetVendorOUI() eeprom.OUI {
 
 // GetDateCode implements eeprom.EEPROM interface's GetDateCode function
 func (e *EEPROM) GetDateCode() time.Time {
-	dateCode := strings.Trim(e.DateCode, " ")
-	t, _ := time.Parse("060102", dateCode)
+	t, _ := eeprom.ParseDateCode(e.DateCode)
 	return t
 }
 
+// GetParseWarnings implements eeprom.EEPROM interface's GetParseWarnings function
+func (e *EEPROM) GetParseWarnings() []eeprom.ParseWarning {
+	return e.ParseWarnings
+}
+
+// GetChecksumErrors implements eeprom.EEPROM interface's GetChecksumErrors function
+func (e *EEPROM) GetChecksumErrors() []*eeprom.ChecksumError {
+	return e.ChecksumErrors
+}
+
 // GetWavelength implements eeprom.EEPROM interface's GetWavelength function
 func (e *EEPROM) GetWavelength() float64 {
 	return e.Wavelength
@@ -94,10 +102,13 @@ func (e *EEPROM) SupportsMonitoring() bool {
 
 // GetModuleTemperature implements eeprom.EEPROM interface's GetModuleTemperature function
 func (e *EEPROM) GetModuleTemperature() (eeprom.Measurement, error) {
+	if e.IgnoredDiagnostics[eeprom.DiagnosticTemperature] {
+		return nil, eeprom.IgnoredDiagnosticError(eeprom.DiagnosticTemperature)
+	}
 	if !e.SupportsMonitoring() {
 		return nil, errors.New("Monitoring not implemented by module")
 	}
-	return &Measurement{
+	m := &Measurement{
 		Value: e.Diagnostics.Temperature,
 		Unit:  "degrees celsius",
 		Thresholds: &MeasurementThresholds{
@@ -106,15 +117,22 @@ func (e *EEPROM) GetModuleTemperature() (eeprom.Measurement, error) {
 			LowAlarm:    e.Thresholds.Temperature.LowAlarm,
 			LowWarning:  e.Thresholds.Temperature.LowWarning,
 		},
-	}, nil
+	}
+	if e.alarmFlagsImplemented() {
+		m.Flags = newMeasurementFlags(e.AlarmFlags.Temperature, e.WarningFlags.Temperature)
+	}
+	return m, nil
 }
 
 // GetModuleVoltage implements eeprom.EEPROM interface's GetModuleVoltage function
 func (e *EEPROM) GetModuleVoltage() (eeprom.Measurement, error) {
+	if e.IgnoredDiagnostics[eeprom.DiagnosticVoltage] {
+		return nil, eeprom.IgnoredDiagnosticError(eeprom.DiagnosticVoltage)
+	}
 	if !e.SupportsMonitoring() {
 		return nil, errors.New("Monitoring not implemented by module")
 	}
-	return &Measurement{
+	m := &Measurement{
 		Value: e.Diagnostics.Voltage,
 		Unit:  "degrees celsius",
 		Thresholds: &MeasurementThresholds{
@@ -123,5 +141,9 @@ func (e *EEPROM) GetModuleVoltage() (eeprom.Measurement, error) {
 			LowAlarm:    e.Thresholds.Voltage.LowAlarm,
 			LowWarning:  e.Thresholds.Voltage.LowWarning,
 		},
-	}, nil
+	}
+	if e.alarmFlagsImplemented() {
+		m.Flags = newMeasurementFlags(e.AlarmFlags.Voltage, e.WarningFlags.Voltage)
+	}
+	return m, nil
 }
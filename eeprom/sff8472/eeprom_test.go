@@ -4,6 +4,10 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"github.com/wobcom/go-ethtool/eeprom"
+	"github.com/wobcom/go-ethtool/eeprom/sff8079"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -70,7 +74,7 @@ func TestParseEEPROM(t *testing.T) {
 	assertString(t, eeprom.GetVendorPN(), "P.B1696.10.DA", "eeprom.GetVendorPN")
 	assertString(t, eeprom.GetVendorRev(), "A", "eeprom.GetVendorRev")
 	assertString(t, eeprom.GetVendorSN(), "F79B5KH", "eeprom.GetVendorSN")
-	assertString(t, eeprom.GetVendorOUI().String(), "20:00:00", "eeprom.GetVendorOUI")
+	assertString(t, eeprom.GetVendorOUI().String(), "00:02:C9", "eeprom.GetVendorOUI")
 	expectedDate, _ := time.Parse("060102", "191218")
 	if dateCode := eeprom.GetDateCode(); dateCode != expectedDate {
 		t.Errorf("eeprom.GetDateCode() returned %s, but expected %s", dateCode.Format("060102"), expectedDate.Format("060102"))
@@ -139,6 +143,27 @@ func TestParseEEPROM1(t *testing.T) {
 	assertFloat64(t, thresholds.GetLowWarning(), 0.0316, "thresholds.GetLowWarning")
 }
 
+func TestVendorOUILookup(t *testing.T) {
+	for _, test := range []struct {
+		e            *EEPROM
+		oui          string
+		organization string
+	}{
+		{getEEPROM(t), "00:02:C9", "Mellanox Technologies, Inc."},
+		{getEEPROM1(t), "00:17:6A", "Avago Technologies"},
+		{getEEPROM2(t), "00:05:1E", "Brocade Communications Systems LLC"},
+	} {
+		assertString(t, test.e.GetVendorOUI().String(), test.oui, "e.GetVendorOUI()")
+		assertString(t, test.e.GetVendorOUI().Lookup(), test.organization, "e.GetVendorOUI().Lookup()")
+	}
+
+	encoded, err := json.Marshal(getEEPROM2(t).VendorOUI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertString(t, string(encoded), `{"ascii":"00:05:1E","hex":"51e","organization":"Brocade Communications Systems LLC"}`, "json.Marshal(e.VendorOUI)")
+}
+
 func TestParseEEPROMwithoutMonitoring(t *testing.T) {
 	eeprom := getEEPROM2(t)
 
@@ -182,6 +207,42 @@ func TestCalibration(t *testing.T) {
 	}
 }
 
+func TestThresholdCalibration(t *testing.T) {
+	thresholds := &Thresholds{
+		Temperature: &AlarmThresholds{HighAlarm: 80},
+		Voltage:     &AlarmThresholds{HighAlarm: 3.5},
+		Bias:        &AlarmThresholds{HighAlarm: 100},
+		TxPower:     &AlarmThresholdsPower{},
+		RxPower:     &AlarmThresholdsPower{},
+	}
+	calibrated := thresholds.calibrate(&ExternalCalibrationConstants{
+		TemperatureSlope: 1,
+		VoltageSlope:     2,
+		VoltageOffset:    -1,
+		BiasSlope:        0.5,
+		BiasOffset:       10,
+	})
+
+	assertFloat64(t, calibrated.Temperature.HighAlarm, 80, "calibrated.Temperature.HighAlarm")
+	assertFloat64(t, calibrated.Voltage.HighAlarm, 6, "calibrated.Voltage.HighAlarm")
+	assertFloat64(t, calibrated.Bias.HighAlarm, 60, "calibrated.Bias.HighAlarm")
+}
+
+func TestParseOptionalThresholds(t *testing.T) {
+	// laser temperature 80, -10, 70, 0 degrees C and TEC current 80, 10, 60, 20 mA
+	raw := [16]byte{0x50, 0x00, 0xF6, 0x00, 0x46, 0x00, 0x00, 0x00, 0x9C, 0x40, 0x13, 0x88, 0x75, 0x30, 0x27, 0x10}
+	thresholds := NewOptionalThresholds(raw)
+
+	assertFloat64(t, thresholds.LaserTemperature.HighAlarm, 80, "thresholds.LaserTemperature.HighAlarm")
+	assertFloat64(t, thresholds.LaserTemperature.LowAlarm, -10, "thresholds.LaserTemperature.LowAlarm")
+	assertFloat64(t, thresholds.LaserTemperature.HighWarning, 70, "thresholds.LaserTemperature.HighWarning")
+	assertFloat64(t, thresholds.LaserTemperature.LowWarning, 0, "thresholds.LaserTemperature.LowWarning")
+	assertFloat64(t, thresholds.TecCurrent.HighAlarm, 80, "thresholds.TecCurrent.HighAlarm")
+	assertFloat64(t, thresholds.TecCurrent.LowAlarm, 10, "thresholds.TecCurrent.LowAlarm")
+	assertFloat64(t, thresholds.TecCurrent.HighWarning, 60, "thresholds.TecCurrent.HighWarning")
+	assertFloat64(t, thresholds.TecCurrent.LowWarning, 20, "thresholds.TecCurrent.LowWarning")
+}
+
 func TestParseEncoding(t *testing.T) {
 	encoding := Encoding(0x04)
 
@@ -256,3 +317,294 @@ func TestLaserAbstraction(t *testing.T) {
 	//     t.Errorf("Cpi;")
 	// }
 }
+
+func TestSoftControl(t *testing.T) {
+	eeprom := getEEPROM(t)
+	control, err := eeprom.GetSoftControl()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	requested := *control
+	requested.SoftTxDisable = !requested.SoftTxDisable
+	err = eeprom.ValidateSoftControl(&requested)
+	assertBool(t, err == nil, eeprom.EnhancedOptions.SoftTxDisableControlAndMonitoringImplemented, "eeprom.ValidateSoftControl() == nil")
+
+	eeprom.EnhancedOptions.SoftRateSelectControlAndMonitoringImplemented = false
+	requested = *control
+	requested.SoftRS1Select = !requested.SoftRS1Select
+	if eeprom.ValidateSoftControl(&requested) == nil {
+		t.Error("eeprom.ValidateSoftControl accepted soft rate select without it being implemented")
+	}
+
+	statusControl, extendedStatusControl := (&SoftControl{SoftTxDisable: true, PowerLevelSelect: true}).Encode(0x8F, 0x0A)
+	assertInt(t, int(statusControl), 0xC7, "statusControl")
+	assertInt(t, int(extendedStatusControl), 0x03, "extendedStatusControl")
+}
+
+func TestEncodeEqualizationControl(t *testing.T) {
+	input, err := (&InputEqualizationControl{HighRate: InputEqualization8dB, LowRate: InputEqualization2dB}).Encode()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	assertInt(t, int(input), 0x82, "InputEqualizationControl.Encode")
+	if _, err := (&InputEqualizationControl{HighRate: 11}).Encode(); err == nil {
+		t.Error("InputEqualizationControl.Encode accepted a reserved value")
+	}
+	output, err := (&OutputEmphasisControl{HighRate: OutputEmphasis3dB, LowRate: OutputEmphasisNoEmphasis}).Encode()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	assertInt(t, int(output), 0x30, "OutputEmphasisControl.Encode")
+	if *NewOutputEmphasisControl(output) != (OutputEmphasisControl{HighRate: OutputEmphasis3dB}) {
+		t.Error("NewOutputEmphasisControl does not decode the encoded byte")
+	}
+}
+
+func TestParseWarnings(t *testing.T) {
+	if warnings := getEEPROM(t).GetParseWarnings(); len(warnings) != 0 {
+		t.Errorf("Expected no parse warnings, got %v", warnings)
+	}
+
+	rawData := append([]byte{}, getEEPROM(t).Raw...)
+	rawData[vendorStartOffset] = '/'
+	copy(rawData[dateCodeStartOffset:], "19?218")
+	eeprom, err := NewEEPROM(rawData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	warnings := eeprom.GetParseWarnings()
+	if len(warnings) != 2 {
+		t.Fatalf("Expected 2 parse warnings, got %v", warnings)
+	}
+	if warnings[0].Field != "VendorName" || warnings[0].Offset != vendorStartOffset {
+		t.Errorf("Unexpected vendor name warning %v", warnings[0])
+	}
+	if warnings[1].Field != "DateCode" || warnings[1].Offset != dateCodeStartOffset {
+		t.Errorf("Unexpected date code warning %v", warnings[1])
+	}
+}
+
+func TestChecksumErrors(t *testing.T) {
+	rawData := append([]byte{}, getEEPROM1(t).Raw...)
+	rawData[dmiChecksumOffset]++
+
+	lenient, err := eeprom.Decode(rawData, eeprom.TypeSFF8472)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checksumErrors := lenient.GetChecksumErrors()
+	if len(checksumErrors) != 1 || !strings.HasPrefix(checksumErrors[0].Region, "CC_DMI") || !checksumErrors[0].Continued {
+		t.Errorf("Expected continued CC_DMI checksum error, got %v", checksumErrors)
+	}
+
+	if _, err := eeprom.DecodeWithOptions(rawData, eeprom.TypeSFF8472, &eeprom.ParseOptions{Strict: true}); err == nil {
+		t.Error("Expected strict decoding to fail")
+	}
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	// external calibration with the constants of getEEPROM1 (unity slopes, Rx_PWR(1) = 1.0)
+	externallyCalibrated := append([]byte{}, getEEPROM1(t).Raw...)
+	externallyCalibrated[diagnosticMonitoringTypeOffset] |= 1 << 4
+	externallyCalibratedEEPROM, err := NewEEPROM(externallyCalibrated)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, original := range []*EEPROM{getEEPROM(t), getEEPROM1(t), getEEPROM2(t), externallyCalibratedEEPROM} {
+		raw, err := original.Encode()
+		if err != nil {
+			t.Fatalf("EEPROM %d: Encode failed: %v", i, err)
+		}
+		decoded, err := NewEEPROMWithOptions(raw, &eeprom.ParseOptions{Strict: true})
+		if err != nil {
+			t.Fatalf("EEPROM %d: Decoding encoded EEPROM failed: %v", i, err)
+		}
+		original.Raw, decoded.Raw = nil, nil
+		original.ChecksumErrors, decoded.ChecksumErrors = nil, nil
+		original.ParseWarnings, decoded.ParseWarnings = nil, nil
+		if !reflect.DeepEqual(original, decoded) {
+			t.Errorf("EEPROM %d: Round trip mismatch:\n%+v\n%+v", i, original, decoded)
+		}
+	}
+}
+
+func TestEncodeVendorFields(t *testing.T) {
+	raw, err := getEEPROM2(t).Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// bytes 20-39: vendor name padded with spaces, transceiver code of byte 36, vendor OUI
+	expected, _ := hex.DecodeString("42524f4341444520202020202020202000" + "00051e")
+	if !reflect.DeepEqual(raw[vendorStartOffset:vendorOuiOffset+3], expected) {
+		t.Errorf("Expected vendor fields %x, got %x", expected, raw[vendorStartOffset:vendorOuiOffset+3])
+	}
+
+	e, err := sff8079.NewEEPROM(raw[:256])
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertString(t, e.GetVendorOUI().String(), "00:05:1E", "sff8079.NewEEPROM(raw).GetVendorOUI()")
+}
+
+func TestApplyQuirk(t *testing.T) {
+	e := getEEPROM(t)
+	rxPower, temperatureHighAlarm := e.Diagnostics.RxPower, e.Thresholds.Temperature.HighAlarm
+	quirk := &eeprom.Quirk{
+		Name:              "Rx power reported in units of 1 uW",
+		Match:             eeprom.QuirkMatch{VendorName: strings.ToLower(e.VendorName), VendorPN: e.VendorPN[:3] + "*"},
+		Calibration:       map[eeprom.Diagnostic]eeprom.Calibration{eeprom.DiagnosticRxPower: {Slope: 10}},
+		Thresholds:        map[eeprom.Diagnostic]eeprom.ThresholdValues{eeprom.DiagnosticVoltage: {HighAlarm: 3.6, LowAlarm: 3.0}},
+		IgnoreDiagnostics: []eeprom.Diagnostic{eeprom.DiagnosticTemperature, eeprom.DiagnosticBias},
+	}
+	if !quirk.Match.Matches(e) {
+		t.Fatal("Quirk does not match")
+	}
+	if (eeprom.QuirkMatch{VendorName: e.VendorName, VendorRev: "X"}).Matches(e) {
+		t.Error("Quirk of different revision matches")
+	}
+
+	e.ApplyQuirk(quirk)
+	assertFloat64(t, float64(e.Diagnostics.RxPower), float64(rxPower)*10, "e.Diagnostics.RxPower")
+	assertFloat64(t, e.Thresholds.Temperature.HighAlarm, temperatureHighAlarm, "e.Thresholds.Temperature.HighAlarm")
+	assertFloat64(t, e.Thresholds.Voltage.HighAlarm, 3.6, "e.Thresholds.Voltage.HighAlarm")
+	if _, err := e.GetModuleTemperature(); err == nil {
+		t.Error("Ignored module temperature is reported")
+	}
+	if _, err := e.GetLasers()[0].GetBias(); err == nil {
+		t.Error("Ignored bias is reported")
+	}
+	if rxPower, err := e.GetLasers()[0].GetRxPower(); err != nil || rxPower.GetValue() != float64(e.Diagnostics.RxPower) {
+		t.Errorf("Unexpected Rx power %v, %v", rxPower, err)
+	}
+	if quirks := e.GetAppliedQuirks(); len(quirks) != 1 || quirks[0] != quirk.Name {
+		t.Errorf("Unexpected applied quirks %v", quirks)
+	}
+}
+
+// registerQuirk registers quirk until the end of the test
+func registerQuirk(t *testing.T, quirk *eeprom.Quirk) {
+	eeprom.RegisterQuirk(quirk)
+	t.Cleanup(func() { eeprom.UnregisterQuirk(quirk) })
+}
+
+// decodeWithVendorName decodes the first fixture with its vendor name replaced, matching it to quirks
+func decodeWithVendorName(t *testing.T, vendorName string) eeprom.EEPROM {
+	rawVendorName := make([]byte, vendorEndOffset-vendorStartOffset+1)
+	if err := eeprom.PutString("VendorName", rawVendorName, vendorName); err != nil {
+		t.Fatal(err)
+	}
+	return decodeWithRawVendorName(t, rawVendorName)
+}
+
+// decodeWithRawVendorName decodes the first fixture with the bytes of its vendor name replaced
+func decodeWithRawVendorName(t *testing.T, rawVendorName []byte) eeprom.EEPROM {
+	rawData := append([]byte{}, getEEPROM(t).Raw...)
+	copy(rawData[vendorStartOffset:vendorEndOffset+1], rawVendorName)
+	decoded, err := eeprom.Decode(rawData, eeprom.TypeSFF8472)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return decoded
+}
+
+func TestForceTypeQuirk(t *testing.T) {
+	registerQuirk(t, &eeprom.Quirk{
+		Name:      "Diagnostics of QUIRKY modules are garbage",
+		Match:     eeprom.QuirkMatch{VendorName: "QUIRKY"},
+		ForceType: eeprom.TypeSFF8079,
+	})
+
+	decoded := decodeWithVendorName(t, "QUIRKY")
+	if _, ok := decoded.(*sff8079.EEPROM); !ok {
+		t.Fatalf("Expected SFF-8079 EEPROM, got %T", decoded)
+	}
+	if quirks := decoded.(eeprom.QuirkApplier).GetAppliedQuirks(); len(quirks) != 1 {
+		t.Errorf("Unexpected applied quirks %v", quirks)
+	}
+}
+
+func TestDecodeQuirks(t *testing.T) {
+	original := getEEPROM(t)
+	calibration := &eeprom.Quirk{
+		Name:        "Rx power reported in units of 1 uW",
+		Match:       eeprom.QuirkMatch{VendorName: "QUIRKY*"},
+		Calibration: map[eeprom.Diagnostic]eeprom.Calibration{eeprom.DiagnosticRxPower: {Slope: 10}},
+	}
+	thresholds := &eeprom.Quirk{
+		Name:       "Wrong voltage thresholds",
+		Match:      eeprom.QuirkMatch{VendorName: "QUIRKY", VendorPN: original.VendorPN},
+		Thresholds: map[eeprom.Diagnostic]eeprom.ThresholdValues{eeprom.DiagnosticVoltage: {HighAlarm: 3.6, LowAlarm: 3.0}},
+	}
+	ignore := &eeprom.Quirk{
+		Name:              "Broken bias",
+		Match:             eeprom.QuirkMatch{VendorName: "QUIRKY", VendorRev: original.VendorRev},
+		IgnoreDiagnostics: []eeprom.Diagnostic{eeprom.DiagnosticBias},
+	}
+	registerQuirk(t, calibration)
+	registerQuirk(t, thresholds)
+	registerQuirk(t, ignore)
+
+	e, ok := decodeWithVendorName(t, "QUIRKY").(*EEPROM)
+	if !ok {
+		t.Fatal("Expected SFF-8472 EEPROM")
+	}
+	assertFloat64(t, float64(e.Diagnostics.RxPower), float64(original.Diagnostics.RxPower)*10, "e.Diagnostics.RxPower")
+	assertFloat64(t, float64(e.Thresholds.RxPower.HighAlarm), float64(original.Thresholds.RxPower.HighAlarm)*10, "e.Thresholds.RxPower.HighAlarm")
+	assertFloat64(t, e.Thresholds.Voltage.HighAlarm, 3.6, "e.Thresholds.Voltage.HighAlarm")
+	assertFloat64(t, e.Thresholds.Voltage.LowAlarm, 3.0, "e.Thresholds.Voltage.LowAlarm")
+	assertFloat64(t, e.Thresholds.Temperature.HighAlarm, original.Thresholds.Temperature.HighAlarm, "e.Thresholds.Temperature.HighAlarm")
+	if _, err := e.GetLasers()[0].GetBias(); err == nil {
+		t.Error("Ignored bias is reported")
+	}
+	if _, err := e.GetModuleTemperature(); err != nil {
+		t.Errorf("Module temperature not reported: %v", err)
+	}
+	if quirks := e.GetAppliedQuirks(); !reflect.DeepEqual(quirks, []string{calibration.Name, thresholds.Name, ignore.Name}) {
+		t.Errorf("Unexpected applied quirks %v", quirks)
+	}
+
+	e, ok = decodeWithVendorName(t, "QUIRKY LLC").(*EEPROM)
+	if !ok {
+		t.Fatal("Expected SFF-8472 EEPROM")
+	}
+	if quirks := e.GetAppliedQuirks(); !reflect.DeepEqual(quirks, []string{calibration.Name}) {
+		t.Errorf("Unexpected applied quirks %v", quirks)
+	}
+
+	eeprom.UnregisterQuirk(calibration)
+	e, ok = decodeWithVendorName(t, "QUIRKY LLC").(*EEPROM)
+	if !ok {
+		t.Fatal("Expected SFF-8472 EEPROM")
+	}
+	if quirks := e.GetAppliedQuirks(); len(quirks) != 0 {
+		t.Errorf("Unexpected applied quirks %v after unregistering", quirks)
+	}
+	assertFloat64(t, float64(e.Diagnostics.RxPower), float64(original.Diagnostics.RxPower), "e.Diagnostics.RxPower")
+}
+
+func TestKnownQuirkGarbageVendorName(t *testing.T) {
+	for _, rawVendorName := range [][]byte{
+		[]byte("/ garbage       "),
+		{0xC3, 0x28, 0xFF, 0xFE, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20, 0x20},
+	} {
+		e, ok := decodeWithRawVendorName(t, rawVendorName).(*EEPROM)
+		if !ok {
+			t.Fatal("Expected SFF-8472 EEPROM")
+		}
+		if quirks := e.GetAppliedQuirks(); len(quirks) != 1 {
+			t.Errorf("Vendor name %x: Unexpected applied quirks %v", rawVendorName, quirks)
+		}
+		if _, err := e.GetModuleTemperature(); err == nil {
+			t.Errorf("Vendor name %x: Module temperature of garbage EEPROM is reported", rawVendorName)
+		}
+		if _, err := e.GetLasers()[0].GetRxPower(); err == nil {
+			t.Errorf("Vendor name %x: Rx power of garbage EEPROM is reported", rawVendorName)
+		}
+	}
+
+	if quirks := decodeWithVendorName(t, "FLEXOPTIX/").(eeprom.QuirkApplier).GetAppliedQuirks(); len(quirks) != 0 {
+		t.Errorf("Unexpected applied quirks %v for a plausible vendor name", quirks)
+	}
+}
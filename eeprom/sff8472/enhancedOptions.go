@@ -1,5 +1,7 @@
 package sff8472
 
+import "github.com/wobcom/go-ethtool/eeprom"
+
 // EnhancedOptions as of SFF-84722
 type EnhancedOptions struct {
 	AlarmWarningFlagsImplemented                    bool
@@ -23,3 +25,16 @@ func NewEnhancedOptions(raw byte) *EnhancedOptions {
 		SoftRateSelectImplementedAsPerSFF8431:           raw&(1<<1) > 0,
 	}
 }
+
+// Encode encodes the enhanced options into their byte as parsed by NewEnhancedOptions
+func (e *EnhancedOptions) Encode() byte {
+	raw := byte(0)
+	eeprom.SetBits(&raw, 1<<7, e.AlarmWarningFlagsImplemented)
+	eeprom.SetBits(&raw, 1<<6, e.SoftTxDisableControlAndMonitoringImplemented)
+	eeprom.SetBits(&raw, 1<<5, e.SoftTxFaultImplemented)
+	eeprom.SetBits(&raw, 1<<4, e.SoftRxLosImplemented)
+	eeprom.SetBits(&raw, 1<<3, e.SoftRateSelectControlAndMonitoringImplemented)
+	eeprom.SetBits(&raw, 1<<2, e.ApplicationSelectControlImplementedAsPersff8079)
+	eeprom.SetBits(&raw, 1<<1, e.SoftRateSelectImplementedAsPerSFF8431)
+	return raw
+}
@@ -1,11 +1,15 @@
 package sff8472
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"github.com/wobcom/go-ethtool/eeprom"
+)
 
 // ExtendedStatusControl Extended module control and status bytes as of SFF-847 Rev 12.3 Table 10-1
 type ExtendedStatusControl struct {
 	SoftRS1Select            bool
 	PowerLevelOperationState PowerLevelOperationState
+	PowerLevelSelect         bool
 	Gfc64ModeTxConfigured    bool
 	Gfc64ModeRxConfigured    bool
 	Gfc64Mode                bool
@@ -40,6 +44,7 @@ func NewExtendedStatusControl(raw [2]byte) *ExtendedStatusControl {
 	return &ExtendedStatusControl{
 		SoftRS1Select:            raw[0]&(1<<3) > 0,
 		PowerLevelOperationState: PowerLevelOperationState(raw[0]&(1<<1) > 0),
+		PowerLevelSelect:         raw[0]&(1<<0) > 0,
 		Gfc64ModeTxConfigured:    raw[1]&(1<<4) > 0,
 		Gfc64ModeRxConfigured:    raw[1]&(1<<3) > 0,
 		Gfc64Mode:                raw[1]&(1<<2) > 0,
@@ -47,3 +52,17 @@ func NewExtendedStatusControl(raw [2]byte) *ExtendedStatusControl {
 		RxCdrUnlocked:            raw[1]&(1<<0) > 0,
 	}
 }
+
+// Encode encodes the extended status and control bits into their [2]byte representation as parsed by NewExtendedStatusControl
+func (e *ExtendedStatusControl) Encode() [2]byte {
+	raw := [2]byte{}
+	eeprom.SetBits(&raw[0], 1<<3, e.SoftRS1Select)
+	eeprom.SetBits(&raw[0], 1<<1, bool(e.PowerLevelOperationState))
+	eeprom.SetBits(&raw[0], 1<<0, e.PowerLevelSelect)
+	eeprom.SetBits(&raw[1], 1<<4, e.Gfc64ModeTxConfigured)
+	eeprom.SetBits(&raw[1], 1<<3, e.Gfc64ModeRxConfigured)
+	eeprom.SetBits(&raw[1], 1<<2, e.Gfc64Mode)
+	eeprom.SetBits(&raw[1], 1<<1, e.TxCdrUnlocked)
+	eeprom.SetBits(&raw[1], 1<<0, e.RxCdrUnlocked)
+	return raw
+}
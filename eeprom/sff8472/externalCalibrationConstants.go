@@ -55,3 +55,25 @@ func NewExternalCalibrationConstants(raw [36]byte) *ExternalCalibrationConstants
 	}
 	return e
 }
+
+// Encode encodes the calibration constants into their [36]byte representation as parsed by NewExternalCalibrationConstants
+func (e *ExternalCalibrationConstants) Encode() [36]byte {
+	raw := [36]byte{}
+	for i, rxPwr := range e.RxPwr {
+		encoded := encodeFloatingPoint(rxPwr)
+		copy(raw[i*4:], encoded[:])
+	}
+	for byteOffset, value := range map[uint][2]byte{
+		0x14: encodeUnsignedDecimal(e.BiasSlope),
+		0x16: encodeSignedDecimal(e.BiasOffset),
+		0x18: encodeUnsignedDecimal(e.TxPowerSlope),
+		0x1A: encodeSignedDecimal(e.TxPowerOffset),
+		0x1C: encodeUnsignedDecimal(e.TemperatureSlope),
+		0x1E: encodeSignedDecimal(e.TemperatureOffset),
+		0x20: encodeUnsignedDecimal(e.VoltageSlope),
+		0x22: encodeSignedDecimal(e.VoltageOffset),
+	} {
+		copy(raw[byteOffset:], value[:])
+	}
+	return raw
+}
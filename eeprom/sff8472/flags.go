@@ -0,0 +1,92 @@
+package sff8472
+
+// MeasurementFlags a helper struct for implementing eeprom.AlarmFlags interface,
+// combines the alarm and warning flags of a measurement
+type MeasurementFlags struct {
+	HighAlarm   bool
+	HighWarning bool
+	LowAlarm    bool
+	LowWarning  bool
+}
+
+// LaneFlags a helper struct for implementing eeprom.LaneFlags interface.
+// Tx fault and Rx LOS reflect the soft state of A2h byte 110, the loss of lock flags the CDR state of byte 119.
+type LaneFlags struct {
+	TxFault bool
+	RxLOS   bool
+	TxLOL   bool
+	RxLOL   bool
+}
+
+// newMeasurementFlags combines alarm and warning flags into a new MeasurementFlags instance
+func newMeasurementFlags(alarm AlarmFlagStatus, warning WarningFlagStatus) *MeasurementFlags {
+	return &MeasurementFlags{
+		HighAlarm:   alarm.HighAlarm,
+		HighWarning: warning.HighWarning,
+		LowAlarm:    alarm.LowAlarm,
+		LowWarning:  warning.LowWarning,
+	}
+}
+
+// alarmFlagsImplemented returns whether the module implements the alarm and warning flags of A2h bytes 112-117
+func (e *EEPROM) alarmFlagsImplemented() bool {
+	return e.EnhancedOptions.AlarmWarningFlagsImplemented && e.AlarmFlags != nil && e.WarningFlags != nil
+}
+
+// getLaneFlags returns the lane flags, nil if A2h is not available
+func (e *EEPROM) getLaneFlags() *LaneFlags {
+	if e.StatusControl == nil || e.ExtendedStatusControl == nil {
+		return nil
+	}
+	return &LaneFlags{
+		TxFault: e.EnhancedOptions.SoftTxFaultImplemented && e.StatusControl.TxFaultState,
+		RxLOS:   e.EnhancedOptions.SoftRxLosImplemented && e.StatusControl.RxLosState,
+		TxLOL:   e.ExtendedStatusControl.TxCdrUnlocked,
+		RxLOL:   e.ExtendedStatusControl.RxCdrUnlocked,
+	}
+}
+
+// GetHighAlarm implements eeprom.AlarmFlags interface's GetHighAlarm function
+func (m *MeasurementFlags) GetHighAlarm() bool {
+	return m.HighAlarm
+}
+
+// GetHighWarning implements eeprom.AlarmFlags interface's GetHighWarning function
+func (m *MeasurementFlags) GetHighWarning() bool {
+	return m.HighWarning
+}
+
+// GetLowAlarm implements eeprom.AlarmFlags interface's GetLowAlarm function
+func (m *MeasurementFlags) GetLowAlarm() bool {
+	return m.LowAlarm
+}
+
+// GetLowWarning implements eeprom.AlarmFlags interface's GetLowWarning function
+func (m *MeasurementFlags) GetLowWarning() bool {
+	return m.LowWarning
+}
+
+// GetTxFault implements eeprom.LaneFlags interface's GetTxFault function
+func (l *LaneFlags) GetTxFault() bool {
+	return l.TxFault
+}
+
+// GetTxLOS implements eeprom.LaneFlags interface's GetTxLOS function, SFF-8472 does not define a Tx LOS flag
+func (l *LaneFlags) GetTxLOS() bool {
+	return false
+}
+
+// GetTxLOL implements eeprom.LaneFlags interface's GetTxLOL function
+func (l *LaneFlags) GetTxLOL() bool {
+	return l.TxLOL
+}
+
+// GetRxLOS implements eeprom.LaneFlags interface's GetRxLOS function
+func (l *LaneFlags) GetRxLOS() bool {
+	return l.RxLOS
+}
+
+// GetRxLOL implements eeprom.LaneFlags interface's GetRxLOL function
+func (l *LaneFlags) GetRxLOL() bool {
+	return l.RxLOL
+}
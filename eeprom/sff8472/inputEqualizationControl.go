@@ -63,3 +63,14 @@ func NewInputEqualizationControl(raw byte) *InputEqualizationControl {
 		LowRate:  InputEqualization(raw & 0b00001111),
 	}
 }
+
+// InputEqualizationControlOffset offset of the input equalization control byte 114 at A2h
+const InputEqualizationControlOffset = unallocatedOffset
+
+// Encode encodes the input equalization control into its byte, fails for reserved values
+func (i *InputEqualizationControl) Encode() (byte, error) {
+	if i.HighRate > InputEqualization10dB || i.LowRate > InputEqualization10dB {
+		return 0, fmt.Errorf("Invalid input equalization %d / %d dB, at most 10 dB are supported", i.HighRate, i.LowRate)
+	}
+	return byte(i.HighRate)<<4 | byte(i.LowRate), nil
+}
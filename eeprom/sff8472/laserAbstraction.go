@@ -11,6 +11,7 @@ type Laser struct {
 	TxPower             *Measurement
 	Bias                *Measurement
 	MonitoringSupported bool
+	Flags               *LaneFlags
 }
 
 // Measurement a helper struct for implementing eeprom.Laser interface
@@ -18,6 +19,7 @@ type Measurement struct {
 	Value      float64
 	Unit       string
 	Thresholds *MeasurementThresholds
+	Flags      *MeasurementFlags
 }
 
 // MeasurementThresholds a helper struct for implementing eeprom.Laser interface
@@ -38,6 +40,9 @@ func (l *Laser) GetBias() (eeprom.Measurement, error) {
 	if !l.SupportsMonitoring() {
 		return nil, errors.New("This module does not implement monitoring")
 	}
+	if l.Bias == nil {
+		return nil, eeprom.IgnoredDiagnosticError(eeprom.DiagnosticBias)
+	}
 	return l.Bias, nil
 }
 
@@ -46,6 +51,9 @@ func (l *Laser) GetTxPower() (eeprom.Measurement, error) {
 	if !l.SupportsMonitoring() {
 		return nil, errors.New("This module does not implement monitoring")
 	}
+	if l.TxPower == nil {
+		return nil, eeprom.IgnoredDiagnosticError(eeprom.DiagnosticTxPower)
+	}
 	return l.TxPower, nil
 }
 
@@ -54,9 +62,25 @@ func (l *Laser) GetRxPower() (eeprom.Measurement, error) {
 	if !l.SupportsMonitoring() {
 		return nil, errors.New("This module does not implement monitoring")
 	}
+	if l.RxPower == nil {
+		return nil, eeprom.IgnoredDiagnosticError(eeprom.DiagnosticRxPower)
+	}
 	return l.RxPower, nil
 }
 
+// SupportsLaneFlags implements eeprom.Laser interface's SupportsLaneFlags function
+func (l *Laser) SupportsLaneFlags() bool {
+	return l.Flags != nil
+}
+
+// GetLaneFlags implements eeprom.Laser interface's GetLaneFlags function
+func (l *Laser) GetLaneFlags() (eeprom.LaneFlags, error) {
+	if !l.SupportsLaneFlags() {
+		return nil, errors.New("No lane flags implemented by this module")
+	}
+	return l.Flags, nil
+}
+
 // GetValue implements eeprom.Measurement interface's GetValue function
 func (m *Measurement) GetValue() float64 {
 	return m.Value
@@ -77,6 +101,19 @@ func (m *Measurement) GetAlarmThresholds() (eeprom.AlarmThresholds, error) {
 	return m.Thresholds, nil
 }
 
+// SupportsAlarmFlags implements eeprom.Measurement interface's SupportsAlarmFlags function
+func (m *Measurement) SupportsAlarmFlags() bool {
+	return m.Flags != nil
+}
+
+// GetAlarmFlags implements eeprom.Measurement interface's GetAlarmFlags function
+func (m *Measurement) GetAlarmFlags() (eeprom.AlarmFlags, error) {
+	if !m.SupportsAlarmFlags() {
+		return nil, errors.New("No alarm flags implemented by this module")
+	}
+	return m.Flags, nil
+}
+
 // GetHighAlarm implements eeprom.Measurement interface's GetHighAlarm function
 func (m *MeasurementThresholds) GetHighAlarm() float64 {
 	return m.HighAlarm
@@ -109,39 +146,45 @@ func (e *EEPROM) GetLasers() []eeprom.Laser {
 			},
 		}
 	}
-	return []eeprom.Laser{
-		&Laser{
-			MonitoringSupported: e.DiagnosticMonitoringType.DiagnosticMonitoringImplemented,
-			RxPower: &Measurement{
-				Value: float64(e.Diagnostics.RxPower),
-				Unit:  "milliwatts",
-				Thresholds: &MeasurementThresholds{
-					HighAlarm:   float64(e.Thresholds.RxPower.HighAlarm),
-					HighWarning: float64(e.Thresholds.RxPower.HighWarning),
-					LowAlarm:    float64(e.Thresholds.RxPower.LowAlarm),
-					LowWarning:  float64(e.Thresholds.RxPower.LowWarning),
-				},
+	laser := &Laser{
+		MonitoringSupported: e.DiagnosticMonitoringType.DiagnosticMonitoringImplemented,
+		RxPower: &Measurement{
+			Value: float64(e.Diagnostics.RxPower),
+			Unit:  "milliwatts",
+			Thresholds: &MeasurementThresholds{
+				HighAlarm:   float64(e.Thresholds.RxPower.HighAlarm),
+				HighWarning: float64(e.Thresholds.RxPower.HighWarning),
+				LowAlarm:    float64(e.Thresholds.RxPower.LowAlarm),
+				LowWarning:  float64(e.Thresholds.RxPower.LowWarning),
 			},
-			TxPower: &Measurement{
-				Value: float64(e.Diagnostics.TxPower),
-				Unit:  "milliwatts",
-				Thresholds: &MeasurementThresholds{
-					HighAlarm:   float64(e.Thresholds.TxPower.HighAlarm),
-					HighWarning: float64(e.Thresholds.TxPower.HighWarning),
-					LowAlarm:    float64(e.Thresholds.TxPower.LowAlarm),
-					LowWarning:  float64(e.Thresholds.TxPower.LowWarning),
-				},
+		},
+		TxPower: &Measurement{
+			Value: float64(e.Diagnostics.TxPower),
+			Unit:  "milliwatts",
+			Thresholds: &MeasurementThresholds{
+				HighAlarm:   float64(e.Thresholds.TxPower.HighAlarm),
+				HighWarning: float64(e.Thresholds.TxPower.HighWarning),
+				LowAlarm:    float64(e.Thresholds.TxPower.LowAlarm),
+				LowWarning:  float64(e.Thresholds.TxPower.LowWarning),
 			},
-			Bias: &Measurement{
-				Value: float64(e.Diagnostics.Bias),
-				Unit:  "milliwatts",
-				Thresholds: &MeasurementThresholds{
-					HighAlarm:   e.Thresholds.Bias.HighAlarm,
-					HighWarning: e.Thresholds.Bias.HighWarning,
-					LowAlarm:    e.Thresholds.Bias.LowAlarm,
-					LowWarning:  e.Thresholds.Bias.LowWarning,
-				},
+		},
+		Bias: &Measurement{
+			Value: float64(e.Diagnostics.Bias),
+			Unit:  "milliwatts",
+			Thresholds: &MeasurementThresholds{
+				HighAlarm:   e.Thresholds.Bias.HighAlarm,
+				HighWarning: e.Thresholds.Bias.HighWarning,
+				LowAlarm:    e.Thresholds.Bias.LowAlarm,
+				LowWarning:  e.Thresholds.Bias.LowWarning,
 			},
 		},
+		Flags: e.getLaneFlags(),
+	}
+	if e.alarmFlagsImplemented() {
+		laser.RxPower.Flags = newMeasurementFlags(e.AlarmFlags.RxPower, e.WarningFlags.RxPower)
+		laser.TxPower.Flags = newMeasurementFlags(e.AlarmFlags.TxPower, e.WarningFlags.TxPower)
+		laser.Bias.Flags = newMeasurementFlags(e.AlarmFlags.Bias, e.WarningFlags.Bias)
 	}
+	e.ignoreDiagnostics(laser)
+	return []eeprom.Laser{laser}
 }
@@ -13,3 +13,9 @@ func NewOptionalDiagnostics(raw [4]byte) *OptionalDiagnostics {
 		TecCurrent:       parseCurrent(raw[2], raw[3]),
 	}
 }
+
+// Encode encodes the optional diagnostics into their [4]byte representation as parsed by NewOptionalDiagnostics
+func (o *OptionalDiagnostics) Encode() [4]byte {
+	laserTemperature, tecCurrent := encodeTemperature(o.LaserTemperature), encodeCurrent(o.TecCurrent)
+	return [4]byte{laserTemperature[0], laserTemperature[1], tecCurrent[0], tecCurrent[1]}
+}
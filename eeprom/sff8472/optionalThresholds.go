@@ -17,13 +17,13 @@ var optionalThresholdsMemoryMap = map[uint]func(*OptionalThresholds, byte, byte)
 		o.LaserTemperature.HighWarning = parseTemperature(msb, lsb)
 	},
 	0x06: func(o *OptionalThresholds, msb byte, lsb byte) {
-		o.LaserTemperature.HighAlarm = parseTemperature(msb, lsb)
+		o.LaserTemperature.LowWarning = parseTemperature(msb, lsb)
 	},
 
 	0x08: func(o *OptionalThresholds, msb byte, lsb byte) { o.TecCurrent.HighAlarm = parseCurrent(msb, lsb) },
 	0x0A: func(o *OptionalThresholds, msb byte, lsb byte) { o.TecCurrent.LowAlarm = parseCurrent(msb, lsb) },
 	0x0C: func(o *OptionalThresholds, msb byte, lsb byte) { o.TecCurrent.HighWarning = parseCurrent(msb, lsb) },
-	0x0E: func(o *OptionalThresholds, msb byte, lsb byte) { o.TecCurrent.HighAlarm = parseCurrent(msb, lsb) },
+	0x0E: func(o *OptionalThresholds, msb byte, lsb byte) { o.TecCurrent.LowWarning = parseCurrent(msb, lsb) },
 }
 
 // NewOptionalThresholds parses [16]byte into a new OptionalThresholds instance
@@ -34,3 +34,13 @@ func NewOptionalThresholds(raw [16]byte) *OptionalThresholds {
 	}
 	return o
 }
+
+// Encode encodes the optional thresholds into their [16]byte representation as parsed by NewOptionalThresholds
+func (o *OptionalThresholds) Encode() [16]byte {
+	raw := [16]byte{}
+	laserTemperature := o.LaserTemperature.encode(encodeTemperature)
+	tecCurrent := o.TecCurrent.encode(encodeCurrent)
+	copy(raw[0x00:], laserTemperature[:])
+	copy(raw[0x08:], tecCurrent[:])
+	return raw
+}
@@ -57,3 +57,14 @@ func NewOutputEmphasisControl(raw byte) *OutputEmphasisControl {
 		LowRate:  OutputEmphasis(raw & 0b00001111),
 	}
 }
+
+// OutputEmphasisControlOffset offset of the output emphasis control byte 115 at A2h
+const OutputEmphasisControlOffset = cdrUnlockedOffset
+
+// Encode encodes the output emphasis control into its byte, values above 7 dB are vendor specific
+func (o *OutputEmphasisControl) Encode() (byte, error) {
+	if o.HighRate > 0x0F || o.LowRate > 0x0F {
+		return 0, fmt.Errorf("Invalid output emphasis %#02x / %#02x, values are 4 bits wide", byte(o.HighRate), byte(o.LowRate))
+	}
+	return byte(o.HighRate)<<4 | byte(o.LowRate), nil
+}
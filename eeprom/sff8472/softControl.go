@@ -0,0 +1,89 @@
+package sff8472
+
+import (
+	"errors"
+	"github.com/wobcom/go-ethtool/eeprom"
+)
+
+// StatusControlOffset offset of the optional status and control byte 110 at A2h
+const StatusControlOffset = statusControlOffset
+
+// ExtendedStatusControlOffset offset of the extended module control byte 118 at A2h
+const ExtendedStatusControlOffset = extendedStatusControlOffset
+
+// Writable bits of the byte at StatusControlOffset
+const (
+	// StatusControlSoftTxDisable disables the transmitter
+	StatusControlSoftTxDisable byte = 1 << 6
+	// StatusControlSoftRS0Select selects full bandwidth operation of the receiver, RS(0)
+	StatusControlSoftRS0Select byte = 1 << 3
+)
+
+// Writable bits of the byte at ExtendedStatusControlOffset
+const (
+	// ExtendedStatusControlSoftRS1Select selects full bandwidth operation of the transmitter, RS(1)
+	ExtendedStatusControlSoftRS1Select byte = 1 << 3
+	// ExtendedStatusControlPowerLevelSelect enables power level 2 or 3
+	ExtendedStatusControlPowerLevelSelect byte = 1 << 0
+)
+
+// SoftControl writable soft controls of A2h bytes 110 and 118, see SFF-8472 rev 12.3 tables 9-11 and 10-1
+type SoftControl struct {
+	SoftTxDisable    bool
+	SoftRS0Select    bool
+	SoftRS1Select    bool
+	PowerLevelSelect bool
+}
+
+// GetSoftControl returns the module's current soft controls, fails if A2h is not available
+func (e *EEPROM) GetSoftControl() (*SoftControl, error) {
+	if e.StatusControl == nil || e.ExtendedStatusControl == nil {
+		return nil, errors.New("Soft controls require page A2h")
+	}
+	return &SoftControl{
+		SoftTxDisable:    e.StatusControl.SoftTxDisableSelect,
+		SoftRS0Select:    e.StatusControl.FullbandwidthOperation,
+		SoftRS1Select:    e.ExtendedStatusControl.SoftRS1Select,
+		PowerLevelSelect: e.ExtendedStatusControl.PowerLevelSelect,
+	}, nil
+}
+
+// Encode writes the soft controls into the given bytes 110 and 118 and returns them, all other bits are kept
+func (c *SoftControl) Encode(statusControl byte, extendedStatusControl byte) (byte, byte) {
+	eeprom.SetBits(&statusControl, StatusControlSoftTxDisable, c.SoftTxDisable)
+	eeprom.SetBits(&statusControl, StatusControlSoftRS0Select, c.SoftRS0Select)
+	eeprom.SetBits(&extendedStatusControl, ExtendedStatusControlSoftRS1Select, c.SoftRS1Select)
+	eeprom.SetBits(&extendedStatusControl, ExtendedStatusControlPowerLevelSelect, c.PowerLevelSelect)
+	return statusControl, extendedStatusControl
+}
+
+// ValidateSoftControl checks that all changes of c compared to the module's current soft controls are implemented by the module
+func (e *EEPROM) ValidateSoftControl(c *SoftControl) error {
+	current, err := e.GetSoftControl()
+	if err != nil {
+		return err
+	}
+	if current.SoftTxDisable != c.SoftTxDisable && !e.EnhancedOptions.SoftTxDisableControlAndMonitoringImplemented {
+		return errors.New("Module does not implement soft Tx disable")
+	}
+	if (current.SoftRS0Select != c.SoftRS0Select || current.SoftRS1Select != c.SoftRS1Select) && !e.EnhancedOptions.SoftRateSelectControlAndMonitoringImplemented {
+		return errors.New("Module does not implement soft rate select")
+	}
+	if current.PowerLevelSelect != c.PowerLevelSelect && !e.Options.PowerLevel2Requirement && !e.Options.PowerLevel3Requirement {
+		return errors.New("Module does not implement power level select")
+	}
+	return nil
+}
+
+// SupportsInputEqualizationControl returns whether the module's Tx input equalization (A2h byte 114, table 9-12) can be set.
+// Tx input equalization is performed by the retimer or CDR advertised in A0h byte 64 bit 3, see SFF-8472 rev 12.3 table 8-3.
+func (e *EEPROM) SupportsInputEqualizationControl() bool {
+	return e.InputEqualizationControl != nil && e.Options != nil && e.Options.RetimeOrCDRPresent
+}
+
+// SupportsOutputEmphasisControl returns whether the module's Rx output emphasis (A2h byte 115, table 9-12) can be set.
+// Rx output emphasis is applied by the retimer or CDR advertised in A0h byte 64 bit 3 and is not defined for
+// linear receiver outputs advertised in A0h byte 64 bit 0, see SFF-8472 rev 12.3 table 8-3.
+func (e *EEPROM) SupportsOutputEmphasisControl() bool {
+	return e.OutputEmphasisControl != nil && e.Options != nil && e.Options.RetimeOrCDRPresent && !e.Options.LinearReceiverOutputImplemented
+}
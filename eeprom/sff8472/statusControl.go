@@ -1,5 +1,7 @@
 package sff8472
 
+import "github.com/wobcom/go-ethtool/eeprom"
+
 // StatusControl  Optional Status and Control Bits as of SFF-8472 rev 12.3 table 9-11
 type StatusControl struct {
 	TxDisableState         bool
@@ -25,3 +27,17 @@ func NewStatusControl(raw byte) *StatusControl {
 		DataReadyBarState:      raw&(1<<0) > 0,
 	}
 }
+
+// Encode encodes the status and control bits into their byte as parsed by NewStatusControl
+func (s *StatusControl) Encode() byte {
+	raw := byte(0)
+	eeprom.SetBits(&raw, 1<<7, s.TxDisableState)
+	eeprom.SetBits(&raw, 1<<6, s.SoftTxDisableSelect)
+	eeprom.SetBits(&raw, 1<<5, s.InputPinRS1State)
+	eeprom.SetBits(&raw, 1<<4, s.InputPinRS0State)
+	eeprom.SetBits(&raw, 1<<3, s.FullbandwidthOperation)
+	eeprom.SetBits(&raw, 1<<2, s.TxFaultState)
+	eeprom.SetBits(&raw, 1<<1, s.RxLosState)
+	eeprom.SetBits(&raw, 1<<0, s.DataReadyBarState)
+	return raw
+}
@@ -66,3 +66,44 @@ func NewThresholds(raw [40]byte) *Thresholds {
 	}
 	return t
 }
+
+// Encode encodes the thresholds into their [40]byte representation as parsed by NewThresholds, nil thresholds are encoded as zero
+func (t *Thresholds) Encode() [40]byte {
+	raw := [40]byte{}
+	for byteOffset, thresholds := range map[uint][8]byte{
+		0x00: t.Temperature.encode(encodeTemperature),
+		0x08: t.Voltage.encode(encodeVoltage),
+		0x10: t.Bias.encode(encodeCurrent),
+		0x18: t.TxPower.encode(),
+		0x20: t.RxPower.encode(),
+	} {
+		copy(raw[byteOffset:], thresholds[:])
+	}
+	return raw
+}
+
+// encode encodes high alarm, low alarm, high warning and low warning in the order of the memory map
+func (a *AlarmThresholds) encode(encodeValue func(float64) [2]byte) [8]byte {
+	raw := [8]byte{}
+	if a == nil {
+		return raw
+	}
+	for i, value := range []float64{a.HighAlarm, a.LowAlarm, a.HighWarning, a.LowWarning} {
+		encoded := encodeValue(value)
+		copy(raw[2*i:], encoded[:])
+	}
+	return raw
+}
+
+// encode encodes high alarm, low alarm, high warning and low warning in the order of the memory map
+func (a *AlarmThresholdsPower) encode() [8]byte {
+	raw := [8]byte{}
+	if a == nil {
+		return raw
+	}
+	for i, value := range []Power{a.HighAlarm, a.LowAlarm, a.HighWarning, a.LowWarning} {
+		encoded := encodePower(value)
+		copy(raw[2*i:], encoded[:])
+	}
+	return raw
+}
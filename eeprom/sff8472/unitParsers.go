@@ -3,12 +3,9 @@ package sff8472
 import (
 	"bytes"
 	"encoding/binary"
-	"encoding/hex"
 	"encoding/json"
-	"fmt"
 	"github.com/wobcom/go-ethtool/util"
 	"math"
-	"strings"
 )
 
 // Power a power measurement
@@ -31,11 +28,7 @@ func (p Power) MarshalJSON() ([]byte, error) {
 }
 
 func parseString(raw []byte) string {
-	s := util.GetValidUtf8String(bytes.Trim(raw, "\x00"))
-	if strings.HasPrefix(s, "/") {
-		fmt.Printf("Parse weird string %s, hex: %s", s, hex.EncodeToString(raw))
-	}
-	return s
+	return util.GetValidUtf8String(bytes.Trim(raw, "\x00"))
 }
 
 func parseWavelength(msb byte, lsb byte) float64 {
@@ -74,3 +67,44 @@ func parseSignedDecimal(msb byte, lsb byte) float64 {
 func parseUnsignedDecimal(msb byte, lsb byte) float64 {
 	return float64(msb) + float64(lsb)/256.0
 }
+
+func encodeWavelength(wavelength float64) [2]byte {
+	return encodeUint16(wavelength)
+}
+
+func encodeTemperature(temperature float64) [2]byte {
+	return encodeSignedDecimal(temperature)
+}
+
+func encodeVoltage(voltage float64) [2]byte {
+	return encodeUint16(voltage * 10000.0)
+}
+
+func encodeCurrent(current float64) [2]byte {
+	return encodeUint16(current * 500.0)
+}
+
+func encodePower(power Power) [2]byte {
+	return encodeUint16(float64(power) * 10000.0)
+}
+
+// encodeUint16 rounds value to the nearest uint16, clamping values out of range
+func encodeUint16(value float64) [2]byte {
+	v := uint16(math.Max(0, math.Min(math.MaxUint16, math.Round(value))))
+	return [2]byte{byte(v >> 8), byte(v)}
+}
+
+func encodeFloatingPoint(value float64) [4]byte {
+	raw := [4]byte{}
+	binary.BigEndian.PutUint32(raw[:], math.Float32bits(float32(value)))
+	return raw
+}
+
+func encodeSignedDecimal(value float64) [2]byte {
+	v := int16(math.Max(math.MinInt16, math.Min(math.MaxInt16, math.Round(value*256.0))))
+	return [2]byte{byte(v >> 8), byte(v)}
+}
+
+func encodeUnsignedDecimal(value float64) [2]byte {
+	return encodeUint16(value * 256.0)
+}
@@ -1,5 +1,7 @@
 package sff8472
 
+import "github.com/wobcom/go-ethtool/eeprom"
+
 // WarningFlags Diagnostic Warning Flag Status Bits  as of SFF-8472 rev 12.3 table 9-12
 type WarningFlags struct {
 	Temperature      WarningFlagStatus
@@ -48,3 +50,17 @@ func NewWarningFlags(raw [2]byte) *WarningFlags {
 	}
 	return a
 }
+
+// Encode encodes the warning flags into their [2]byte representation as parsed by NewWarningFlags
+func (w *WarningFlags) Encode() [2]byte {
+	raw := [2]byte{}
+	for byteOffset, bitMap := range warningFlagsMemoryMap {
+		for bitOffset, callback := range bitMap {
+			// clearing the flag only changes the warning flags if it has been set
+			probe := *w
+			callback(&probe, false)
+			eeprom.SetBits(&raw[byteOffset], 1<<bitOffset, probe != *w)
+		}
+	}
+	return raw
+}
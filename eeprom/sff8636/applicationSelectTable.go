@@ -0,0 +1,70 @@
+package sff8636
+
+import (
+	"fmt"
+	"github.com/wobcom/go-ethtool/eeprom"
+)
+
+// ApplicationSelectTable application select table (AST) of the optional upper page 01h, see SFF-8636 rev 2.10a section 6.2.4
+type ApplicationSelectTable struct {
+	// Check code over bytes 129-255 of upper page 01h
+	CheckCode byte                     `json:"checkCode"`
+	Entries   []ApplicationSelectEntry `json:"entries"`
+}
+
+// ApplicationSelectEntry a single entry of the application select table
+type ApplicationSelectEntry struct {
+	// Application code, bits 5-0 of the entry's first byte
+	ApplicationCode byte `json:"applicationCode"`
+	// Extended rate select compliance of the application, the entry's second byte
+	ExtendedRateSelect byte `json:"extendedRateSelect"`
+}
+
+// Offsets relative to the start of upper page 01h
+const (
+	// Check code of the application select table
+	astCheckCodeOffset = 0x00
+	// Table length, bits 5-0 contain the number of entries minus one
+	astTableLengthOffset = 0x01
+	// First of up to 63 two byte entries
+	astEntriesOffset = 0x02
+	// Maximum number of entries
+	astMaxEntries = 63
+)
+
+// NewApplicationSelectTable parses upper page 01h into a new ApplicationSelectTable instance
+func NewApplicationSelectTable(raw [128]byte) *ApplicationSelectTable {
+	length := int(raw[astTableLengthOffset]&0x3F) + 1
+	if length > astMaxEntries {
+		length = astMaxEntries
+	}
+	a := &ApplicationSelectTable{
+		CheckCode: raw[astCheckCodeOffset],
+		Entries:   make([]ApplicationSelectEntry, length),
+	}
+	for i := range a.Entries {
+		offset := astEntriesOffset + 2*i
+		a.Entries[i] = ApplicationSelectEntry{
+			ApplicationCode:    raw[offset] & 0x3F,
+			ExtendedRateSelect: raw[offset+1],
+		}
+	}
+	return a
+}
+
+// Encode encodes the application select table into upper page 01h as parsed by NewApplicationSelectTable.
+// The check code is computed over the encoded table, CheckCode is ignored. Fails for more than 63 or no entries.
+func (a *ApplicationSelectTable) Encode() ([128]byte, error) {
+	raw := [128]byte{}
+	if len(a.Entries) == 0 || len(a.Entries) > astMaxEntries {
+		return raw, fmt.Errorf("Application select table requires 1 to %d entries, got %d", astMaxEntries, len(a.Entries))
+	}
+	raw[astTableLengthOffset] = byte(len(a.Entries) - 1)
+	for i, entry := range a.Entries {
+		offset := astEntriesOffset + 2*i
+		raw[offset] = entry.ApplicationCode & 0x3F
+		raw[offset+1] = entry.ExtendedRateSelect
+	}
+	raw[astCheckCodeOffset] = eeprom.Checksum(raw[astCheckCodeOffset+1:])
+	return raw, nil
+}
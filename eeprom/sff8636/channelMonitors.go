@@ -37,3 +37,15 @@ func NewChannelMonitors(raw [48]byte) *ChannelMonitors {
 	}
 	return c
 }
+
+// Encode encodes the channel monitors into their [48]byte representation as parsed by NewChannelMonitors
+func (c *ChannelMonitors) Encode() [48]byte {
+	raw := [48]byte{}
+	for channel, monitor := range c {
+		rxPower, bias, txPower := encodePower(monitor.RxPower), encodeCurrent(monitor.Bias), encodePower(monitor.TxPower)
+		copy(raw[0x00+2*channel:], rxPower[:])
+		copy(raw[0x08+2*channel:], bias[:])
+		copy(raw[0x10+2*channel:], txPower[:])
+	}
+	return raw
+}
@@ -0,0 +1,72 @@
+package sff8636
+
+import "github.com/wobcom/go-ethtool/eeprom"
+
+// ChannelThresholds channel monitor thresholds of upper page 03h, see SFF-8636 rev 2.10a table 6-27.
+// SFF-8636 defines a single set of channel thresholds which applies to all four channels.
+type ChannelThresholds struct {
+	RxPower AlarmPowerThresholds
+	TxBias  AlarmThresholds
+	TxPower AlarmPowerThresholds
+}
+
+// ChannelMonitorMasks channel monitor interrupt masks of upper page 03h, see SFF-8636 rev 2.10a table 6-29
+type ChannelMonitorMasks struct {
+	RxPowerMask AlarmMask `json:"rxPowerMask"`
+	TxBiasMask  AlarmMask `json:"txBiasMask"`
+	TxPowerMask AlarmMask `json:"txPowerMask"`
+}
+
+// channelMonitorMaskFields selects the mask of a channel monitor, in the order of the mask bytes
+var channelMonitorMaskFields = []func(*ChannelMonitorMasks) *AlarmMask{
+	func(c *ChannelMonitorMasks) *AlarmMask { return &c.RxPowerMask },
+	func(c *ChannelMonitorMasks) *AlarmMask { return &c.TxBiasMask },
+	func(c *ChannelMonitorMasks) *AlarmMask { return &c.TxPowerMask },
+}
+
+// NewChannelMonitorMasks parses [6]byte into the channel monitor masks of all four channels.
+// Each monitor uses two bytes, the upper nibble of a byte covers the first, the lower nibble the second channel.
+func NewChannelMonitorMasks(raw [6]byte) [4]ChannelMonitorMasks {
+	masks := [4]ChannelMonitorMasks{}
+	for monitor, field := range channelMonitorMaskFields {
+		for channel := 0; channel < 4; channel++ {
+			nibble := raw[monitor*2+channel/2] >> (4 * uint(1-channel%2))
+			mask := field(&masks[channel])
+			mask.HighAlarmMask = nibble&(1<<3) > 0
+			mask.LowAlarmMask = nibble&(1<<2) > 0
+			mask.HighWarningMask = nibble&(1<<1) > 0
+			mask.LowWarningMask = nibble&(1<<0) > 0
+		}
+	}
+	return masks
+}
+
+// EncodeChannelMonitorMasks encodes the channel monitor masks of all four channels as parsed by NewChannelMonitorMasks
+func EncodeChannelMonitorMasks(masks [4]ChannelMonitorMasks) [6]byte {
+	raw := [6]byte{}
+	for monitor, field := range channelMonitorMaskFields {
+		for channel := 0; channel < 4; channel++ {
+			mask := field(&masks[channel])
+			nibble := byte(0)
+			eeprom.SetBits(&nibble, 1<<3, mask.HighAlarmMask)
+			eeprom.SetBits(&nibble, 1<<2, mask.LowAlarmMask)
+			eeprom.SetBits(&nibble, 1<<1, mask.HighWarningMask)
+			eeprom.SetBits(&nibble, 1<<0, mask.LowWarningMask)
+			raw[monitor*2+channel/2] |= nibble << (4 * uint(1-channel%2))
+		}
+	}
+	return raw
+}
+
+// GetChannelThresholds returns the thresholds applying to the given channel (0-3),
+// nil if the module does not provide upper page 03h
+func (e *EEPROM) GetChannelThresholds(channel int) *ChannelThresholds {
+	if e.Thresholds == nil || channel < 0 || channel > 3 {
+		return nil
+	}
+	return &ChannelThresholds{
+		RxPower: e.Thresholds.RxPower,
+		TxBias:  e.Thresholds.TxBias,
+		TxPower: e.Thresholds.TxPower,
+	}
+}
@@ -1,5 +1,11 @@
 package sff8636
 
+import (
+	"errors"
+	"fmt"
+	"github.com/wobcom/go-ethtool/eeprom"
+)
+
 // Control functions
 type Control struct {
 	ChannelControls      [4]ChannelControl
@@ -20,6 +26,29 @@ type ChannelControl struct {
 	RxCDR        bool
 }
 
+// ControlOffset offset of the control bytes 86-99 in the lower page
+const ControlOffset = controlOffset
+
+// ControlLength number of control bytes
+const ControlLength = 14
+
+// PowerControlOffset offset of the control byte holding software reset and power settings (byte 93)
+const PowerControlOffset = controlOffset + 0x07
+
+// Bits of the control byte at PowerControlOffset
+const (
+	// PowerControlSoftwareReset writing 1 initiates a software reset, self clearing
+	PowerControlSoftwareReset byte = 1 << 7
+	// PowerControlPowerClass8Enable enables power class 8 if implemented
+	PowerControlPowerClass8Enable byte = 1 << 3
+	// PowerControlPowerClass5To7Enable enables power classes 5 to 7 if implemented
+	PowerControlPowerClass5To7Enable byte = 1 << 2
+	// PowerControlLowPowerMode requests low power mode if PowerControlPowerOverride is set
+	PowerControlLowPowerMode byte = 1 << 1
+	// PowerControlPowerOverride lets PowerControlLowPowerMode override the LPMode pin
+	PowerControlPowerOverride byte = 1 << 0
+)
+
 // RateSelect used for software rate select
 type RateSelect struct {
 	MSB bool
@@ -93,3 +122,57 @@ func NewControl(raw [14]byte) *Control {
 	}
 	return c
 }
+
+// Encode writes the controls into the given control bytes (86-99) and returns them.
+// Reserved bits are kept as given, the self clearing software reset bit is always cleared.
+func (c *Control) Encode(raw [14]byte) [14]byte {
+	for channel, channelControl := range c.ChannelControls {
+		eeprom.SetBits(&raw[0x00], 1<<uint(channel), channelControl.TxDisable)
+		eeprom.SetBits(&raw[0x01], 1<<uint(2*channel+1), channelControl.RxRateSelect.MSB)
+		eeprom.SetBits(&raw[0x01], 1<<uint(2*channel), channelControl.RxRateSelect.LSB)
+		eeprom.SetBits(&raw[0x02], 1<<uint(2*channel+1), channelControl.TxRateSelect.MSB)
+		eeprom.SetBits(&raw[0x02], 1<<uint(2*channel), channelControl.TxRateSelect.LSB)
+		eeprom.SetBits(&raw[0x0C], 1<<uint(4+channel), channelControl.TxCDR)
+		eeprom.SetBits(&raw[0x0C], 1<<uint(channel), channelControl.RxCDR)
+	}
+	raw[0x07] &^= PowerControlSoftwareReset
+	eeprom.SetBits(&raw[0x07], 1<<3, c.PowerClass8Enable)
+	eeprom.SetBits(&raw[0x07], 1<<2, c.PowerClass5To7Enable)
+	eeprom.SetBits(&raw[0x07], 1<<1, c.LowPowerMode)
+	eeprom.SetBits(&raw[0x07], 1<<0, c.PowerOverride)
+	eeprom.SetBits(&raw[0x0D], 1<<1, c.LPModeTxDIS)
+	eeprom.SetBits(&raw[0x0D], 1<<0, c.IntlLOSL)
+	return raw
+}
+
+// ValidateControl checks that all changes of c compared to the module's current controls are implemented by the module
+func (e *EEPROM) ValidateControl(c *Control) error {
+	for channel := range c.ChannelControls {
+		current, requested := e.Control.ChannelControls[channel], c.ChannelControls[channel]
+		if current.TxDisable != requested.TxDisable && !e.Options.TxDisableImplemented {
+			return fmt.Errorf("Module does not implement Tx disable (channel %d)", channel+1)
+		}
+		if (current.RxRateSelect != requested.RxRateSelect || current.TxRateSelect != requested.TxRateSelect) && !e.Options.RateSelectMultiRateImplemented {
+			return fmt.Errorf("Module does not implement rate select (channel %d)", channel+1)
+		}
+		if current.TxCDR != requested.TxCDR && !e.Options.TxCDROnOffControlImplemented {
+			return fmt.Errorf("Module does not implement Tx CDR control (channel %d)", channel+1)
+		}
+		if current.RxCDR != requested.RxCDR && !e.Options.RxCDROnOffControlImplemented {
+			return fmt.Errorf("Module does not implement Rx CDR control (channel %d)", channel+1)
+		}
+	}
+	if e.Control.PowerClass8Enable != c.PowerClass8Enable && !e.ExtendedIdentifier.PowerClass8Implemented {
+		return errors.New("Module does not implement power class 8")
+	}
+	if e.Control.PowerClass5To7Enable != c.PowerClass5To7Enable && e.ExtendedIdentifier.PowerClass < eeprom.PowerClass5 {
+		return fmt.Errorf("Module does not implement power classes 5 to 7, it advertises %s", e.ExtendedIdentifier.PowerClass)
+	}
+	if e.Control.LPModeTxDIS != c.LPModeTxDIS && !e.Options.LPModeTxDisConfigurable {
+		return errors.New("Module does not allow configuring LPMode/TxDis")
+	}
+	if e.Control.IntlLOSL != c.IntlLOSL && !e.Options.IntLRxLOSLConfigurable {
+		return errors.New("Module does not allow configuring IntL/RxLOSL")
+	}
+	return nil
+}
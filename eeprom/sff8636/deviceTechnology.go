@@ -3,6 +3,7 @@ package sff8636
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/wobcom/go-ethtool/eeprom"
 )
 
 // DeviceTechnology aspects of the device or cable technology used
@@ -91,3 +92,13 @@ func NewDeviceTechnology(raw byte) *DeviceTechnology {
 		TransmitterTechnology: TransmitterTechnology((raw & 0b11110000) >> 4),
 	}
 }
+
+// Encode encodes the device technology into its byte as parsed by NewDeviceTechnology
+func (d *DeviceTechnology) Encode() byte {
+	raw := byte(d.TransmitterTechnology&0b1111) << 4
+	eeprom.SetBits(&raw, 1<<3, d.WavelengthControl)
+	eeprom.SetBits(&raw, 1<<2, d.CooledTransmitter)
+	eeprom.SetBits(&raw, 1<<1, d.APDDetector)
+	eeprom.SetBits(&raw, 1<<0, d.TransmitterTunable)
+	return raw
+}
@@ -1,5 +1,7 @@
 package sff8636
 
+import "github.com/wobcom/go-ethtool/eeprom"
+
 // DiagnosticMonitoringType indicators describing how diagnostic monitoring is implemented
 type DiagnosticMonitoringType struct {
 	TemperatureMonitoringImplemented     bool
@@ -27,3 +29,13 @@ func NewDiagnosticMonitoringType(raw byte) *DiagnosticMonitoringType {
 		TransmitterPowerMeasurementSupported: raw&(1<<2) > 0,
 	}
 }
+
+// Encode encodes the diagnostic monitoring type into its byte as parsed by NewDiagnosticMonitoringType
+func (d *DiagnosticMonitoringType) Encode() byte {
+	raw := byte(0)
+	eeprom.SetBits(&raw, 1<<5, d.TemperatureMonitoringImplemented)
+	eeprom.SetBits(&raw, 1<<4, d.SupplyVoltageMonitoringImplemented)
+	eeprom.SetBits(&raw, 1<<3, bool(d.ReceivedPowerMeasurementsType))
+	eeprom.SetBits(&raw, 1<<2, d.TransmitterPowerMeasurementSupported)
+	return raw
+}
@@ -1,9 +1,11 @@
 package sff8636
 
 import (
+	"bytes"
 	"errors"
 	"github.com/wobcom/go-ethtool/eeprom"
 	"github.com/wobcom/go-ethtool/eeprom/sff8024"
+	"math"
 	"strings"
 )
 
@@ -153,6 +155,9 @@ const (
 	// Note: The standard does not specify how to parse this field
 	maxCaseTemperatureOffset = 0xBE
 
+	// Check code for the base ID fields (bytes 128-190)
+	baseChecksumOffset = 0xBF
+
 	// Extended Specification Compliance Codes (See SFF-
 	// 8024)
 	linkCodesOffset = 0xC0
@@ -178,9 +183,22 @@ const (
 	// 6-24.
 	enhancedOptionsOffset = 0xDD
 
+	// Check code for the extended ID fields (bytes 192-222)
+	extendedChecksumOffset = 0xDF
+
+	/* Upper Page 01h (Optional) */
+	// Application Select Table
+	applicationSelectTableOffset = 0x100
+
+	/* Upper Page 02h (Optional) */
+	// User EEPROM (NVRAM)
+	userEEPROMOffset = 0x180
+
 	/* Upper Page 03h (Optional) */
 	// Free Side Device Thresholds
 	thresholdsOffset = 0x200
+	// Channel monitor masks (bytes 242-247)
+	channelMonitorMasksOffset = 0x272
 )
 
 // EEPROM implementation is based on SFF-8636 Rev 2.10a
@@ -229,12 +247,58 @@ type EEPROM struct {
 	DiagnosticMonitoringType        *DiagnosticMonitoringType
 	EnhancedOptions                 *EnhancedOptions
 
+	/* Upper Page 01h (optional) */
+	ApplicationSelectTable *ApplicationSelectTable
+
+	/* Upper Page 02h (optional) */
+	// UserEEPROM nil if the page is not provided or read erased
+	UserEEPROM []byte
+
 	/* Upper Page 03h (optional) */
-	Thresholds *Thresholds
+	Thresholds          *Thresholds
+	ChannelMonitorMasks *[4]ChannelMonitorMasks
+
+	// Checksum errors found while parsing leniently
+	ChecksumErrors []*eeprom.ChecksumError
+	// Anomalies found while parsing, the affected fields have been decoded on a best-effort basis
+	ParseWarnings []eeprom.ParseWarning
+	// Names of the quirks applied after parsing, see eeprom.ApplyQuirks
+	AppliedQuirks []string
+	// Diagnostics reported as not available due to a quirk
+	IgnoredDiagnostics map[eeprom.Diagnostic]bool
+}
+
+// stringFields ASCII fields checked for anomalies while parsing
+var stringFields = []eeprom.StringField{
+	{Name: "VendorName", Start: vendorNameStartOffset, End: vendorNameEndOffset},
+	{Name: "VendorPN", Start: vendorPnStartOffset, End: vendorPnEndOffset},
+	{Name: "VendorRev", Start: vendorRevStartOffset, End: vendorRevEndOffset},
+	{Name: "VendorSN", Start: vendorSnStartOffset, End: vendorSnEndOffset},
 }
 
-// NewEEPROM parses a byte slice of at least length 512 into a new EEPROM instance
+func init() {
+	eeprom.RegisterDecoder(eeprom.TypeSFF8636, decode)
+	eeprom.RegisterDecoder(eeprom.TypeSFF8436, decode)
+}
+
+// decode parses raw into an eeprom.EEPROM, registered with eeprom.RegisterDecoder
+func decode(raw []byte, options *eeprom.ParseOptions) (eeprom.EEPROM, error) {
+	e, err := NewEEPROMWithOptions(raw, options)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// NewEEPROM parses a byte slice of at least length 512 into a new EEPROM instance,
+// checksum errors are recorded in ChecksumErrors
 func NewEEPROM(raw []byte) (*EEPROM, error) {
+	return NewEEPROMWithOptions(raw, nil)
+}
+
+// NewEEPROMWithOptions parses a byte slice of at least length 512 into a new EEPROM instance,
+// failing on checksum errors if options are strict
+func NewEEPROMWithOptions(raw []byte, options *eeprom.ParseOptions) (*EEPROM, error) {
 	if len(raw) < 512 {
 		return nil, errors.New("SFF-8636 requires EEPROM to be at least of 512 bytes length")
 	}
@@ -427,7 +491,37 @@ func NewEEPROM(raw []byte) (*EEPROM, error) {
 		DiagnosticMonitoringType: NewDiagnosticMonitoringType(raw[diagnosticMonitoringTypeOffset]),
 		EnhancedOptions:          NewEnhancedOptions(raw[enhancedOptionsOffset]),
 	}
+	e.ParseWarnings = eeprom.CheckStringFields(raw, stringFields)
+	if warning := eeprom.CheckDateCode("DateCode", vendorDateCodeStartOffset, raw[vendorDateCodeStartOffset:vendorDateCodeEndOffset+1]); warning != nil {
+		e.ParseWarnings = append(e.ParseWarnings, *warning)
+	}
+
+	if err := eeprom.CheckChecksum(&e.ChecksumErrors, "CC_BASE (page 00h bytes 128-190)", raw[identifierOffset1:baseChecksumOffset], raw[baseChecksumOffset], options); err != nil {
+		return nil, err
+	}
+	if err := eeprom.CheckChecksum(&e.ChecksumErrors, "CC_EXT (page 00h bytes 192-222)", raw[linkCodesOffset:extendedChecksumOffset], raw[extendedChecksumOffset], options); err != nil {
+		return nil, err
+	}
+
+	/* Upper Page 01h (Optional) */
+	// drivers not reading the page return it erased
+	if e.Options.MemoryPage01hProvided && len(raw) >= applicationSelectTableOffset+0x80 && !isErased(raw[applicationSelectTableOffset:applicationSelectTableOffset+0x80]) {
+		if err := eeprom.CheckChecksum(&e.ChecksumErrors, "CC_APPS (page 01h bytes 129-255)", raw[applicationSelectTableOffset+1:applicationSelectTableOffset+0x80], raw[applicationSelectTableOffset], options); err != nil {
+			return nil, err
+		}
+		e.ApplicationSelectTable = NewApplicationSelectTable(*(*[128]byte)(raw[applicationSelectTableOffset : applicationSelectTableOffset+0x80]))
+	}
+	/* Upper Page 02h (Optional) */
+	// like page 01h, an erased page is treated as not read
+	if e.Options.MemoryPage02hProvided && len(raw) >= userEEPROMOffset+0x80 && !isErased(raw[userEEPROMOffset:userEEPROMOffset+0x80]) {
+		e.UserEEPROM = make([]byte, 0x80)
+		copy(e.UserEEPROM, raw[userEEPROMOffset:userEEPROMOffset+0x80])
+	}
 	/* Upper Page 03h (Optional) */
+	if len(raw) >= channelMonitorMasksOffset+6 {
+		masks := NewChannelMonitorMasks(*(*[6]byte)(raw[channelMonitorMasksOffset : channelMonitorMasksOffset+6]))
+		e.ChannelMonitorMasks = &masks
+	}
 	if len(raw) >= 0x248 {
 		e.Thresholds = NewThresholds([72]byte{
 			raw[thresholdsOffset+0],
@@ -516,3 +610,154 @@ func NewEEPROM(raw []byte) (*EEPROM, error) {
 
 	return e, nil
 }
+
+// Encode encodes the EEPROM into a byte image as parsed by NewEEPROM, including valid check codes.
+// The image covers the lower page and upper pages 00h-02h (512 bytes), plus upper page 03h (640 bytes)
+// if Thresholds or ChannelMonitorMasks are set. Upper pages 01h and 02h are only parsed if advertised in Options.
+func (e *EEPROM) Encode() ([]byte, error) {
+	size := 512
+	if e.Thresholds != nil || e.ChannelMonitorMasks != nil {
+		size = 640
+	}
+	raw := make([]byte, size)
+
+	/* Lower Page */
+	raw[identifierOffset] = byte(e.Identifier)
+	if e.StatusIndicators != nil {
+		statusIndicators := e.StatusIndicators.Encode()
+		copy(raw[statusIndicatorsOffset:], statusIndicators[:])
+	}
+	if e.InterruptFlags != nil {
+		interruptFlags := e.InterruptFlags.Encode()
+		copy(raw[interruptFlagsOffset:], interruptFlags[:])
+	}
+	if e.FreeSideMonitors != nil {
+		freeSideMonitors := e.FreeSideMonitors.Encode()
+		copy(raw[freeSideDeviceMonitorsOffset:], freeSideMonitors[:])
+	}
+	if e.ChannelMonitors != nil {
+		channelMonitors := e.ChannelMonitors.Encode()
+		copy(raw[channelMonitorsOffset:], channelMonitors[:])
+	}
+	if e.Control != nil {
+		control := e.Control.Encode([ControlLength]byte{})
+		copy(raw[controlOffset:], control[:])
+	}
+	if e.InterruptMasks != nil {
+		interruptMasks := e.InterruptMasks.Encode()
+		copy(raw[freeSideInterruptMasksOffset:], interruptMasks[:])
+	}
+	if e.FreeSideDeviceProperties != nil {
+		freeSideDeviceProperties := e.FreeSideDeviceProperties.Encode()
+		copy(raw[freeSideDevicePropertiesOffset:], freeSideDeviceProperties[:])
+	}
+
+	/* Upper Page 00h */
+	raw[identifierOffset1] = byte(e.Identifier1)
+	if e.ExtendedIdentifier != nil {
+		raw[extendedIdentifierOffset] = e.ExtendedIdentifier.Encode()
+	}
+	raw[connectorTypeOffset] = byte(e.ConnectorType)
+	encodedSpecificationCompliance := e.SpecificationCompliance.Encode()
+	copy(raw[specificationCompliance:], encodedSpecificationCompliance[:])
+	raw[encodingOffset] = byte(e.Encoding)
+	// signaling rates above 25.4 GBd are encoded in units of 250 MBd
+	if e.SignalingRate > 0xFE*100*1000000 {
+		raw[signalingRateOffset] = 0xFF
+		raw[signalingRateExtendedOffset] = encodeLength(e.SignalingRate, 250*1000000)
+	} else {
+		raw[signalingRateOffset] = encodeLength(e.SignalingRate, 100*1000000)
+	}
+	raw[extendedRateSelectOffset] = byte(e.ExtendedRateSelectCompliance)
+	raw[lengthSmfOffset] = encodeLength(e.LengthSMF, 1000)
+	raw[lengthOM3Offset] = encodeLength(e.LengthOM3, 2)
+	raw[lengthOM2Offset] = encodeLength(e.LengthOM2, 1)
+	raw[lengthOM1offset] = encodeLength(e.LengthOM1, 1)
+	raw[lengthOM4orActivePassiveCableOffset] = encodeLength(e.LengthOM4ActiveOrPassiveCable, 1)
+	if e.DeviceTechnology != nil {
+		raw[deviceTechnologyOffset] = e.DeviceTechnology.Encode()
+	}
+	for _, field := range []struct {
+		name  string
+		raw   []byte
+		value string
+	}{
+		{"VendorName", raw[vendorNameStartOffset : vendorNameEndOffset+1], e.VendorName},
+		{"VendorPN", raw[vendorPnStartOffset : vendorPnEndOffset+1], e.VendorPN},
+		{"VendorRev", raw[vendorRevStartOffset : vendorRevEndOffset+1], e.VendorRev},
+		{"VendorSN", raw[vendorSnStartOffset : vendorSnEndOffset+1], e.VendorSN},
+		{"DateCode", raw[vendorDateCodeStartOffset : vendorDateCodeEndOffset+1], e.DateCode},
+	} {
+		if err := eeprom.PutString(field.name, field.raw, field.value); err != nil {
+			return nil, err
+		}
+	}
+	if e.ExtendedModuleCodeValues != nil {
+		raw[extendedModuleOffset] = e.ExtendedModuleCodeValues.Encode()
+	}
+	oui := e.VendorOUI.Encode()
+	copy(raw[vendorOuiOffset:], oui[:])
+	if e.SpecificationCompliance.IsNonOpticalImplementation() {
+		raw[copperAttenuation2dot5GHzOffset] = e.CopperAttenuation2_5GHz
+		raw[copperAttenuation5GHzOffset] = e.CopperAttenuation5GHz
+		raw[copperAttenuation7GHzOffset] = e.CopperAttenuation7GHz
+		raw[copperAttenuation12dot9GHzOffset] = e.CopperAttenuation12_9GHz
+	} else {
+		wavelength, wavelengthTolerance := encodeWavelength(e.Wavelength), encodeWavelengthTolerance(e.WavelengthTolerance)
+		copy(raw[wavelengthOffset:], wavelength[:])
+		copy(raw[wavelengthToleranceOffset:], wavelengthTolerance[:])
+	}
+	raw[maxCaseTemperatureOffset] = e.MaxCaseTemperature
+	raw[linkCodesOffset] = byte(e.ExtendedSpecificationCompliance)
+	if e.Options != nil {
+		options := e.Options.Encode()
+		copy(raw[optionsOffset:], options[:])
+	}
+	if e.DiagnosticMonitoringType != nil {
+		raw[diagnosticMonitoringTypeOffset] = e.DiagnosticMonitoringType.Encode()
+	}
+	if e.EnhancedOptions != nil {
+		raw[enhancedOptionsOffset] = e.EnhancedOptions.Encode()
+	}
+	raw[baseChecksumOffset] = eeprom.Checksum(raw[identifierOffset1:baseChecksumOffset])
+	raw[extendedChecksumOffset] = eeprom.Checksum(raw[linkCodesOffset:extendedChecksumOffset])
+
+	/* Upper Page 01h (optional) */
+	if e.ApplicationSelectTable != nil {
+		applicationSelectTable, err := e.ApplicationSelectTable.Encode()
+		if err != nil {
+			return nil, err
+		}
+		copy(raw[applicationSelectTableOffset:], applicationSelectTable[:])
+	} else if e.Options != nil && e.Options.MemoryPage01hProvided {
+		// an advertised page without table is encoded erased, as returned by drivers not reading it
+		copy(raw[applicationSelectTableOffset:], bytes.Repeat([]byte{0xFF}, 0x80))
+	}
+	/* Upper Page 02h (optional) */
+	if e.UserEEPROM != nil {
+		copy(raw[userEEPROMOffset:userEEPROMOffset+0x80], e.UserEEPROM)
+	} else if e.Options != nil && e.Options.MemoryPage02hProvided {
+		copy(raw[userEEPROMOffset:], bytes.Repeat([]byte{0xFF}, 0x80))
+	}
+	/* Upper Page 03h (optional) */
+	if e.Thresholds != nil {
+		thresholds := e.Thresholds.Encode()
+		copy(raw[thresholdsOffset:], thresholds[:])
+	}
+	if e.ChannelMonitorMasks != nil {
+		channelMonitorMasks := EncodeChannelMonitorMasks(*e.ChannelMonitorMasks)
+		copy(raw[channelMonitorMasksOffset:], channelMonitorMasks[:])
+	}
+
+	return raw, nil
+}
+
+// encodeLength encodes a length (or rate) given in units of unit, clamped to a byte
+func encodeLength(length int, unit int) byte {
+	return byte(math.Max(0, math.Min(0xFF, math.Round(float64(length)/float64(unit)))))
+}
+
+// isErased returns true if all bytes of page are 0xFF
+func isErased(page []byte) bool {
+	return bytes.Count(page, []byte{0xFF}) == len(page)
+}
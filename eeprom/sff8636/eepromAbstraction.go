@@ -3,7 +3,6 @@ package sff8636
 import (
 	"github.com/wobcom/go-ethtool/eeprom"
 	"github.com/wobcom/go-ethtool/eeprom/sff8024"
-	"strings"
 	"time"
 )
 
@@ -75,11 +74,20 @@ func (e *EEPROM) GetVendorOUI() eeprom.OUI {
 
 // GetDateCode implements eeprom.EEPROM interface's GetDateCode function
 func (e *EEPROM) GetDateCode() time.Time {
-	dateCode := strings.Trim(e.DateCode, " ")
-	t, _ := time.Parse("060102", dateCode)
+	t, _ := eeprom.ParseDateCode(e.DateCode)
 	return t
 }
 
+// GetParseWarnings implements eeprom.EEPROM interface's GetParseWarnings function
+func (e *EEPROM) GetParseWarnings() []eeprom.ParseWarning {
+	return e.ParseWarnings
+}
+
+// GetChecksumErrors implements eeprom.EEPROM interface's GetChecksumErrors function
+func (e *EEPROM) GetChecksumErrors() []*eeprom.ChecksumError {
+	return e.ChecksumErrors
+}
+
 // GetWavelength implements eeprom.EEPROM interface's GetWavelength function
 func (e *EEPROM) GetWavelength() float64 {
 	return e.Wavelength
@@ -92,10 +100,14 @@ func (e *EEPROM) SupportsMonitoring() bool {
 
 // GetModuleTemperature implements eeprom.EEPROM interface's GetModuleTemperature function
 func (e *EEPROM) GetModuleTemperature() (eeprom.Measurement, error) {
+	if e.IgnoredDiagnostics[eeprom.DiagnosticTemperature] {
+		return nil, eeprom.IgnoredDiagnosticError(eeprom.DiagnosticTemperature)
+	}
 	m := &Measurement{
 		Value:               e.FreeSideMonitors.Temperature,
 		Unit:                "degrees celsius",
 		ThresholdsSupported: e.Thresholds != nil,
+		Flags:               &e.InterruptFlags.FreeSideInterruptFlags.TemperatureAlarm,
 	}
 
 	if e.Thresholds != nil {
@@ -111,10 +123,14 @@ func (e *EEPROM) GetModuleTemperature() (eeprom.Measurement, error) {
 
 // GetModuleVoltage implements eeprom.EEPROM interface's GetModuleVoltage function
 func (e *EEPROM) GetModuleVoltage() (eeprom.Measurement, error) {
+	if e.IgnoredDiagnostics[eeprom.DiagnosticVoltage] {
+		return nil, eeprom.IgnoredDiagnosticError(eeprom.DiagnosticVoltage)
+	}
 	m := &Measurement{
 		Value:               e.FreeSideMonitors.SupplyVoltage,
 		Unit:                "volts",
 		ThresholdsSupported: e.Thresholds != nil,
+		Flags:               &e.InterruptFlags.FreeSideInterruptFlags.VoltageAlarm,
 	}
 
 	if e.Thresholds != nil {
@@ -3,6 +3,8 @@ package sff8636
 import (
 	"encoding/hex"
 	"encoding/json"
+	"github.com/wobcom/go-ethtool/eeprom"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -117,3 +119,189 @@ func TestJsonMarshal(t *testing.T) {
 		t.Error(err.Error())
 	}
 }
+
+func TestParseOptionalPages(t *testing.T) {
+	rawData := make([]byte, 640)
+	// advertise upper pages 01h and 02h
+	rawData[optionsOffset+2] |= 0xC0
+	// application select table with two entries
+	copy(rawData[applicationSelectTableOffset:], []byte{0x5A, 0x01, 0x02, 0x10, 0x43, 0x20})
+	rawData[userEEPROMOffset] = 0x42
+	// Rx power high alarm of channel 2 and Tx power low warning of channel 4 masked
+	rawData[channelMonitorMasksOffset] = 0x08
+	rawData[channelMonitorMasksOffset+5] = 0x01
+	// Tx bias high alarm threshold of 75 mA
+	rawData[thresholdsOffset+0x38] = 0x92
+	rawData[thresholdsOffset+0x39] = 0x7C
+
+	eeprom, err := NewEEPROM(rawData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eeprom.ApplicationSelectTable == nil {
+		t.Fatal("eeprom.ApplicationSelectTable is nil")
+	}
+	assertInt(t, int(eeprom.ApplicationSelectTable.CheckCode), 0x5A, "eeprom.ApplicationSelectTable.CheckCode")
+	assertInt(t, len(eeprom.ApplicationSelectTable.Entries), 2, "len(eeprom.ApplicationSelectTable.Entries)")
+	assertInt(t, int(eeprom.ApplicationSelectTable.Entries[1].ApplicationCode), 0x03, "Entries[1].ApplicationCode")
+	assertInt(t, int(eeprom.ApplicationSelectTable.Entries[1].ExtendedRateSelect), 0x20, "Entries[1].ExtendedRateSelect")
+	assertInt(t, len(eeprom.UserEEPROM), 128, "len(eeprom.UserEEPROM)")
+	assertInt(t, int(eeprom.UserEEPROM[0]), 0x42, "eeprom.UserEEPROM[0]")
+
+	masks := eeprom.ChannelMonitorMasks
+	assertBool(t, masks[1].RxPowerMask.HighAlarmMask, true, "masks[1].RxPowerMask.HighAlarmMask")
+	assertBool(t, masks[0].RxPowerMask.HighAlarmMask, false, "masks[0].RxPowerMask.HighAlarmMask")
+	assertBool(t, masks[3].TxPowerMask.LowWarningMask, true, "masks[3].TxPowerMask.LowWarningMask")
+	assertBool(t, masks[2].TxPowerMask.LowWarningMask, false, "masks[2].TxPowerMask.LowWarningMask")
+
+	thresholds := eeprom.GetChannelThresholds(3)
+	assertFloat64(t, thresholds.TxBias.HighAlarm, 75, "thresholds.TxBias.HighAlarm")
+	if eeprom.GetChannelThresholds(4) != nil {
+		t.Error("eeprom.GetChannelThresholds(4) returned thresholds for a non-existing channel")
+	}
+}
+
+func TestEncodeControl(t *testing.T) {
+	raw := [14]byte{0x00, 0x00, 0x00, 0x5A, 0x00, 0x00, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
+	control := NewControl(raw)
+	control.ChannelControls[2].TxDisable = true
+	control.ChannelControls[1].RxRateSelect.MSB = true
+	control.ChannelControls[3].TxCDR = true
+	control.PowerOverride = true
+
+	encoded := control.Encode(raw)
+	assertInt(t, int(encoded[0x00]), 0x04, "encoded[0x00]")
+	assertInt(t, int(encoded[0x01]), 0x08, "encoded[0x01]")
+	// reserved bytes are kept, software reset is cleared
+	assertInt(t, int(encoded[0x03]), 0x5A, "encoded[0x03]")
+	assertInt(t, int(encoded[0x07]), 0x01, "encoded[0x07]")
+	assertInt(t, int(encoded[0x0C]), 0x80, "encoded[0x0C]")
+	if *NewControl(encoded) != *control {
+		t.Error("NewControl(control.Encode()) does not match control")
+	}
+
+	eeprom := getEEPROM(t)
+	requested := *eeprom.Control
+	requested.ChannelControls[0].TxDisable = !requested.ChannelControls[0].TxDisable
+	if err := eeprom.ValidateControl(&requested); (err == nil) != eeprom.Options.TxDisableImplemented {
+		t.Errorf("eeprom.ValidateControl returned %v, but TxDisableImplemented is %t", err, eeprom.Options.TxDisableImplemented)
+	}
+}
+
+func TestAlarmAndLaneFlags(t *testing.T) {
+	rawData := make([]byte, 640)
+	// Rx LOS of channel 2, Rx power high alarm of channel 1, temperature low warning
+	rawData[interruptFlagsOffset+0x00] = 0x02
+	rawData[interruptFlagsOffset+0x06] = 0x80
+	rawData[interruptFlagsOffset+0x03] = 0x10
+
+	eeprom, err := NewEEPROM(rawData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lasers := eeprom.GetLasers()
+	laneFlags, err := lasers[1].GetLaneFlags()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertBool(t, laneFlags.GetRxLOS(), true, "lasers[1].GetLaneFlags().GetRxLOS")
+	assertBool(t, laneFlags.GetTxFault(), false, "lasers[1].GetLaneFlags().GetTxFault")
+
+	rxPower, _ := lasers[0].GetRxPower()
+	flags, err := rxPower.GetAlarmFlags()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertBool(t, flags.GetHighAlarm(), true, "rxPower.GetAlarmFlags().GetHighAlarm")
+	assertBool(t, flags.GetLowWarning(), false, "rxPower.GetAlarmFlags().GetLowWarning")
+
+	temperature, _ := eeprom.GetModuleTemperature()
+	flags, err = temperature.GetAlarmFlags()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertBool(t, flags.GetLowWarning(), true, "temperature.GetAlarmFlags().GetLowWarning")
+}
+
+func TestChecksumValidation(t *testing.T) {
+	for i, valid := range []*EEPROM{getEEPROM(t), getEEPROM1(t)} {
+		if len(valid.ChecksumErrors) != 0 {
+			t.Errorf("EEPROM %d: Unexpected checksum errors %v", i, valid.ChecksumErrors)
+		}
+	}
+
+	rawData := make([]byte, 640)
+	rawData[identifierOffset1] = 0x11
+	rawData[baseChecksumOffset] = 0x11
+	// corrupt the extended ID fields
+	rawData[extendedChecksumOffset-1] = 0x01
+
+	lenient, err := NewEEPROM(rawData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lenient.ChecksumErrors) != 1 {
+		t.Fatalf("Expected 1 checksum error, got %d", len(lenient.ChecksumErrors))
+	}
+	checksumError := lenient.ChecksumErrors[0]
+	if checksumError.Expected != 0x00 || checksumError.Actual != 0x01 || !checksumError.Continued {
+		t.Errorf("Unexpected checksum error: %v", checksumError)
+	}
+
+	_, err = NewEEPROMWithOptions(rawData, &eeprom.ParseOptions{Strict: true})
+	if checksumError, ok := err.(*eeprom.ChecksumError); !ok || checksumError.Continued {
+		t.Errorf("Expected aborting checksum error, got %v", err)
+	}
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	optionalPages := make([]byte, 640)
+	optionalPages[optionsOffset+2] |= 0xC0
+	copy(optionalPages[applicationSelectTableOffset:], []byte{0x00, 0x01, 0x02, 0x10, 0x43, 0x20})
+	optionalPages[userEEPROMOffset] = 0x42
+	optionalPages[channelMonitorMasksOffset] = 0x08
+	optionalPages[thresholdsOffset+0x38] = 0x92
+	optionalPages[thresholdsOffset+0x39] = 0x7C
+	copy(optionalPages[vendorDateCodeStartOffset:], "230101  ")
+	optionalPagesEEPROM, err := NewEEPROM(optionalPages)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, original := range []*EEPROM{getEEPROM(t), getEEPROM1(t), optionalPagesEEPROM} {
+		raw, err := original.Encode()
+		if err != nil {
+			t.Fatalf("EEPROM %d: Encode failed: %v", i, err)
+		}
+		decoded, err := NewEEPROMWithOptions(raw, &eeprom.ParseOptions{Strict: true})
+		if err != nil {
+			t.Fatalf("EEPROM %d: Decoding encoded EEPROM failed: %v", i, err)
+		}
+		original.ChecksumErrors, decoded.ChecksumErrors = nil, nil
+		// the check code of the application select table is recomputed while encoding
+		if original.ApplicationSelectTable != nil && decoded.ApplicationSelectTable != nil {
+			original.ApplicationSelectTable.CheckCode = decoded.ApplicationSelectTable.CheckCode
+		}
+		original.ParseWarnings, decoded.ParseWarnings = nil, nil
+		if !reflect.DeepEqual(original, decoded) {
+			t.Errorf("EEPROM %d: Round trip mismatch:\n%+v\n%+v", i, original, decoded)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	hexRaw := "110702000000000000000000000000000000000000001c97000081b9000000000000436a31c82b822ed64340414047c045402ccc2d91302c2f9d0000000000000000000000000000000000000000000000000000000000000000000000010000000000000000000000001f00000000000000000000000000000000000000000011cc07800000000000000005ff0002000000004446532020202020202020202020202020000002c95153465032382d4952342d3130304720413165bf00ce00fb0307ffde4331383132313535343631202020202031393031313020200c1068bc0000000000000000000000000000000000000000000000000000000014320000ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000005000f6004b00fb000000000000000000908871708c7075480000000000000000000000000000000000000000000000006e18016357730232927c138888b81d4c6e170584577306f20000000000000000000000000000000000000000000000000000000000000000000000000077111100000000000000000000000000000000"
+	rawData, err := hex.DecodeString(hexRaw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// detected from the identifier despite the wrong type reported
+	decoded, err := eeprom.Decode(rawData, eeprom.TypeSFF8472)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := decoded.(*EEPROM); !ok {
+		t.Fatalf("Expected SFF-8636 EEPROM, got %T", decoded)
+	}
+	assertString(t, decoded.GetVendorPN(), "QSFP28-IR4-100G", "decoded.GetVendorPN()")
+}
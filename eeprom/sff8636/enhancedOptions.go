@@ -1,5 +1,7 @@
 package sff8636
 
+import "github.com/wobcom/go-ethtool/eeprom"
+
 // EnhancedOptions as of SFF-8636 rev 2.10a table 6-24
 type EnhancedOptions struct {
 	InitializationCompleteFlag bool
@@ -17,3 +19,13 @@ func NewEnhancedOptions(raw byte) *EnhancedOptions {
 		SoftwareResetImplemented:   raw&(1<<0) > 0,
 	}
 }
+
+// Encode encodes the enhanced options into their byte as parsed by NewEnhancedOptions
+func (e *EnhancedOptions) Encode() byte {
+	raw := byte(0)
+	eeprom.SetBits(&raw, 1<<4, e.InitializationCompleteFlag)
+	eeprom.SetBits(&raw, 1<<3, e.RateSelectImplemented)
+	eeprom.SetBits(&raw, 1<<1, e.TCReadinessImplemented)
+	eeprom.SetBits(&raw, 1<<0, e.SoftwareResetImplemented)
+	return raw
+}
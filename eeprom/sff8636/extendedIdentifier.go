@@ -44,3 +44,25 @@ func NewExtendedIdentifier(raw byte) *ExtendedIdentifier {
 		RxCDRPresent:           raw&(1<<2) > 0,
 	}
 }
+
+// encodePowerClass is the inverse of parsePowerClass, power class 8 is indicated by PowerClass8Implemented only
+func encodePowerClass(powerClass eeprom.PowerClass) byte {
+	return map[eeprom.PowerClass]byte{
+		eeprom.PowerClass2: 0b01000000,
+		eeprom.PowerClass3: 0b10000000,
+		eeprom.PowerClass4: 0b11000000,
+		eeprom.PowerClass5: 0b11000001,
+		eeprom.PowerClass6: 0b11000010,
+		eeprom.PowerClass7: 0b11000011,
+	}[powerClass]
+}
+
+// Encode encodes the extended identifier into its byte as parsed by NewExtendedIdentifier
+func (e *ExtendedIdentifier) Encode() byte {
+	raw := encodePowerClass(e.PowerClass)
+	eeprom.SetBits(&raw, 1<<5, e.PowerClass8Implemented)
+	eeprom.SetBits(&raw, 1<<4, e.CLEICodePresent)
+	eeprom.SetBits(&raw, 1<<3, e.TxCDRPresent)
+	eeprom.SetBits(&raw, 1<<2, e.RxCDRPresent)
+	return raw
+}
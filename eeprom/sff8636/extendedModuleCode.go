@@ -1,5 +1,7 @@
 package sff8636
 
+import "github.com/wobcom/go-ethtool/eeprom"
+
 // ExtendedModuleCodeValues maps a ExtendedModuleCode to a bool to indicate support of a electronic or optical interface for InfiniBand
 type ExtendedModuleCodeValues map[ExtendedModuleCode]bool
 
@@ -39,3 +41,12 @@ func NewExtendedModuleCodeValues(raw byte) *ExtendedModuleCodeValues {
 	}
 	return &e
 }
+
+// Encode encodes the extended module code values into their byte as parsed by NewExtendedModuleCodeValues
+func (e ExtendedModuleCodeValues) Encode() byte {
+	raw := byte(0)
+	for bitIndex, extendedModuleCode := range extendedModuleCodeMemoryMap {
+		eeprom.SetBits(&raw, 1<<bitIndex, e[extendedModuleCode])
+	}
+	return raw
+}
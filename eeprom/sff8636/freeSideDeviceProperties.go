@@ -1,5 +1,10 @@
 package sff8636
 
+import (
+	"github.com/wobcom/go-ethtool/eeprom"
+	"math"
+)
+
 // FreeSideDeviceProperties as defined in SFF-8636 rev 2.10a Table 6-13
 type FreeSideDeviceProperties struct {
 	MaxPowerConsumption   float64
@@ -111,3 +116,31 @@ func NewFreeSideDeviceProperties(raw [10]byte) *FreeSideDeviceProperties {
 		NearEndImplementation: parseNearEndImplementation(raw[6] & 0b000001111),
 	}
 }
+
+// encodeMinOperatingVoltage is the inverse of parseMinOperatingVoltage, unknown voltages are encoded as reserved
+func encodeMinOperatingVoltage(voltage float64) byte {
+	code, found := map[float64]byte{
+		3.3: 0b000,
+		2.5: 0b001,
+		1.8: 0b010,
+	}[voltage]
+	if !found {
+		return 0b011
+	}
+	return code
+}
+
+// Encode encodes the free side device properties into their [10]byte representation as parsed by NewFreeSideDeviceProperties
+func (f *FreeSideDeviceProperties) Encode() [10]byte {
+	raw := [10]byte{}
+	raw[0] = byte(math.Max(0, math.Min(0xFF, math.Round(f.MaxPowerConsumption/0.1))))
+	propagationDelay := encodeUint16(f.PropagationDelay / 10)
+	copy(raw[1:], propagationDelay[:])
+	raw[3] = byte(f.AdvancedLowPowerMode&0b1111)<<4 | encodeMinOperatingVoltage(f.MinOperatingVoltage)
+	eeprom.SetBits(&raw[3], 1<<3, f.FarSideManaged)
+	raw[6] = byte(f.FarEndImplementation&0b111) << 4
+	for channel, implemented := range f.NearEndImplementation.ChannelImplemented {
+		eeprom.SetBits(&raw[6], 1<<uint(channel), implemented)
+	}
+	return raw
+}
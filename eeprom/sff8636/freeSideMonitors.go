@@ -27,3 +27,12 @@ func NewFreeSideMonitors(raw [12]byte) *FreeSideMonitors {
 	}
 	return f
 }
+
+// Encode encodes the free side monitors into their [12]byte representation as parsed by NewFreeSideMonitors
+func (f *FreeSideMonitors) Encode() [12]byte {
+	raw := [12]byte{}
+	temperature, supplyVoltage := encodeTemperature(f.Temperature), encodeVoltage(f.SupplyVoltage)
+	copy(raw[0x00:], temperature[:])
+	copy(raw[0x04:], supplyVoltage[:])
+	return raw
+}
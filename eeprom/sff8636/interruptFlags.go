@@ -1,5 +1,7 @@
 package sff8636
 
+import "github.com/wobcom/go-ethtool/eeprom"
+
 // InterruptFlags as defined in SFF-8636 rev 2.10a table 6-4
 type InterruptFlags struct {
 	ChannelInterrupt       [4]ChannelInterrupt
@@ -35,6 +37,51 @@ type Alarm struct {
 	LowWarning  bool
 }
 
+// GetHighAlarm implements eeprom.AlarmFlags interface's GetHighAlarm function
+func (a *Alarm) GetHighAlarm() bool {
+	return a.HighAlarm
+}
+
+// GetHighWarning implements eeprom.AlarmFlags interface's GetHighWarning function
+func (a *Alarm) GetHighWarning() bool {
+	return a.HighWarning
+}
+
+// GetLowAlarm implements eeprom.AlarmFlags interface's GetLowAlarm function
+func (a *Alarm) GetLowAlarm() bool {
+	return a.LowAlarm
+}
+
+// GetLowWarning implements eeprom.AlarmFlags interface's GetLowWarning function
+func (a *Alarm) GetLowWarning() bool {
+	return a.LowWarning
+}
+
+// GetTxFault implements eeprom.LaneFlags interface's GetTxFault function
+func (c *ChannelInterrupt) GetTxFault() bool {
+	return c.TxFault
+}
+
+// GetTxLOS implements eeprom.LaneFlags interface's GetTxLOS function
+func (c *ChannelInterrupt) GetTxLOS() bool {
+	return c.TxLOS
+}
+
+// GetTxLOL implements eeprom.LaneFlags interface's GetTxLOL function
+func (c *ChannelInterrupt) GetTxLOL() bool {
+	return c.TxLOL
+}
+
+// GetRxLOS implements eeprom.LaneFlags interface's GetRxLOS function
+func (c *ChannelInterrupt) GetRxLOS() bool {
+	return c.RxLOS
+}
+
+// GetRxLOL implements eeprom.LaneFlags interface's GetRxLOL function
+func (c *ChannelInterrupt) GetRxLOL() bool {
+	return c.RxLOL
+}
+
 var interruptFlagsMemoryMap = map[uint]map[uint]func(*InterruptFlags, bool){
 	0x00: map[uint]func(*InterruptFlags, bool){
 		0x07: func(i *InterruptFlags, v bool) { i.ChannelInterrupt[3].TxLOS = v },
@@ -163,3 +210,17 @@ func NewInterruptFlags(raw [19]byte) *InterruptFlags {
 	}
 	return i
 }
+
+// Encode encodes the interrupt flags into their [19]byte representation as parsed by NewInterruptFlags
+func (i *InterruptFlags) Encode() [19]byte {
+	raw := [19]byte{}
+	for byteIndex, bitmap := range interruptFlagsMemoryMap {
+		for bitIndex, callback := range bitmap {
+			// clearing the flag only changes the interrupt flags if it has been set, reserved bits remain cleared
+			probe := *i
+			callback(&probe, false)
+			eeprom.SetBits(&raw[byteIndex], 1<<bitIndex, probe != *i)
+		}
+	}
+	return raw
+}
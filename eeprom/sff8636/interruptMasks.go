@@ -1,5 +1,7 @@
 package sff8636
 
+import "github.com/wobcom/go-ethtool/eeprom"
+
 // InterruptMasks as defined in SFF-8636 rev 2.10a table 6-12
 type InterruptMasks struct {
 	ChannelInterruptMasks  [4]ChannelInterruptMasks `json:"channelInterruptMasks"`
@@ -97,3 +99,17 @@ func NewInterruptMasks(raw [6]byte) *InterruptMasks {
 	}
 	return i
 }
+
+// Encode encodes the interrupt masks into their [6]byte representation as parsed by NewInterruptMasks
+func (i *InterruptMasks) Encode() [6]byte {
+	raw := [6]byte{}
+	for byteOffset, bitMap := range interruptMasksMemoryMap {
+		for bitOffset, callback := range bitMap {
+			// clearing the mask only changes the interrupt masks if it has been set, reserved bits remain cleared
+			probe := *i
+			callback(&probe, false)
+			eeprom.SetBits(&raw[byteOffset], 1<<bitOffset, probe != *i)
+		}
+	}
+	return raw
+}
@@ -10,6 +10,7 @@ type Laser struct {
 	RxPower *Measurement
 	TxPower *Measurement
 	Bias    *Measurement
+	Flags   *ChannelInterrupt
 }
 
 // Measurement a helper struct for implementing eeprom.Laser interface
@@ -18,6 +19,7 @@ type Measurement struct {
 	Unit                string
 	ThresholdsSupported bool
 	Thresholds          *MeasurementThresholds
+	Flags               *Alarm
 }
 
 // MeasurementThresholds a helper struct for implementing eeprom.Laser interface
@@ -35,19 +37,41 @@ func (l *Laser) SupportsMonitoring() bool {
 
 // GetBias implements eeprom.Laser interface's GetBias function
 func (l *Laser) GetBias() (eeprom.Measurement, error) {
+	if l.Bias == nil {
+		return nil, eeprom.IgnoredDiagnosticError(eeprom.DiagnosticBias)
+	}
 	return l.Bias, nil
 }
 
 // GetTxPower implements eeprom.Laser interface's GetTxPower function
 func (l *Laser) GetTxPower() (eeprom.Measurement, error) {
+	if l.TxPower == nil {
+		return nil, eeprom.IgnoredDiagnosticError(eeprom.DiagnosticTxPower)
+	}
 	return l.TxPower, nil
 }
 
 // GetRxPower implements eeprom.Laser interface's GetRxPower function
 func (l *Laser) GetRxPower() (eeprom.Measurement, error) {
+	if l.RxPower == nil {
+		return nil, eeprom.IgnoredDiagnosticError(eeprom.DiagnosticRxPower)
+	}
 	return l.RxPower, nil
 }
 
+// SupportsLaneFlags implements eeprom.Laser interface's SupportsLaneFlags function
+func (l *Laser) SupportsLaneFlags() bool {
+	return l.Flags != nil
+}
+
+// GetLaneFlags implements eeprom.Laser interface's GetLaneFlags function
+func (l *Laser) GetLaneFlags() (eeprom.LaneFlags, error) {
+	if !l.SupportsLaneFlags() {
+		return nil, errors.New("No lane flags available")
+	}
+	return l.Flags, nil
+}
+
 // GetValue implements eeprom.Measurement interface's GetValue function
 func (m *Measurement) GetValue() float64 {
 	return m.Value
@@ -71,6 +95,19 @@ func (m *Measurement) GetAlarmThresholds() (eeprom.AlarmThresholds, error) {
 	return m.Thresholds, nil
 }
 
+// SupportsAlarmFlags implements eeprom.Measurement interface's SupportsAlarmFlags function
+func (m *Measurement) SupportsAlarmFlags() bool {
+	return m.Flags != nil
+}
+
+// GetAlarmFlags implements eeprom.Measurement interface's GetAlarmFlags function
+func (m *Measurement) GetAlarmFlags() (eeprom.AlarmFlags, error) {
+	if !m.SupportsAlarmFlags() {
+		return nil, errors.New("No alarm flags available")
+	}
+	return m.Flags, nil
+}
+
 // GetHighAlarm implements eeprom.Measurement interface's GetHighAlarm function
 func (m *MeasurementThresholds) GetHighAlarm() float64 {
 	return m.HighAlarm
@@ -99,45 +136,52 @@ func (e *EEPROM) GetLasers() []eeprom.Laser {
 	ret := []eeprom.Laser{}
 
 	for i := 0; i < 4; i++ {
+		thresholds := e.GetChannelThresholds(i)
+		flags := &e.InterruptFlags.ChannelInterrupt[i]
 		laser := &Laser{
 			RxPower: &Measurement{
 				Value:               float64(e.ChannelMonitors[i].RxPower),
-				ThresholdsSupported: e.Thresholds != nil,
+				ThresholdsSupported: thresholds != nil,
 				Unit:                "milliwatts",
+				Flags:               &flags.RxPowerAlarm,
 			},
 			TxPower: &Measurement{
 				Value:               float64(e.ChannelMonitors[i].TxPower),
-				ThresholdsSupported: e.Thresholds != nil,
+				ThresholdsSupported: thresholds != nil,
 				Unit:                "miliwatts",
+				Flags:               &flags.TxPowerAlarm,
 			},
 			Bias: &Measurement{
 				Value:               e.ChannelMonitors[i].Bias,
-				ThresholdsSupported: e.Thresholds != nil,
+				ThresholdsSupported: thresholds != nil,
 				Unit:                "milliamperes",
+				Flags:               &flags.BiasAlarm,
 			},
+			Flags: flags,
 		}
 
-		if e.Thresholds != nil {
+		if thresholds != nil {
 			laser.RxPower.Thresholds = &MeasurementThresholds{
-				HighAlarm:   float64(e.Thresholds.RxPower.HighAlarm),
-				HighWarning: float64(e.Thresholds.RxPower.HighWarning),
-				LowAlarm:    float64(e.Thresholds.RxPower.LowAlarm),
-				LowWarning:  float64(e.Thresholds.RxPower.LowWarning),
+				HighAlarm:   float64(thresholds.RxPower.HighAlarm),
+				HighWarning: float64(thresholds.RxPower.HighWarning),
+				LowAlarm:    float64(thresholds.RxPower.LowAlarm),
+				LowWarning:  float64(thresholds.RxPower.LowWarning),
 			}
 			laser.TxPower.Thresholds = &MeasurementThresholds{
-				HighAlarm:   float64(e.Thresholds.TxPower.HighAlarm),
-				HighWarning: float64(e.Thresholds.TxPower.HighWarning),
-				LowAlarm:    float64(e.Thresholds.TxPower.LowAlarm),
-				LowWarning:  float64(e.Thresholds.TxPower.LowWarning),
+				HighAlarm:   float64(thresholds.TxPower.HighAlarm),
+				HighWarning: float64(thresholds.TxPower.HighWarning),
+				LowAlarm:    float64(thresholds.TxPower.LowAlarm),
+				LowWarning:  float64(thresholds.TxPower.LowWarning),
 			}
 			laser.Bias.Thresholds = &MeasurementThresholds{
-				HighAlarm:   e.Thresholds.TxBias.HighAlarm,
-				HighWarning: e.Thresholds.TxBias.HighWarning,
-				LowAlarm:    e.Thresholds.TxBias.LowAlarm,
-				LowWarning:  e.Thresholds.TxBias.LowWarning,
+				HighAlarm:   thresholds.TxBias.HighAlarm,
+				HighWarning: thresholds.TxBias.HighWarning,
+				LowAlarm:    thresholds.TxBias.LowAlarm,
+				LowWarning:  thresholds.TxBias.LowWarning,
 			}
 		}
 
+		e.ignoreDiagnostics(laser)
 		ret = append(ret, laser)
 	}
 	return ret
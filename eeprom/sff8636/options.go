@@ -1,5 +1,7 @@
 package sff8636
 
+import "github.com/wobcom/go-ethtool/eeprom"
+
 // Options options implemented in the free side device
 type Options struct {
 	LPModeTxDisConfigurable                bool
@@ -72,3 +74,17 @@ func NewOptions(raw [3]byte) *Options {
 	}
 	return o
 }
+
+// Encode encodes the options into their [3]byte representation as parsed by NewOptions
+func (o *Options) Encode() [3]byte {
+	raw := [3]byte{}
+	for byteOffset, bitMap := range optionsMemoryMap {
+		for bitOffset, callback := range bitMap {
+			// clearing the option only changes the options if it has been set
+			probe := *o
+			callback(&probe, false)
+			eeprom.SetBits(&raw[byteOffset], 1<<bitOffset, probe != *o)
+		}
+	}
+	return raw
+}
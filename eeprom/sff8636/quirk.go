@@ -0,0 +1,80 @@
+package sff8636
+
+import (
+	"github.com/wobcom/go-ethtool/eeprom"
+)
+
+// ApplyQuirk implements eeprom.QuirkApplier interface's ApplyQuirk function
+func (e *EEPROM) ApplyQuirk(quirk *eeprom.Quirk) {
+	if e.FreeSideMonitors != nil {
+		e.FreeSideMonitors.Temperature = quirk.CorrectValue(eeprom.DiagnosticTemperature, e.FreeSideMonitors.Temperature)
+		e.FreeSideMonitors.SupplyVoltage = quirk.CorrectValue(eeprom.DiagnosticVoltage, e.FreeSideMonitors.SupplyVoltage)
+	}
+	if e.ChannelMonitors != nil {
+		for i := range e.ChannelMonitors {
+			channel := &e.ChannelMonitors[i]
+			channel.Bias = quirk.CorrectValue(eeprom.DiagnosticBias, channel.Bias)
+			channel.TxPower = Power(quirk.CorrectValue(eeprom.DiagnosticTxPower, float64(channel.TxPower)))
+			channel.RxPower = Power(quirk.CorrectValue(eeprom.DiagnosticRxPower, float64(channel.RxPower)))
+		}
+	}
+	if e.Thresholds != nil {
+		e.Thresholds.Temperature.applyQuirk(quirk, eeprom.DiagnosticTemperature)
+		e.Thresholds.Voltage.applyQuirk(quirk, eeprom.DiagnosticVoltage)
+		e.Thresholds.TxBias.applyQuirk(quirk, eeprom.DiagnosticBias)
+		e.Thresholds.TxPower.applyQuirk(quirk, eeprom.DiagnosticTxPower)
+		e.Thresholds.RxPower.applyQuirk(quirk, eeprom.DiagnosticRxPower)
+	}
+	for _, diagnostic := range quirk.IgnoreDiagnostics {
+		if e.IgnoredDiagnostics == nil {
+			e.IgnoredDiagnostics = map[eeprom.Diagnostic]bool{}
+		}
+		e.IgnoredDiagnostics[diagnostic] = true
+	}
+	e.AppliedQuirks = append(e.AppliedQuirks, quirk.Name)
+}
+
+// GetAppliedQuirks implements eeprom.QuirkApplier interface's GetAppliedQuirks function
+func (e *EEPROM) GetAppliedQuirks() []string {
+	return e.AppliedQuirks
+}
+
+// ignoreDiagnostics removes the measurements of laser ignored due to a quirk
+func (e *EEPROM) ignoreDiagnostics(laser *Laser) {
+	if e.IgnoredDiagnostics[eeprom.DiagnosticBias] {
+		laser.Bias = nil
+	}
+	if e.IgnoredDiagnostics[eeprom.DiagnosticTxPower] {
+		laser.TxPower = nil
+	}
+	if e.IgnoredDiagnostics[eeprom.DiagnosticRxPower] {
+		laser.RxPower = nil
+	}
+}
+
+func (a *AlarmThresholds) applyQuirk(quirk *eeprom.Quirk, diagnostic eeprom.Diagnostic) {
+	if a == nil {
+		return
+	}
+	thresholds := quirk.CorrectThresholds(diagnostic, eeprom.ThresholdValues{
+		HighAlarm:   a.HighAlarm,
+		HighWarning: a.HighWarning,
+		LowAlarm:    a.LowAlarm,
+		LowWarning:  a.LowWarning,
+	})
+	a.HighAlarm, a.HighWarning, a.LowAlarm, a.LowWarning = thresholds.HighAlarm, thresholds.HighWarning, thresholds.LowAlarm, thresholds.LowWarning
+}
+
+func (a *AlarmPowerThresholds) applyQuirk(quirk *eeprom.Quirk, diagnostic eeprom.Diagnostic) {
+	if a == nil {
+		return
+	}
+	thresholds := quirk.CorrectThresholds(diagnostic, eeprom.ThresholdValues{
+		HighAlarm:   float64(a.HighAlarm),
+		HighWarning: float64(a.HighWarning),
+		LowAlarm:    float64(a.LowAlarm),
+		LowWarning:  float64(a.LowWarning),
+	})
+	a.HighAlarm, a.HighWarning = Power(thresholds.HighAlarm), Power(thresholds.HighWarning)
+	a.LowAlarm, a.LowWarning = Power(thresholds.LowAlarm), Power(thresholds.LowWarning)
+}
@@ -2,6 +2,7 @@ package sff8636
 
 import (
 	"encoding/json"
+	"github.com/wobcom/go-ethtool/eeprom"
 )
 
 // SpecificationCompliance maps a Specification to a bool, to indiciate compliance (true) or not (false) to a certain standard
@@ -270,3 +271,14 @@ func NewSpecificationCompliance(raw [8]byte) SpecificationCompliance {
 	}
 	return s
 }
+
+// Encode encodes the specification compliance into its [8]byte representation as parsed by NewSpecificationCompliance
+func (s SpecificationCompliance) Encode() [8]byte {
+	raw := [8]byte{}
+	for byteOffset, bitMap := range specificationComplianceMemoryMap {
+		for bitOffset, specification := range bitMap {
+			eeprom.SetBits(&raw[byteOffset], 1<<bitOffset, s[specification])
+		}
+	}
+	return raw
+}
@@ -2,6 +2,7 @@ package sff8636
 
 import (
 	"encoding/json"
+	"github.com/wobcom/go-ethtool/eeprom"
 )
 
 // StatusIndicators as defined in SFF-8636 rev 2.10a table 6-2
@@ -94,3 +95,21 @@ func NewStatusIndicators(raw [2]byte) *StatusIndicators {
 		StatusIndicator:    NewStatusIndiciator(raw[1]),
 	}
 }
+
+// Encode encodes the status indicator into its byte as parsed by NewStatusIndiciator
+func (s *StatusIndicator) Encode() byte {
+	raw := byte(0)
+	eeprom.SetBits(&raw, 1<<flatMemBitoffset, s.FlatMemory)
+	eeprom.SetBits(&raw, 1<<intlBitOffset, s.IntL)
+	eeprom.SetBits(&raw, 1<<dataNotReadyBitoffset, s.DataNotReady)
+	return raw
+}
+
+// Encode encodes the status indicators into their [2]byte representation as parsed by NewStatusIndicators
+func (s *StatusIndicators) Encode() [2]byte {
+	raw := [2]byte{byte(s.RevisionCompliance)}
+	if s.StatusIndicator != nil {
+		raw[1] = s.StatusIndicator.Encode()
+	}
+	return raw
+}
@@ -61,3 +61,38 @@ func NewThresholds(raw [72]byte) *Thresholds {
 
 	return t
 }
+
+// Encode encodes the thresholds into their [72]byte representation as parsed by NewThresholds
+func (t *Thresholds) Encode() [72]byte {
+	raw := [72]byte{}
+	for byteOffset, thresholds := range map[uint][8]byte{
+		0x00: t.Temperature.encode(encodeTemperature),
+		0x10: t.Voltage.encode(encodeVoltage),
+		0x30: t.RxPower.encode(),
+		0x38: t.TxBias.encode(encodeCurrent),
+		0x40: t.TxPower.encode(),
+	} {
+		copy(raw[byteOffset:], thresholds[:])
+	}
+	return raw
+}
+
+// encode encodes high alarm, low alarm, high warning and low warning in the order of the memory map
+func (a *AlarmThresholds) encode(encodeValue func(float64) [2]byte) [8]byte {
+	raw := [8]byte{}
+	for i, value := range []float64{a.HighAlarm, a.LowAlarm, a.HighWarning, a.LowWarning} {
+		encoded := encodeValue(value)
+		copy(raw[2*i:], encoded[:])
+	}
+	return raw
+}
+
+// encode encodes high alarm, low alarm, high warning and low warning in the order of the memory map
+func (a *AlarmPowerThresholds) encode() [8]byte {
+	raw := [8]byte{}
+	for i, value := range []Power{a.HighAlarm, a.LowAlarm, a.HighWarning, a.LowWarning} {
+		encoded := encodePower(value)
+		copy(raw[2*i:], encoded[:])
+	}
+	return raw
+}
@@ -2,12 +2,9 @@ package sff8636
 
 import (
 	"bytes"
-	"encoding/hex"
 	"encoding/json"
-	"fmt"
 	"github.com/wobcom/go-ethtool/util"
 	"math"
-	"strings"
 )
 
 // Power type for power measurements, provides conversion to dBm when JSON serialized
@@ -46,11 +43,7 @@ func parseCurrent(msb byte, lsb byte) float64 {
 }
 
 func parseString(raw []byte) string {
-	s := util.GetValidUtf8String(bytes.Trim(raw, "\x00"))
-	if strings.HasPrefix(s, "/") {
-		fmt.Printf("Parse weird string %s, hex: %s \n", s, hex.EncodeToString(raw))
-	}
-	return s
+	return util.GetValidUtf8String(bytes.Trim(raw, "\x00"))
 }
 
 func parseWavelength(msb byte, lsb byte) float64 {
@@ -68,3 +61,39 @@ func parseUint16(msb byte, lsb byte) uint16 {
 func parseInt16(msb byte, lsb byte) int16 {
 	return int16(int16(msb)<<8) | int16(lsb)
 }
+
+func encodeTemperature(temperature float64) [2]byte {
+	return encodeInt16(temperature * 256.0)
+}
+
+func encodeVoltage(voltage float64) [2]byte {
+	return encodeUint16(voltage * 10000)
+}
+
+func encodePower(power Power) [2]byte {
+	return encodeUint16(float64(power) * 10000)
+}
+
+func encodeCurrent(current float64) [2]byte {
+	return encodeUint16(current / 0.002)
+}
+
+func encodeWavelength(wavelength float64) [2]byte {
+	return encodeUint16(wavelength * 20)
+}
+
+func encodeWavelengthTolerance(tolerance float64) [2]byte {
+	return encodeUint16(tolerance * 200)
+}
+
+// encodeUint16 rounds value to the nearest uint16, clamping values out of range
+func encodeUint16(value float64) [2]byte {
+	v := uint16(math.Max(0, math.Min(math.MaxUint16, math.Round(value))))
+	return [2]byte{byte(v >> 8), byte(v)}
+}
+
+// encodeInt16 rounds value to the nearest int16, clamping values out of range
+func encodeInt16(value float64) [2]byte {
+	v := int16(math.Max(math.MinInt16, math.Min(math.MaxInt16, math.Round(value))))
+	return [2]byte{byte(v >> 8), byte(v)}
+}
@@ -0,0 +1,310 @@
+// Package threshold evaluates the monitor readings of an eeprom.EEPROM against their alarm and warning thresholds in software,
+// independent of the alarm flags the module asserts itself.
+package threshold
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/wobcom/go-ethtool/eeprom"
+	"math"
+	"sort"
+)
+
+// Measurement kind of a monitored value
+type Measurement string
+
+const (
+	// MeasurementTemperature module temperature
+	MeasurementTemperature Measurement = "temperature"
+	// MeasurementVoltage module supply voltage
+	MeasurementVoltage Measurement = "voltage"
+	// MeasurementBias laser bias current of a lane
+	MeasurementBias Measurement = "bias"
+	// MeasurementTxPower transmit power of a lane
+	MeasurementTxPower Measurement = "txPower"
+	// MeasurementRxPower receive power of a lane
+	MeasurementRxPower Measurement = "rxPower"
+)
+
+// IsPower returns whether the measurement is an optical power in milliwatts, evaluated in dBm
+func (m Measurement) IsPower() bool {
+	return m == MeasurementTxPower || m == MeasurementRxPower
+}
+
+// ModuleLane lane of module wide measurements (temperature, voltage)
+const ModuleLane = -1
+
+// Power readings of 0 mW are evaluated as -40 dBm, the resolution of the power monitors (0.1 uW)
+const minPowerDBm = -40.0
+
+// Severity of a finding
+type Severity int
+
+const (
+	// SeverityWarning a warning threshold has been crossed
+	SeverityWarning Severity = 1
+	// SeverityAlarm an alarm threshold has been crossed
+	SeverityAlarm Severity = 2
+)
+
+func (s Severity) String() string {
+	str, found := map[Severity]string{
+		SeverityWarning: "warning",
+		SeverityAlarm:   "alarm",
+	}[s]
+	if found {
+		return str
+	}
+	return "none"
+}
+
+// MarshalJSON implements the encoding/json/Marshaler interface's MarshalJSON function
+func (s Severity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// Direction whether a high or a low threshold has been crossed
+type Direction int
+
+const (
+	// DirectionHigh the value is at or above a high threshold
+	DirectionHigh Direction = 1
+	// DirectionLow the value is at or below a low threshold
+	DirectionLow Direction = 2
+)
+
+func (d Direction) String() string {
+	if d == DirectionHigh {
+		return "high"
+	}
+	return "low"
+}
+
+// MarshalJSON implements the encoding/json/Marshaler interface's MarshalJSON function
+func (d Direction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// Finding a measurement found at or beyond one of its thresholds
+type Finding struct {
+	Measurement Measurement `json:"measurement"`
+	// Lane (0 being the first), ModuleLane for module wide measurements
+	Lane      int       `json:"lane"`
+	Severity  Severity  `json:"severity"`
+	Direction Direction `json:"direction"`
+	Value     float64   `json:"value"`
+	// Threshold that has been crossed
+	Limit float64 `json:"limit"`
+	Unit  string  `json:"unit"`
+	// Distance beyond the limit, in dB for power measurements and in Unit otherwise
+	Margin float64 `json:"margin"`
+}
+
+func (f Finding) String() string {
+	lane := "module"
+	if f.Lane != ModuleLane {
+		lane = fmt.Sprintf("lane %d", f.Lane+1)
+	}
+	marginUnit := f.Unit
+	if f.Measurement.IsPower() {
+		marginUnit = "dB"
+	}
+	return fmt.Sprintf("%s %s %s %s: %g %s (limit %g, %.2f %s beyond)",
+		lane, f.Measurement, f.Direction, f.Severity, f.Value, f.Unit, f.Limit, f.Margin, marginUnit)
+}
+
+// Thresholds operator supplied thresholds, implements eeprom.AlarmThresholds
+type Thresholds struct {
+	HighAlarm   float64
+	HighWarning float64
+	LowAlarm    float64
+	LowWarning  float64
+}
+
+// GetHighAlarm implements eeprom.AlarmThresholds interface's GetHighAlarm function
+func (t *Thresholds) GetHighAlarm() float64 {
+	return t.HighAlarm
+}
+
+// GetHighWarning implements eeprom.AlarmThresholds interface's GetHighWarning function
+func (t *Thresholds) GetHighWarning() float64 {
+	return t.HighWarning
+}
+
+// GetLowAlarm implements eeprom.AlarmThresholds interface's GetLowAlarm function
+func (t *Thresholds) GetLowAlarm() float64 {
+	return t.LowAlarm
+}
+
+// GetLowWarning implements eeprom.AlarmThresholds interface's GetLowWarning function
+func (t *Thresholds) GetLowWarning() float64 {
+	return t.LowWarning
+}
+
+// Evaluator evaluates measurements against their thresholds.
+// It keeps the findings of the previous evaluation to apply hysteresis, so use one Evaluator per module.
+type Evaluator struct {
+	// Hysteresis per measurement, in dB for power measurements and in the measurement's unit otherwise.
+	// A finding is only cleared once the value is back within its limit by at least the hysteresis.
+	Hysteresis map[Measurement]float64
+	// Overrides thresholds per vendor part number, replacing those provided by the module
+	Overrides map[string]map[Measurement]Thresholds
+
+	active map[findingKey]Severity
+}
+
+type findingKey struct {
+	measurement Measurement
+	lane        int
+	direction   Direction
+}
+
+// NewEvaluator returns a new Evaluator without hysteresis and overrides
+func NewEvaluator() *Evaluator {
+	return &Evaluator{
+		Hysteresis: map[Measurement]float64{},
+		Overrides:  map[string]map[Measurement]Thresholds{},
+		active:     map[findingKey]Severity{},
+	}
+}
+
+// Evaluate evaluates the measurements of the given EEPROM once, without hysteresis and overrides
+func Evaluate(e eeprom.EEPROM) []Finding {
+	return NewEvaluator().Evaluate(e)
+}
+
+// Evaluate walks the module and laser measurements of the given EEPROM and returns all findings, ordered by lane and measurement.
+// Measurements without thresholds (neither advertised nor overridden) or whose thresholds are all zero are skipped.
+func (ev *Evaluator) Evaluate(e eeprom.EEPROM) []Finding {
+	if ev.active == nil {
+		ev.active = map[findingKey]Severity{}
+	}
+	overrides := ev.Overrides[e.GetVendorPN()]
+	findings := []Finding{}
+	active := map[findingKey]Severity{}
+
+	evaluate := func(kind Measurement, lane int, measurement eeprom.Measurement, err error) {
+		if err != nil || measurement == nil {
+			return
+		}
+		var thresholds eeprom.AlarmThresholds
+		if override, found := overrides[kind]; found {
+			thresholds = &override
+		} else if measurement.SupportsThresholds() {
+			if thresholds, err = measurement.GetAlarmThresholds(); err != nil {
+				return
+			}
+		}
+		if thresholds == nil || isUnset(thresholds) {
+			return
+		}
+		for _, finding := range ev.evaluate(kind, lane, measurement, thresholds) {
+			active[findingKey{kind, lane, finding.Direction}] = finding.Severity
+			findings = append(findings, finding)
+		}
+	}
+
+	if e.SupportsMonitoring() {
+		temperature, err := e.GetModuleTemperature()
+		evaluate(MeasurementTemperature, ModuleLane, temperature, err)
+		voltage, err := e.GetModuleVoltage()
+		evaluate(MeasurementVoltage, ModuleLane, voltage, err)
+	}
+	for lane, laser := range e.GetLasers() {
+		if !laser.SupportsMonitoring() {
+			continue
+		}
+		bias, err := laser.GetBias()
+		evaluate(MeasurementBias, lane, bias, err)
+		txPower, err := laser.GetTxPower()
+		evaluate(MeasurementTxPower, lane, txPower, err)
+		rxPower, err := laser.GetRxPower()
+		evaluate(MeasurementRxPower, lane, rxPower, err)
+	}
+
+	ev.active = active
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Lane < findings[j].Lane
+	})
+	return findings
+}
+
+// evaluate compares a single measurement against its thresholds, returning at most one finding per direction
+func (ev *Evaluator) evaluate(kind Measurement, lane int, measurement eeprom.Measurement, thresholds eeprom.AlarmThresholds) []Finding {
+	value := measurement.GetValue()
+	scale := func(v float64) float64 { return v }
+	if kind.IsPower() {
+		scale = toDBm
+	}
+	hysteresis := ev.Hysteresis[kind]
+
+	findings := []Finding{}
+	for _, check := range []struct {
+		direction Direction
+		severity  Severity
+		limit     float64
+	}{
+		{DirectionHigh, SeverityAlarm, thresholds.GetHighAlarm()},
+		{DirectionHigh, SeverityWarning, thresholds.GetHighWarning()},
+		{DirectionLow, SeverityAlarm, thresholds.GetLowAlarm()},
+		{DirectionLow, SeverityWarning, thresholds.GetLowWarning()},
+	} {
+		// a previously active finding of at least this severity only clears once the value is within the limit by the hysteresis
+		limit := scale(check.limit)
+		if ev.active[findingKey{kind, lane, check.direction}] >= check.severity {
+			if check.direction == DirectionHigh {
+				limit -= hysteresis
+			} else {
+				limit += hysteresis
+			}
+		}
+		margin := scale(value) - limit
+		if check.direction == DirectionLow {
+			margin = limit - scale(value)
+		}
+		if margin < 0 || hasDirection(findings, check.direction) {
+			continue
+		}
+		findings = append(findings, Finding{
+			Measurement: kind,
+			Lane:        lane,
+			Severity:    check.severity,
+			Direction:   check.direction,
+			Value:       value,
+			Limit:       check.limit,
+			Unit:        measurement.GetUnit(),
+			Margin:      marginBeyond(scale, value, check.limit, check.direction),
+		})
+	}
+	return findings
+}
+
+// marginBeyond returns the distance of value beyond limit, ignoring hysteresis
+func marginBeyond(scale func(float64) float64, value float64, limit float64, direction Direction) float64 {
+	if direction == DirectionHigh {
+		return scale(value) - scale(limit)
+	}
+	return scale(limit) - scale(value)
+}
+
+func hasDirection(findings []Finding, direction Direction) bool {
+	for _, finding := range findings {
+		if finding.Direction == direction {
+			return true
+		}
+	}
+	return false
+}
+
+// isUnset returns whether all thresholds are zero, as reported by modules not implementing them
+func isUnset(t eeprom.AlarmThresholds) bool {
+	return t.GetHighAlarm() == 0 && t.GetHighWarning() == 0 && t.GetLowAlarm() == 0 && t.GetLowWarning() == 0
+}
+
+// toDBm converts milliwatts to dBm
+func toDBm(milliwatts float64) float64 {
+	if milliwatts <= 0 {
+		return minPowerDBm
+	}
+	return math.Max(10*math.Log10(milliwatts), minPowerDBm)
+}
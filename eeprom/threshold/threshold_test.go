@@ -0,0 +1,72 @@
+package threshold
+
+import (
+	"github.com/wobcom/go-ethtool/eeprom/sff8636"
+	"testing"
+)
+
+// getEEPROM returns an SFF-8636 EEPROM with temperature and Rx power thresholds, the given module temperature
+// (units of 1/256 degrees celsius) and Rx power of 0.08 mW on the first, 0 mW on all other lanes
+func getEEPROM(t *testing.T, temperature uint16) *sff8636.EEPROM {
+	raw := make([]byte, 640)
+	copy(raw[0x200:], []byte{0x50, 0x00, 0xF6, 0x00, 0x4B, 0x00, 0xFB, 0x00})
+	copy(raw[0x230:], []byte{0x4E, 0x20, 0x01, 0xF4, 0x3A, 0x98, 0x03, 0xE8})
+	raw[0x16], raw[0x17] = byte(temperature>>8), byte(temperature)
+	raw[0x22], raw[0x23] = 0x03, 0x20
+
+	e, err := sff8636.NewEEPROM(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestEvaluate(t *testing.T) {
+	findings := Evaluate(getEEPROM(t, 0x4C00))
+	if len(findings) != 5 {
+		t.Fatalf("Evaluate returned %d findings, but expected 5: %v", len(findings), findings)
+	}
+
+	temperature := findings[0]
+	if temperature.Measurement != MeasurementTemperature || temperature.Lane != ModuleLane ||
+		temperature.Severity != SeverityWarning || temperature.Direction != DirectionHigh ||
+		temperature.Limit != 75 || temperature.Margin != 1 {
+		t.Errorf("Unexpected temperature finding %s", temperature)
+	}
+
+	rxPower := findings[1]
+	if rxPower.Measurement != MeasurementRxPower || rxPower.Lane != 0 || rxPower.Severity != SeverityWarning ||
+		rxPower.Direction != DirectionLow || rxPower.Margin < 0.96 || rxPower.Margin > 0.97 {
+		t.Errorf("Unexpected Rx power finding %s", rxPower)
+	}
+	for _, finding := range findings[2:] {
+		if finding.Severity != SeverityAlarm || finding.Margin < 26.98 || finding.Margin > 26.99 {
+			t.Errorf("Unexpected Rx power finding %s", finding)
+		}
+	}
+}
+
+func TestEvaluateHysteresisAndOverrides(t *testing.T) {
+	evaluator := NewEvaluator()
+	evaluator.Hysteresis[MeasurementTemperature] = 1
+	evaluator.Evaluate(getEEPROM(t, 0x4C00))
+
+	// 74.5 degrees celsius are within the hysteresis of the high warning
+	findings := evaluator.Evaluate(getEEPROM(t, 0x4A80))
+	if len(findings) != 5 || findings[0].Measurement != MeasurementTemperature {
+		t.Errorf("High temperature warning cleared within the hysteresis: %v", findings)
+	}
+	// 73.5 degrees celsius clear it
+	findings = evaluator.Evaluate(getEEPROM(t, 0x4980))
+	if len(findings) != 4 {
+		t.Errorf("High temperature warning did not clear: %v", findings)
+	}
+
+	evaluator.Overrides[""] = map[Measurement]Thresholds{
+		MeasurementTemperature: {HighAlarm: 73, HighWarning: 70, LowAlarm: -5, LowWarning: 0},
+	}
+	findings = evaluator.Evaluate(getEEPROM(t, 0x4980))
+	if len(findings) != 5 || findings[0].Severity != SeverityAlarm || findings[0].Limit != 73 {
+		t.Errorf("Override thresholds not applied: %v", findings)
+	}
+}
@@ -0,0 +1,33 @@
+package ethtool
+
+import (
+	"github.com/wobcom/go-ethtool/eeprom"
+	"github.com/wobcom/go-ethtool/eeprom/sff8636"
+	"testing"
+)
+
+func TestParseBaseLengthSFF8636(t *testing.T) {
+	module := getFakeQSFP28()
+	// upper pages 01h and 02h provided, but not returned by the driver
+	module.raw[0xC3] |= 0xC0
+
+	for _, eepromType := range []eeprom.Type{eeprom.TypeSFF8636, eeprom.TypeSFF8436} {
+		parsed, err := (&Interface{Name: "swp42"}).parseEEPROM(module.raw, eepromType, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		e, ok := parsed.(*sff8636.EEPROM)
+		if !ok {
+			t.Fatalf("Expected SFF-8636 EEPROM, got %T", parsed)
+		}
+		if !e.Options.MemoryPage01hProvided || !e.Options.MemoryPage02hProvided {
+			t.Fatal("Expected upper pages 01h and 02h to be advertised")
+		}
+		if e.ApplicationSelectTable != nil {
+			t.Errorf("Unexpected application select table %+v decoded from padding", e.ApplicationSelectTable)
+		}
+		if e.UserEEPROM != nil {
+			t.Errorf("Unexpected user EEPROM %x decoded from padding", e.UserEEPROM)
+		}
+	}
+}
@@ -12,8 +12,9 @@ const (
 
 // Ethtool provides a wrapper around the Kernel's ethtool ioctls
 type Ethtool struct {
-	fd int
-	mu *sync.Mutex
+	fd      int
+	mu      *sync.Mutex
+	netlink *netlinkSocket
 }
 
 // NewEthtool initializes internal data structure (i.e. opens a socket) and returns a new Ethtool instance
@@ -43,7 +44,10 @@ func (e *Ethtool) PerformIoctl(ifr *ifreq) error {
 	return nil
 }
 
-// Close closes the internally used socket
+// Close closes the internally used sockets
 func (e *Ethtool) Close() {
 	unix.Close(e.fd)
+	if e.netlink != nil {
+		e.netlink.close()
+	}
 }
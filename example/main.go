@@ -2,6 +2,7 @@ package main
 
 import (
 	"github.com/wobcom/go-ethtool"
+	"github.com/wobcom/go-ethtool/eeprom"
 
 	"encoding/json"
 	"flag"
@@ -11,10 +12,16 @@ import (
 
 func main() {
 	ifname := flag.String("interface", "", "Interface name")
+	file := flag.String("file", "", "EEPROM dump to decode instead of an interface (ethtool -m hex/raw output or plain hex)")
 	flag.Parse()
 
+	if *file != "" {
+		decodeFile(*file)
+		return
+	}
+
 	if *ifname == "" {
-		log.Fatal("Specify interface with --interface")
+		log.Fatal("Specify interface with --interface or EEPROM dump with --file")
 	}
 
 	tool, err := ethtool.NewEthtool()
@@ -33,3 +40,22 @@ func main() {
 	}
 	os.Stdout.Write(b)
 }
+
+func decodeFile(path string) {
+	raw, err := eeprom.ReadDumpFile(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// no type reported by a driver, SFPs are decoded according to their compliance revision and diagnostic monitoring type
+	e, err := eeprom.Decode(raw, eeprom.TypeSFF8079)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		panic(err.Error())
+	}
+	os.Stdout.Write(b)
+}
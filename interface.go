@@ -18,6 +18,10 @@ type Interface struct {
 
 	Eeprom     eeprom.EEPROM
 	DriverInfo *DriverInfo
+	// ModuleEEPROMWriter is used for changing module settings, nil disables writes
+	ModuleEEPROMWriter ModuleEEPROMWriter `json:"-"`
+	// readModuleEEPROM replaces reading the module EEPROM through the kernel if set, used by tests
+	readModuleEEPROM func() ([]byte, eeprom.Type, error)
 
 	ethtool *Ethtool
 }
@@ -38,7 +42,7 @@ func (e *Ethtool) NewInterface(ifname string, ignoreEepromReadErrors bool) (*Int
 	}
 	iface.DriverInfo = driverInfo
 
-	eeprom, err := iface.getEEPROM()
+	eeprom, err := iface.getEEPROM(nil)
 	if err != nil {
 		if ignoreEepromReadErrors {
 			return iface, nil
@@ -0,0 +1,323 @@
+package ethtool
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/wobcom/go-ethtool/eeprom"
+	"github.com/wobcom/go-ethtool/eeprom/sff8472"
+	"github.com/wobcom/go-ethtool/eeprom/sff8636"
+)
+
+const (
+	// Get plug-in module parameters
+	moduleGetCommand = 0x22
+	// Set plug-in module parameters
+	moduleSetCommand = 0x23
+)
+
+/* ETHTOOL_A_MODULE_* */
+const (
+	moduleAttrHeader          = 0x01
+	moduleAttrPowerModePolicy = 0x02
+	moduleAttrPowerMode       = 0x03
+)
+
+// ModulePowerModePolicy policy the kernel applies to a plug-in module's power mode
+type ModulePowerModePolicy uint8
+
+const (
+	// ModulePowerModePolicyHigh module is always in high power mode
+	ModulePowerModePolicyHigh ModulePowerModePolicy = 0x01
+	// ModulePowerModePolicyAuto module is transitioned by the host to high power mode when the first port using it is put administratively up and to low power mode when the last port using it is put administratively down
+	ModulePowerModePolicyAuto ModulePowerModePolicy = 0x02
+)
+
+func (m ModulePowerModePolicy) String() string {
+	str, found := map[ModulePowerModePolicy]string{
+		ModulePowerModePolicyHigh: "high",
+		ModulePowerModePolicyAuto: "auto",
+	}[m]
+	if found {
+		return str
+	}
+	return "Not reported"
+}
+
+// MarshalJSON implements the encoding/json/Marshaler interface's MarshalJSON function
+func (m ModulePowerModePolicy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// ModulePowerMode operational power mode of a plug-in module
+type ModulePowerMode uint8
+
+const (
+	// ModulePowerModeLow module is in low power mode
+	ModulePowerModeLow ModulePowerMode = 0x01
+	// ModulePowerModeHigh module is in high power mode
+	ModulePowerModeHigh ModulePowerMode = 0x02
+)
+
+func (m ModulePowerMode) String() string {
+	str, found := map[ModulePowerMode]string{
+		ModulePowerModeLow:  "low",
+		ModulePowerModeHigh: "high",
+	}[m]
+	if found {
+		return str
+	}
+	return "Not reported"
+}
+
+// MarshalJSON implements the encoding/json/Marshaler interface's MarshalJSON function
+func (m ModulePowerMode) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// ModuleParams plug-in module parameters as reported by the driver, zero values mean not reported
+type ModuleParams struct {
+	PowerModePolicy ModulePowerModePolicy
+	PowerMode       ModulePowerMode
+}
+
+// GetModuleParams retrieves the plug-in module's power mode policy and power mode
+func (i *Interface) GetModuleParams() (*ModuleParams, error) {
+	attrs := &netlinkAttributeEncoder{}
+	i.encodeRequestHeader(attrs, moduleAttrHeader, 0)
+
+	replies, err := i.netlinkRequest(moduleGetCommand, attrs)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Could not retrieve module parameters for interface %s", i.Name)
+	}
+
+	params := &ModuleParams{}
+	for _, reply := range replies {
+		if policy, found := reply.Attributes.get(moduleAttrPowerModePolicy); found {
+			params.PowerModePolicy = ModulePowerModePolicy(policy.uint8())
+		}
+		if mode, found := reply.Attributes.get(moduleAttrPowerMode); found {
+			params.PowerMode = ModulePowerMode(mode.uint8())
+		}
+	}
+	return params, nil
+}
+
+// SetModulePowerModePolicy sets the plug-in module's power mode policy, requires driver support
+func (i *Interface) SetModulePowerModePolicy(policy ModulePowerModePolicy) error {
+	attrs := &netlinkAttributeEncoder{}
+	i.encodeRequestHeader(attrs, moduleAttrHeader, 0)
+	attrs.uint8(moduleAttrPowerModePolicy, uint8(policy))
+
+	if _, err := i.netlinkRequest(moduleSetCommand, attrs); err != nil {
+		return errors.Wrapf(err, "Could not set module power mode policy %s for interface %s", policy, i.Name)
+	}
+	return nil
+}
+
+// SetModuleLowPowerMode forces an SFF-8636 module into (or out of) low power mode by software,
+// overriding the LPMode pin. This is written directly to the module EEPROM and hence requires a ModuleEEPROMWriter,
+// use SetModulePowerModePolicy if the driver supports it.
+func (i *Interface) SetModuleLowPowerMode(lowPower bool) error {
+	if _, _, err := i.getSFF8636ModuleEEPROM(); err != nil {
+		return err
+	}
+
+	expected := sff8636.PowerControlPowerOverride
+	if lowPower {
+		expected |= sff8636.PowerControlLowPowerMode
+	}
+	mask := sff8636.PowerControlPowerOverride | sff8636.PowerControlLowPowerMode
+	err := i.updateModuleEEPROMByte(sff8636.PowerControlOffset, func(current byte) byte {
+		// software reset is self clearing and must never be written back
+		return (current &^ (mask | sff8636.PowerControlSoftwareReset)) | expected
+	})
+	if err != nil {
+		return errors.Wrapf(err, "Could not set low power mode for interface %s", i.Name)
+	}
+	return i.verifyModuleEEPROM(sff8636.PowerControlOffset, mask, expected)
+}
+
+// ResetModule triggers a software reset of an SFF-8636 module, requires a ModuleEEPROMWriter
+func (i *Interface) ResetModule() error {
+	_, e, err := i.getSFF8636ModuleEEPROM()
+	if err != nil {
+		return err
+	}
+	if !e.EnhancedOptions.SoftwareResetImplemented {
+		return fmt.Errorf("Module of interface %s does not implement software reset", i.Name)
+	}
+
+	err = i.updateModuleEEPROMByte(sff8636.PowerControlOffset, func(current byte) byte {
+		return current | sff8636.PowerControlSoftwareReset
+	})
+	if err != nil {
+		return errors.Wrapf(err, "Could not reset module of interface %s", i.Name)
+	}
+	return nil
+}
+
+// SetModuleControl changes the controls (bytes 86-99) of an SFF-8636 module, requires a ModuleEEPROMWriter.
+// modify receives the module's current controls and changes them in place,
+// the result is validated against the module's advertised options, written and read back to confirm.
+func (i *Interface) SetModuleControl(modify func(*sff8636.Control)) error {
+	raw, e, err := i.getSFF8636ModuleEEPROM()
+	if err != nil {
+		return err
+	}
+
+	control := *e.Control
+	modify(&control)
+	if err := e.ValidateControl(&control); err != nil {
+		return errors.Wrapf(err, "Could not change module controls for interface %s", i.Name)
+	}
+	current := *(*[sff8636.ControlLength]byte)(raw[sff8636.ControlOffset : sff8636.ControlOffset+sff8636.ControlLength])
+	encoded := control.Encode(current)
+	if err := i.writeModuleEEPROM(sff8636.ControlOffset, encoded[:]); err != nil {
+		return errors.Wrapf(err, "Could not change module controls for interface %s", i.Name)
+	}
+
+	_, e, err = i.getSFF8636ModuleEEPROM()
+	if err != nil {
+		return errors.Wrapf(err, "Could not re-read module EEPROM")
+	}
+	if *e.Control != control {
+		return fmt.Errorf("Module control write for interface %s did not apply", i.Name)
+	}
+	return nil
+}
+
+// SetModuleTxDisable disables (or enables) the transmitter of the given channel (0-3) of an SFF-8636 module
+func (i *Interface) SetModuleTxDisable(channel int, disable bool) error {
+	if channel < 0 || channel > 3 {
+		return fmt.Errorf("Invalid channel %d, SFF-8636 modules have channels 0-3", channel)
+	}
+	return i.SetModuleControl(func(c *sff8636.Control) {
+		c.ChannelControls[channel].TxDisable = disable
+	})
+}
+
+// SetModuleSoftControl changes the soft controls (A2h bytes 110 and 118) of an SFF-8472 module, requires a ModuleEEPROMWriter.
+// modify receives the module's current soft controls and changes them in place,
+// controls not advertised by the module are refused, the change is read back to confirm.
+func (i *Interface) SetModuleSoftControl(modify func(*sff8472.SoftControl)) error {
+	raw, e, err := i.getSFF8472ModuleEEPROM()
+	if err != nil {
+		return err
+	}
+	control, err := e.GetSoftControl()
+	if err != nil {
+		return errors.Wrapf(err, "Could not change soft controls for interface %s", i.Name)
+	}
+
+	requested := *control
+	modify(&requested)
+	if err := e.ValidateSoftControl(&requested); err != nil {
+		return errors.Wrapf(err, "Could not change soft controls for interface %s", i.Name)
+	}
+	statusControl, extendedStatusControl := requested.Encode(raw[sff8472.StatusControlOffset], raw[sff8472.ExtendedStatusControlOffset])
+	if statusControl != raw[sff8472.StatusControlOffset] {
+		if err := i.writeModuleEEPROM(sff8472.StatusControlOffset, []byte{statusControl}); err != nil {
+			return errors.Wrapf(err, "Could not change soft controls for interface %s", i.Name)
+		}
+	}
+	if extendedStatusControl != raw[sff8472.ExtendedStatusControlOffset] {
+		if err := i.writeModuleEEPROM(sff8472.ExtendedStatusControlOffset, []byte{extendedStatusControl}); err != nil {
+			return errors.Wrapf(err, "Could not change soft controls for interface %s", i.Name)
+		}
+	}
+
+	if _, e, err = i.getSFF8472ModuleEEPROM(); err != nil {
+		return errors.Wrapf(err, "Could not re-read module EEPROM")
+	}
+	if control, err = e.GetSoftControl(); err != nil || *control != requested {
+		return fmt.Errorf("Soft control write for interface %s did not apply", i.Name)
+	}
+	return nil
+}
+
+// SetModuleSoftTxDisable disables (or enables) the transmitter of an SFF-8472 module through soft Tx disable
+func (i *Interface) SetModuleSoftTxDisable(disable bool) error {
+	return i.SetModuleSoftControl(func(c *sff8472.SoftControl) {
+		c.SoftTxDisable = disable
+	})
+}
+
+// SetModuleSoftRateSelect sets the soft rate select RS(0) (receiver) and RS(1) (transmitter) of an SFF-8472 module
+func (i *Interface) SetModuleSoftRateSelect(rs0 bool, rs1 bool) error {
+	return i.SetModuleSoftControl(func(c *sff8472.SoftControl) {
+		c.SoftRS0Select = rs0
+		c.SoftRS1Select = rs1
+	})
+}
+
+// SetModuleInputEqualization sets the Tx input equalization of an SFF-8472 module for high and low rate, requires a ModuleEEPROMWriter
+func (i *Interface) SetModuleInputEqualization(control sff8472.InputEqualizationControl) error {
+	value, err := control.Encode()
+	if err != nil {
+		return err
+	}
+	return i.setSFF8472EqualizationByte(sff8472.InputEqualizationControlOffset, value, "input equalization", (*sff8472.EEPROM).SupportsInputEqualizationControl)
+}
+
+// SetModuleOutputEmphasis sets the Rx output emphasis of an SFF-8472 module for high and low rate, requires a ModuleEEPROMWriter
+func (i *Interface) SetModuleOutputEmphasis(control sff8472.OutputEmphasisControl) error {
+	value, err := control.Encode()
+	if err != nil {
+		return err
+	}
+	return i.setSFF8472EqualizationByte(sff8472.OutputEmphasisControlOffset, value, "output emphasis", (*sff8472.EEPROM).SupportsOutputEmphasisControl)
+}
+
+// setSFF8472EqualizationByte writes an input equalization or output emphasis byte after checking the module supports it using supported
+func (i *Interface) setSFF8472EqualizationByte(offset uint32, value byte, name string, supported func(*sff8472.EEPROM) bool) error {
+	_, e, err := i.getSFF8472ModuleEEPROM()
+	if err != nil {
+		return err
+	}
+	if !supported(e) {
+		return fmt.Errorf("Module of interface %s does not implement %s control", i.Name, name)
+	}
+	if err := i.writeModuleEEPROM(offset, []byte{value}); err != nil {
+		return errors.Wrapf(err, "Could not set %s for interface %s", name, i.Name)
+	}
+	return i.verifyModuleEEPROM(offset, 0xFF, value)
+}
+
+// getSFF8472ModuleEEPROM reads and parses the module EEPROM, failing for anything but SFF-8472 modules.
+// The standard is detected from the EEPROM contents like on the read path, as drivers report SFF-8472 modules as SFF-8079.
+func (i *Interface) getSFF8472ModuleEEPROM() ([]byte, *sff8472.EEPROM, error) {
+	raw, reportedType, err := i.ReadModuleEEPROM()
+	if err != nil {
+		return nil, nil, err
+	}
+	eepromType := eeprom.DetectType(raw, reportedType)
+	if eepromType != eeprom.TypeSFF8472 {
+		return nil, nil, fmt.Errorf("Operation requires an SFF-8472 module, interface %s has a %s module", i.Name, eepromType)
+	}
+	e, err := sff8472.NewEEPROM(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	return raw, e, nil
+}
+
+// getSFF8636ModuleEEPROM reads and parses the module EEPROM, failing for anything but SFF-8636 / SFF-8436 modules.
+// The standard is detected from the EEPROM contents, as drivers report CMIS modules as SFF-8636.
+// The returned raw EEPROM is padded to the length required by sff8636.NewEEPROM.
+func (i *Interface) getSFF8636ModuleEEPROM() ([]byte, *sff8636.EEPROM, error) {
+	raw, reportedType, err := i.ReadModuleEEPROM()
+	if err != nil {
+		return nil, nil, err
+	}
+	eepromType := eeprom.DetectType(raw, reportedType)
+	if eepromType != eeprom.TypeSFF8636 && eepromType != eeprom.TypeSFF8436 {
+		return nil, nil, fmt.Errorf("Operation requires an SFF-8636 module, interface %s has a %s module", i.Name, eepromType)
+	}
+	raw = padSFF8636(raw)
+	e, err := sff8636.NewEEPROM(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+	return raw, e, nil
+}
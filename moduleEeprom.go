@@ -0,0 +1,160 @@
+package ethtool
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/wobcom/go-ethtool/eeprom"
+	"os"
+)
+
+const (
+	// Get a page of the plug-in module EEPROM
+	moduleEEPROMGetCommand = 0x1f
+)
+
+/* ETHTOOL_A_MODULE_EEPROM_* */
+const (
+	moduleEEPROMAttrHeader     = 0x01
+	moduleEEPROMAttrOffset     = 0x02
+	moduleEEPROMAttrLength     = 0x03
+	moduleEEPROMAttrPage       = 0x04
+	moduleEEPROMAttrBank       = 0x05
+	moduleEEPROMAttrI2CAddress = 0x06
+	moduleEEPROMAttrData       = 0x07
+
+	// I2C address of paged SFF-8636 / CMIS module memory
+	moduleEEPROMI2CAddress = 0x50
+	// Size of a lower or upper page
+	moduleEEPROMPageSize = 0x80
+)
+
+// ModuleEEPROMWriter writes to a plug-in module's EEPROM.
+// Offsets use the same linear layout as the EEPROM read through the kernel,
+// i.e. A2h follows A0h at 0x100 for SFF-8472 and upper page n starts at 0x80 + n * 0x80 for SFF-8636 and CMIS.
+// The kernel's ethtool API does not provide module EEPROM writes, so an implementation has to be supplied by the caller.
+type ModuleEEPROMWriter interface {
+	WriteModuleEEPROM(offset uint32, data []byte) error
+}
+
+// FileModuleEEPROMWriter writes to a file exposing the module EEPROM in the linear layout,
+// e.g. the eeprom file created by the optoe driver at /sys/bus/i2c/devices/<bus>-0050/eeprom
+type FileModuleEEPROMWriter struct {
+	Path string
+}
+
+// WriteModuleEEPROM implements the ModuleEEPROMWriter interface's WriteModuleEEPROM function
+func (f *FileModuleEEPROMWriter) WriteModuleEEPROM(offset uint32, data []byte) error {
+	file, err := os.OpenFile(f.Path, os.O_WRONLY, 0)
+	if err != nil {
+		return errors.Wrapf(err, "Could not open module EEPROM %s", f.Path)
+	}
+	defer file.Close()
+
+	if _, err := file.WriteAt(data, int64(offset)); err != nil {
+		return errors.Wrapf(err, "Could not write %d bytes at offset %#x to module EEPROM %s", len(data), offset, f.Path)
+	}
+	return nil
+}
+
+// ReadModuleEEPROM reads the raw module EEPROM and returns it together with the EEPROM type reported by the driver
+func (i *Interface) ReadModuleEEPROM() ([]byte, eeprom.Type, error) {
+	if i.readModuleEEPROM != nil {
+		return i.readModuleEEPROM()
+	}
+	ethtoolModInfo, err := i.getEEPROMModuleInfo()
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "Could not retrieve module info for interface %s", i.Name)
+	}
+	if ethtoolModInfo.Length == 0 {
+		return nil, 0, errors.New("EERPOM of length 0 reported")
+	}
+	ethtoolEeprom, err := i.getModuleEEPROM(ethtoolModInfo.Length)
+	if err != nil {
+		return nil, 0, err
+	}
+	data := make([]byte, ethtoolModInfo.Length)
+	copy(data, ethtoolEeprom.Data[:ethtoolModInfo.Length])
+	return data, eeprom.Type(ethtoolModInfo.EepromType), nil
+}
+
+// ReadModuleEEPROMPage reads length bytes at offset of the given page and bank of a paged module EEPROM (SFF-8636, CMIS).
+// Offsets 0x00-0x7F address the lower page, 0x80-0xFF the selected upper page. A read must not cross the two.
+func (i *Interface) ReadModuleEEPROMPage(page uint8, bank uint8, offset uint32, length uint32) ([]byte, error) {
+	attrs := &netlinkAttributeEncoder{}
+	i.encodeRequestHeader(attrs, moduleEEPROMAttrHeader, 0)
+	attrs.uint32(moduleEEPROMAttrOffset, offset)
+	attrs.uint32(moduleEEPROMAttrLength, length)
+	attrs.uint8(moduleEEPROMAttrPage, page)
+	attrs.uint8(moduleEEPROMAttrBank, bank)
+	attrs.uint8(moduleEEPROMAttrI2CAddress, moduleEEPROMI2CAddress)
+
+	replies, err := i.netlinkRequest(moduleEEPROMGetCommand, attrs)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Could not read page %#02x bank %d of module EEPROM for interface %s", page, bank, i.Name)
+	}
+	for _, reply := range replies {
+		if data, found := reply.Attributes.get(moduleEEPROMAttrData); found {
+			return data.Data, nil
+		}
+	}
+	return nil, fmt.Errorf("No data returned reading page %#02x of module EEPROM for interface %s", page, i.Name)
+}
+
+// readUpperPages extends a paged module EEPROM read through the ioctl interface by the given upper pages (bank 0),
+// using the linear layout upper page n at 0x80 + n * 0x80. Pages are read in order until the first one fails.
+func (i *Interface) readUpperPages(data []byte, pages []uint8) []byte {
+	raw := make([]byte, len(data))
+	copy(raw, data)
+	for _, page := range pages {
+		start := moduleEEPROMPageSize + int(page)*moduleEEPROMPageSize
+		end := start + moduleEEPROMPageSize
+		if end <= len(raw) {
+			continue
+		}
+		pageData, err := i.ReadModuleEEPROMPage(page, 0, moduleEEPROMPageSize, moduleEEPROMPageSize)
+		if err != nil || len(pageData) != moduleEEPROMPageSize {
+			break
+		}
+		if len(raw) < end {
+			raw = append(raw, make([]byte, end-len(raw))...)
+		}
+		copy(raw[start:end], pageData)
+	}
+	return raw
+}
+
+// writeModuleEEPROM writes data through the configured ModuleEEPROMWriter
+func (i *Interface) writeModuleEEPROM(offset uint32, data []byte) error {
+	if i.ModuleEEPROMWriter == nil {
+		return fmt.Errorf("No ModuleEEPROMWriter configured for interface %s", i.Name)
+	}
+	return i.ModuleEEPROMWriter.WriteModuleEEPROM(offset, data)
+}
+
+// updateModuleEEPROMByte performs a read-modify-write of a single module EEPROM byte,
+// modify receives the current value and returns the new one
+func (i *Interface) updateModuleEEPROMByte(offset uint32, modify func(byte) byte) error {
+	raw, _, err := i.ReadModuleEEPROM()
+	if err != nil {
+		return err
+	}
+	if int(offset) >= len(raw) {
+		return fmt.Errorf("Offset %#x exceeds module EEPROM of length %d", offset, len(raw))
+	}
+	return i.writeModuleEEPROM(offset, []byte{modify(raw[offset])})
+}
+
+// verifyModuleEEPROM re-reads the module EEPROM and checks that the bits selected by mask match the expected values
+func (i *Interface) verifyModuleEEPROM(offset uint32, mask byte, expected byte) error {
+	raw, _, err := i.ReadModuleEEPROM()
+	if err != nil {
+		return errors.Wrapf(err, "Could not re-read module EEPROM")
+	}
+	if int(offset) >= len(raw) {
+		return fmt.Errorf("Offset %#x exceeds module EEPROM of length %d", offset, len(raw))
+	}
+	if raw[offset]&mask != expected&mask {
+		return fmt.Errorf("Module EEPROM write to offset %#x did not apply, read back %#02x, expected %#02x (mask %#02x)", offset, raw[offset], expected, mask)
+	}
+	return nil
+}
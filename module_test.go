@@ -0,0 +1,221 @@
+package ethtool
+
+import (
+	"github.com/wobcom/go-ethtool/eeprom"
+	"github.com/wobcom/go-ethtool/eeprom/sff8472"
+	"github.com/wobcom/go-ethtool/eeprom/sff8636"
+	"testing"
+)
+
+// fakeModuleEEPROM module EEPROM in the linear layout, read and written in place of the kernel and a ModuleEEPROMWriter
+type fakeModuleEEPROM struct {
+	raw      []byte
+	reported eeprom.Type
+	writes   int
+}
+
+func (f *fakeModuleEEPROM) WriteModuleEEPROM(offset uint32, data []byte) error {
+	copy(f.raw[offset:], data)
+	f.writes++
+	return nil
+}
+
+func (f *fakeModuleEEPROM) read() ([]byte, eeprom.Type, error) {
+	return append([]byte{}, f.raw...), f.reported, nil
+}
+
+func (f *fakeModuleEEPROM) newInterface() *Interface {
+	return &Interface{
+		Name:               "swp42",
+		ModuleEEPROMWriter: f,
+		readModuleEEPROM:   f.read,
+	}
+}
+
+// getFakeQSFP28 returns the lower page and upper page 00h of a power class 5 QSFP28 module
+// implementing Tx disable and software reset, as reported by most drivers
+func getFakeQSFP28() *fakeModuleEEPROM {
+	raw := make([]byte, 256)
+	raw[0x00] = 0x11
+	// SFF-8636 rev 2.5 or later
+	raw[0x01] = 0x08
+	// power class 5-7 enabled
+	raw[sff8636.PowerControlOffset] = sff8636.PowerControlPowerClass5To7Enable
+	raw[0x80] = 0x11
+	// power class 5, power class 8 not implemented
+	raw[0x81] = 0xC1
+	// Tx disable implemented
+	raw[0xC3] = 0x10
+	// software reset implemented
+	raw[0xDD] = 0x01
+	return &fakeModuleEEPROM{raw: raw, reported: eeprom.TypeSFF8636}
+}
+
+func TestSetModuleLowPowerMode(t *testing.T) {
+	module := getFakeQSFP28()
+	iface := module.newInterface()
+
+	if err := iface.SetModuleLowPowerMode(true); err != nil {
+		t.Fatal(err)
+	}
+	expected := sff8636.PowerControlPowerClass5To7Enable | sff8636.PowerControlPowerOverride | sff8636.PowerControlLowPowerMode
+	if module.raw[sff8636.PowerControlOffset] != expected {
+		t.Errorf("Expected power control %#02x, got %#02x", expected, module.raw[sff8636.PowerControlOffset])
+	}
+
+	if err := iface.SetModuleLowPowerMode(false); err != nil {
+		t.Fatal(err)
+	}
+	expected = sff8636.PowerControlPowerClass5To7Enable | sff8636.PowerControlPowerOverride
+	if module.raw[sff8636.PowerControlOffset] != expected {
+		t.Errorf("Expected power control %#02x, got %#02x", expected, module.raw[sff8636.PowerControlOffset])
+	}
+}
+
+func TestResetModule(t *testing.T) {
+	module := getFakeQSFP28()
+	if err := module.newInterface().ResetModule(); err != nil {
+		t.Fatal(err)
+	}
+	if module.raw[sff8636.PowerControlOffset]&sff8636.PowerControlSoftwareReset == 0 {
+		t.Errorf("Software reset not requested, power control %#02x", module.raw[sff8636.PowerControlOffset])
+	}
+
+	module = getFakeQSFP28()
+	module.raw[0xDD] = 0x00
+	if err := module.newInterface().ResetModule(); err == nil || module.writes != 0 {
+		t.Errorf("Expected reset of module without software reset to fail without writes, got %v", err)
+	}
+}
+
+func TestSFF8636WritesRefuseCMISModules(t *testing.T) {
+	module := getFakeQSFP28()
+	// QSFP-DD reported as SFF-8636 by the driver
+	module.raw[0x00] = 0x18
+	iface := module.newInterface()
+
+	if err := iface.SetModuleLowPowerMode(true); err == nil {
+		t.Error("Expected SetModuleLowPowerMode to fail for a CMIS module")
+	}
+	if err := iface.ResetModule(); err == nil {
+		t.Error("Expected ResetModule to fail for a CMIS module")
+	}
+	if err := iface.SetModuleTxDisable(0, true); err == nil {
+		t.Error("Expected SetModuleTxDisable to fail for a CMIS module")
+	}
+	if module.writes != 0 {
+		t.Errorf("Expected no writes to a CMIS module, got %d", module.writes)
+	}
+}
+
+func TestSetModuleControl(t *testing.T) {
+	module := getFakeQSFP28()
+	iface := module.newInterface()
+
+	if err := iface.SetModuleTxDisable(2, true); err != nil {
+		t.Fatal(err)
+	}
+	if module.raw[sff8636.ControlOffset] != 0x04 {
+		t.Errorf("Expected Tx disable %#02x, got %#02x", 0x04, module.raw[sff8636.ControlOffset])
+	}
+	if module.raw[sff8636.PowerControlOffset] != sff8636.PowerControlPowerClass5To7Enable {
+		t.Errorf("Unexpected change of power control to %#02x", module.raw[sff8636.PowerControlOffset])
+	}
+
+	writes := module.writes
+	err := iface.SetModuleControl(func(c *sff8636.Control) {
+		c.PowerClass8Enable = true
+	})
+	if err == nil || module.writes != writes {
+		t.Errorf("Expected enabling power class 8 not implemented by the module to fail without writes, got %v", err)
+	}
+	err = iface.SetModuleControl(func(c *sff8636.Control) {
+		c.PowerClass5To7Enable = false
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if module.raw[sff8636.PowerControlOffset] != 0x00 {
+		t.Errorf("Expected power classes 5 to 7 disabled, got power control %#02x", module.raw[sff8636.PowerControlOffset])
+	}
+
+	module.raw[0x81] = 0x00
+	err = iface.SetModuleControl(func(c *sff8636.Control) {
+		c.PowerClass5To7Enable = true
+	})
+	if err == nil {
+		t.Error("Expected enabling power classes 5 to 7 of a power class 1 module to fail")
+	}
+}
+
+// getFakeSFP returns pages A0h and A2h of an internally calibrated SFP implementing diagnostics and soft Tx disable,
+// without SFF-8472 compliance revision and reported as SFF-8079 by the driver
+func getFakeSFP() *fakeModuleEEPROM {
+	raw := make([]byte, 512)
+	raw[0x00] = 0x03
+	// diagnostic monitoring implemented, internally calibrated
+	raw[0x5C] = 0x60
+	// soft Tx disable implemented
+	raw[0x5D] = 0x40
+	return &fakeModuleEEPROM{raw: raw, reported: eeprom.TypeSFF8079}
+}
+
+func TestSetModuleSoftTxDisable(t *testing.T) {
+	module := getFakeSFP()
+	if err := module.newInterface().SetModuleSoftTxDisable(true); err != nil {
+		t.Fatal(err)
+	}
+	if module.raw[sff8472.StatusControlOffset]&sff8472.StatusControlSoftTxDisable == 0 {
+		t.Errorf("Soft Tx disable not set, status control %#02x", module.raw[sff8472.StatusControlOffset])
+	}
+
+	// SFP without diagnostics, detected as SFF-8079
+	module = getFakeSFP()
+	module.raw[0x5C] = 0x00
+	if err := module.newInterface().SetModuleSoftTxDisable(true); err == nil || module.writes != 0 {
+		t.Errorf("Expected soft Tx disable of an SFF-8079 module to fail without writes, got %v", err)
+	}
+}
+
+func TestSetModuleEqualization(t *testing.T) {
+	input := sff8472.InputEqualizationControl{HighRate: sff8472.InputEqualization8dB, LowRate: sff8472.InputEqualization2dB}
+	output := sff8472.OutputEmphasisControl{HighRate: sff8472.OutputEmphasis3dB}
+
+	module := getFakeSFP()
+	// retimer or CDR present
+	module.raw[0x40] = 0x08
+	iface := module.newInterface()
+	if err := iface.SetModuleInputEqualization(input); err != nil {
+		t.Fatal(err)
+	}
+	if module.raw[sff8472.InputEqualizationControlOffset] != 0x82 {
+		t.Errorf("Expected input equalization %#02x, got %#02x", 0x82, module.raw[sff8472.InputEqualizationControlOffset])
+	}
+	if err := iface.SetModuleOutputEmphasis(output); err != nil {
+		t.Fatal(err)
+	}
+	if module.raw[sff8472.OutputEmphasisControlOffset] != 0x30 {
+		t.Errorf("Expected output emphasis %#02x, got %#02x", 0x30, module.raw[sff8472.OutputEmphasisControlOffset])
+	}
+
+	// linear receiver output, no output emphasis
+	module = getFakeSFP()
+	module.raw[0x40] = 0x09
+	iface = module.newInterface()
+	if err := iface.SetModuleOutputEmphasis(output); err == nil || module.writes != 0 {
+		t.Errorf("Expected output emphasis of a linear receiver output to fail without writes, got %v", err)
+	}
+	if err := iface.SetModuleInputEqualization(input); err != nil {
+		t.Errorf("Input equalization of a module with linear receiver output failed: %v", err)
+	}
+
+	// neither retimer nor CDR
+	module = getFakeSFP()
+	iface = module.newInterface()
+	if err := iface.SetModuleInputEqualization(input); err == nil || module.writes != 0 {
+		t.Errorf("Expected input equalization of a module without CDR to fail without writes, got %v", err)
+	}
+	if err := iface.SetModuleOutputEmphasis(output); err == nil || module.writes != 0 {
+		t.Errorf("Expected output emphasis of a module without CDR to fail without writes, got %v", err)
+	}
+}
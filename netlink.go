@@ -0,0 +1,459 @@
+package ethtool
+
+import (
+	"encoding/binary"
+	"fmt"
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+	"sync"
+	"time"
+)
+
+const (
+	// Name of the ethtool generic netlink family
+	ethtoolGenlName = "ethtool"
+	// Version of the ethtool generic netlink family
+	ethtoolGenlVersion = 1
+	// Name of the ethtool multicast group used for notifications
+	ethtoolMcgrpMonitorName = "monitor"
+
+	// Size of the generic netlink header following struct nlmsghdr
+	genlHeaderLength = 4
+	// Receive buffer size, large enough for any ethtool reply
+	netlinkReceiveBufferSize = 65536
+)
+
+/* Request header attributes (ETHTOOL_A_HEADER_*) */
+const (
+	headerAttrDevIndex = 0x01
+	headerAttrDevName  = 0x02
+	headerAttrFlags    = 0x03
+)
+
+/* Request header flags (ETHTOOL_FLAG_*) */
+const (
+	headerFlagCompactBitsets = 1 << 0
+	headerFlagOmitReply      = 1 << 1
+	headerFlagStats          = 1 << 2
+)
+
+// netlinkAttribute a single netlink attribute (struct nlattr and its payload)
+type netlinkAttribute struct {
+	Type uint16
+	Data []byte
+}
+
+// netlinkAttributes a list of netlink attributes in wire order, types may repeat
+type netlinkAttributes []netlinkAttribute
+
+func parseNetlinkAttributes(raw []byte) (netlinkAttributes, error) {
+	attributes := netlinkAttributes{}
+	for len(raw) >= unix.SizeofNlAttr {
+		length := int(binary.LittleEndian.Uint16(raw[0:2]))
+		attrType := binary.LittleEndian.Uint16(raw[2:4]) & ^uint16(unix.NLA_F_NESTED|unix.NLA_F_NET_BYTEORDER)
+		if length < unix.SizeofNlAttr || length > len(raw) {
+			return nil, fmt.Errorf("Malformed netlink attribute of type %d with length %d", attrType, length)
+		}
+		attributes = append(attributes, netlinkAttribute{
+			Type: attrType,
+			Data: raw[unix.SizeofNlAttr:length],
+		})
+		aligned := netlinkAlign(length)
+		if aligned > len(raw) {
+			break
+		}
+		raw = raw[aligned:]
+	}
+	return attributes, nil
+}
+
+func (a netlinkAttributes) get(attrType uint16) (netlinkAttribute, bool) {
+	for _, attr := range a {
+		if attr.Type == attrType {
+			return attr, true
+		}
+	}
+	return netlinkAttribute{}, false
+}
+
+func (a netlinkAttribute) uint8() uint8 {
+	if len(a.Data) < 1 {
+		return 0
+	}
+	return a.Data[0]
+}
+
+func (a netlinkAttribute) uint16() uint16 {
+	if len(a.Data) < 2 {
+		return 0
+	}
+	return binary.LittleEndian.Uint16(a.Data)
+}
+
+func (a netlinkAttribute) uint32() uint32 {
+	if len(a.Data) < 4 {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(a.Data)
+}
+
+func (a netlinkAttribute) uint64() uint64 {
+	if len(a.Data) < 8 {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(a.Data)
+}
+
+func (a netlinkAttribute) string() string {
+	for i, c := range a.Data {
+		if c == 0 {
+			return string(a.Data[:i])
+		}
+	}
+	return string(a.Data)
+}
+
+func (a netlinkAttribute) nested() (netlinkAttributes, error) {
+	return parseNetlinkAttributes(a.Data)
+}
+
+// netlinkAttributeEncoder builds a serialized list of netlink attributes
+type netlinkAttributeEncoder struct {
+	raw []byte
+}
+
+func (e *netlinkAttributeEncoder) put(attrType uint16, data []byte) {
+	header := make([]byte, unix.SizeofNlAttr)
+	binary.LittleEndian.PutUint16(header[0:2], uint16(unix.SizeofNlAttr+len(data)))
+	binary.LittleEndian.PutUint16(header[2:4], attrType)
+	e.raw = append(e.raw, header...)
+	e.raw = append(e.raw, data...)
+	for len(e.raw)%unix.NLA_ALIGNTO != 0 {
+		e.raw = append(e.raw, 0)
+	}
+}
+
+func (e *netlinkAttributeEncoder) uint8(attrType uint16, value uint8) {
+	e.put(attrType, []byte{value})
+}
+
+func (e *netlinkAttributeEncoder) uint16(attrType uint16, value uint16) {
+	data := make([]byte, 2)
+	binary.LittleEndian.PutUint16(data, value)
+	e.put(attrType, data)
+}
+
+func (e *netlinkAttributeEncoder) uint32(attrType uint16, value uint32) {
+	data := make([]byte, 4)
+	binary.LittleEndian.PutUint32(data, value)
+	e.put(attrType, data)
+}
+
+func (e *netlinkAttributeEncoder) string(attrType uint16, value string) {
+	e.put(attrType, append([]byte(value), 0))
+}
+
+func (e *netlinkAttributeEncoder) nested(attrType uint16, fn func(*netlinkAttributeEncoder)) {
+	nested := &netlinkAttributeEncoder{}
+	fn(nested)
+	e.put(attrType|unix.NLA_F_NESTED, nested.raw)
+}
+
+func (e *netlinkAttributeEncoder) bytes() []byte {
+	return e.raw
+}
+
+func netlinkAlign(length int) int {
+	return (length + unix.NLA_ALIGNTO - 1) & ^(unix.NLA_ALIGNTO - 1)
+}
+
+// genetlinkMessage a generic netlink message as received from the kernel
+type genetlinkMessage struct {
+	Command    uint8
+	Attributes netlinkAttributes
+}
+
+// netlinkSocket a generic netlink socket bound to the ethtool family
+type netlinkSocket struct {
+	fd          int
+	seq         uint32
+	familyID    uint16
+	mcastGroups map[string]uint32
+	mu          *sync.Mutex
+}
+
+// newNetlinkSocket opens a generic netlink socket and resolves the ethtool family
+func newNetlinkSocket() (*netlinkSocket, error) {
+	fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_RAW|unix.SOCK_CLOEXEC, unix.NETLINK_GENERIC)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Could not open generic netlink socket")
+	}
+	s := &netlinkSocket{
+		fd:          fd,
+		mcastGroups: make(map[string]uint32),
+		mu:          &sync.Mutex{},
+	}
+	if err := unix.Bind(fd, &unix.SockaddrNetlink{Family: unix.AF_NETLINK}); err != nil {
+		s.close()
+		return nil, errors.Wrapf(err, "Could not bind generic netlink socket")
+	}
+	// extended acks are optional, older kernels just do not provide error messages
+	_ = unix.SetsockoptInt(fd, unix.SOL_NETLINK, unix.NETLINK_EXT_ACK, 1)
+
+	if err := s.resolveFamily(); err != nil {
+		s.close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *netlinkSocket) resolveFamily() error {
+	attrs := &netlinkAttributeEncoder{}
+	attrs.string(unix.CTRL_ATTR_FAMILY_NAME, ethtoolGenlName)
+
+	replies, err := s.execute(unix.GENL_ID_CTRL, unix.CTRL_CMD_GETFAMILY, 1, 0, attrs.bytes())
+	if err != nil {
+		return errors.Wrapf(err, "Could not resolve generic netlink family %s, kernel may lack ethtool netlink support", ethtoolGenlName)
+	}
+	for _, reply := range replies {
+		if familyID, found := reply.Attributes.get(unix.CTRL_ATTR_FAMILY_ID); found {
+			s.familyID = familyID.uint16()
+		}
+		groups, found := reply.Attributes.get(unix.CTRL_ATTR_MCAST_GROUPS)
+		if !found {
+			continue
+		}
+		groupList, err := groups.nested()
+		if err != nil {
+			return err
+		}
+		for _, group := range groupList {
+			groupAttrs, err := group.nested()
+			if err != nil {
+				return err
+			}
+			name, nameFound := groupAttrs.get(unix.CTRL_ATTR_MCAST_GRP_NAME)
+			id, idFound := groupAttrs.get(unix.CTRL_ATTR_MCAST_GRP_ID)
+			if nameFound && idFound {
+				s.mcastGroups[name.string()] = id.uint32()
+			}
+		}
+	}
+	if s.familyID == 0 {
+		return fmt.Errorf("Generic netlink family %s not found", ethtoolGenlName)
+	}
+	return nil
+}
+
+// joinGroup subscribes the socket to the ethtool multicast group of the given name
+func (s *netlinkSocket) joinGroup(name string) error {
+	id, found := s.mcastGroups[name]
+	if !found {
+		return fmt.Errorf("Multicast group %s not provided by generic netlink family %s", name, ethtoolGenlName)
+	}
+	if err := unix.SetsockoptInt(s.fd, unix.SOL_NETLINK, unix.NETLINK_ADD_MEMBERSHIP, int(id)); err != nil {
+		return errors.Wrapf(err, "Could not join multicast group %s", name)
+	}
+	return nil
+}
+
+// setReceiveTimeout limits how long receive blocks, zero disables the timeout
+func (s *netlinkSocket) setReceiveTimeout(timeout time.Duration) error {
+	tv := unix.NsecToTimeval(timeout.Nanoseconds())
+	return unix.SetsockoptTimeval(s.fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &tv)
+}
+
+// request sends an ethtool netlink request and returns all replies
+func (s *netlinkSocket) request(command uint8, flags uint16, attrs []byte) ([]genetlinkMessage, error) {
+	return s.execute(s.familyID, command, ethtoolGenlVersion, flags, attrs)
+}
+
+func (s *netlinkSocket) execute(family uint16, command uint8, version uint8, flags uint16, attrs []byte) ([]genetlinkMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seq++
+	seq := s.seq
+
+	msg := make([]byte, unix.NLMSG_HDRLEN+genlHeaderLength, unix.NLMSG_HDRLEN+genlHeaderLength+len(attrs))
+	binary.LittleEndian.PutUint32(msg[0:4], uint32(unix.NLMSG_HDRLEN+genlHeaderLength+len(attrs)))
+	binary.LittleEndian.PutUint16(msg[4:6], family)
+	binary.LittleEndian.PutUint16(msg[6:8], unix.NLM_F_REQUEST|unix.NLM_F_ACK|flags)
+	binary.LittleEndian.PutUint32(msg[8:12], seq)
+	msg[unix.NLMSG_HDRLEN] = command
+	msg[unix.NLMSG_HDRLEN+1] = version
+	msg = append(msg, attrs...)
+
+	if err := unix.Sendto(s.fd, msg, 0, &unix.SockaddrNetlink{Family: unix.AF_NETLINK}); err != nil {
+		return nil, errors.Wrapf(err, "Could not send netlink message")
+	}
+
+	replies := []genetlinkMessage{}
+	for {
+		done, messages, err := s.receiveReplies(seq)
+		if err != nil {
+			return nil, err
+		}
+		replies = append(replies, messages...)
+		if done {
+			return replies, nil
+		}
+	}
+}
+
+// receiveReplies reads one datagram and returns the messages belonging to seq, done is set once the request was acknowledged
+func (s *netlinkSocket) receiveReplies(seq uint32) (bool, []genetlinkMessage, error) {
+	buf := make([]byte, netlinkReceiveBufferSize)
+	n, _, err := unix.Recvfrom(s.fd, buf, 0)
+	if err != nil {
+		return false, nil, errors.Wrapf(err, "Could not receive netlink message")
+	}
+
+	messages := []genetlinkMessage{}
+	raw := buf[:n]
+	for len(raw) >= unix.NLMSG_HDRLEN {
+		length := int(binary.LittleEndian.Uint32(raw[0:4]))
+		msgType := binary.LittleEndian.Uint16(raw[4:6])
+		msgFlags := binary.LittleEndian.Uint16(raw[6:8])
+		msgSeq := binary.LittleEndian.Uint32(raw[8:12])
+		if length < unix.NLMSG_HDRLEN || length > len(raw) {
+			return false, nil, fmt.Errorf("Malformed netlink message of length %d", length)
+		}
+		payload := raw[unix.NLMSG_HDRLEN:length]
+		raw = raw[netlinkAlign(length):]
+
+		if msgSeq != seq {
+			// not a reply to our request, e.g. a notification
+			continue
+		}
+
+		switch msgType {
+		case unix.NLMSG_DONE:
+			return true, messages, nil
+		case unix.NLMSG_ERROR:
+			if err := parseNetlinkError(payload, msgFlags); err != nil {
+				return false, nil, err
+			}
+			return true, messages, nil
+		default:
+			message, err := parseGenetlinkMessage(payload)
+			if err != nil {
+				return false, nil, err
+			}
+			messages = append(messages, message)
+		}
+	}
+	return false, messages, nil
+}
+
+// receive reads one datagram and returns all contained ethtool messages regardless of their sequence number
+func (s *netlinkSocket) receive() ([]genetlinkMessage, error) {
+	buf := make([]byte, netlinkReceiveBufferSize)
+	n, _, err := unix.Recvfrom(s.fd, buf, 0)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Could not receive netlink message")
+	}
+
+	messages := []genetlinkMessage{}
+	raw := buf[:n]
+	for len(raw) >= unix.NLMSG_HDRLEN {
+		length := int(binary.LittleEndian.Uint32(raw[0:4]))
+		msgType := binary.LittleEndian.Uint16(raw[4:6])
+		if length < unix.NLMSG_HDRLEN || length > len(raw) {
+			return nil, fmt.Errorf("Malformed netlink message of length %d", length)
+		}
+		payload := raw[unix.NLMSG_HDRLEN:length]
+		raw = raw[netlinkAlign(length):]
+
+		if msgType != s.familyID {
+			continue
+		}
+		message, err := parseGenetlinkMessage(payload)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+func (s *netlinkSocket) close() {
+	unix.Close(s.fd)
+}
+
+func parseGenetlinkMessage(payload []byte) (genetlinkMessage, error) {
+	if len(payload) < genlHeaderLength {
+		return genetlinkMessage{}, errors.New("Generic netlink message too short")
+	}
+	attrs, err := parseNetlinkAttributes(payload[genlHeaderLength:])
+	if err != nil {
+		return genetlinkMessage{}, err
+	}
+	return genetlinkMessage{
+		Command:    payload[0],
+		Attributes: attrs,
+	}, nil
+}
+
+// parseNetlinkError returns nil for an acknowledgement and an error including the extended ack message otherwise
+func parseNetlinkError(payload []byte, flags uint16) error {
+	if len(payload) < 4 {
+		return errors.New("Netlink error message too short")
+	}
+	errno := int32(binary.LittleEndian.Uint32(payload[0:4]))
+	if errno == 0 {
+		return nil
+	}
+	err := unix.Errno(-errno)
+
+	// struct nlmsgerr is followed by the offending request, which is truncated to its header if NLM_F_CAPPED is set
+	offset := 4 + unix.NLMSG_HDRLEN
+	if flags&unix.NLM_F_CAPPED == 0 && len(payload) >= offset {
+		offset = 4 + int(binary.LittleEndian.Uint32(payload[4:8]))
+	}
+	if flags&unix.NLM_F_ACK_TLVS == 0 || offset > len(payload) {
+		return err
+	}
+	attrs, parseErr := parseNetlinkAttributes(payload[netlinkAlign(offset):])
+	if parseErr != nil {
+		return err
+	}
+	if msg, found := attrs.get(unix.NLMSGERR_ATTR_MSG); found {
+		return errors.Wrap(err, msg.string())
+	}
+	return err
+}
+
+// getNetlink returns the lazily opened ethtool netlink socket
+func (e *Ethtool) getNetlink() (*netlinkSocket, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.netlink == nil {
+		netlink, err := newNetlinkSocket()
+		if err != nil {
+			return nil, err
+		}
+		e.netlink = netlink
+	}
+	return e.netlink, nil
+}
+
+// encodeRequestHeader adds the ETHTOOL_A_*_HEADER nest identifying the interface
+func (i *Interface) encodeRequestHeader(attrs *netlinkAttributeEncoder, headerType uint16, flags uint32) {
+	attrs.nested(headerType, func(header *netlinkAttributeEncoder) {
+		header.string(headerAttrDevName, i.Name)
+		if flags != 0 {
+			header.uint32(headerAttrFlags, flags)
+		}
+	})
+}
+
+// netlinkRequest sends a request for this interface to the ethtool netlink family
+func (i *Interface) netlinkRequest(command uint8, attrs *netlinkAttributeEncoder) ([]genetlinkMessage, error) {
+	netlink, err := i.ethtool.getNetlink()
+	if err != nil {
+		return nil, err
+	}
+	return netlink.request(command, 0, attrs.bytes())
+}
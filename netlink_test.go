@@ -0,0 +1,185 @@
+package ethtool
+
+import (
+	"testing"
+)
+
+func TestNetlinkAttributesRoundtrip(t *testing.T) {
+	attrs := &netlinkAttributeEncoder{}
+	attrs.uint8(1, 0x42)
+	attrs.string(2, "swp42")
+	attrs.nested(3, func(nested *netlinkAttributeEncoder) {
+		nested.uint32(1, 0xDEADBEEF)
+		nested.uint16(2, 0xBEEF)
+	})
+
+	parsed, err := parseNetlinkAttributes(attrs.bytes())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(parsed) != 3 {
+		t.Fatalf("Expected 3 attributes, got %d", len(parsed))
+	}
+	if attr, _ := parsed.get(1); attr.uint8() != 0x42 {
+		t.Errorf("Expected 0x42, got %#x", attr.uint8())
+	}
+	if attr, _ := parsed.get(2); attr.string() != "swp42" {
+		t.Errorf("Expected swp42, got %s", attr.string())
+	}
+	attr, found := parsed.get(3)
+	if !found {
+		t.Fatal("Nested attribute not found")
+	}
+	nested, err := attr.nested()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if attr, _ := nested.get(1); attr.uint32() != 0xDEADBEEF {
+		t.Errorf("Expected 0xDEADBEEF, got %#x", attr.uint32())
+	}
+	if attr, _ := nested.get(2); attr.uint16() != 0xBEEF {
+		t.Errorf("Expected 0xBEEF, got %#x", attr.uint16())
+	}
+}
+
+func TestParseCableTestResult(t *testing.T) {
+	attrs := &netlinkAttributeEncoder{}
+	attrs.nested(cableNestAttrResult, func(result *netlinkAttributeEncoder) {
+		result.uint8(cableResultAttrPair, uint8(CablePairA))
+		result.uint8(cableResultAttrCode, uint8(CableTestResultOK))
+	})
+	attrs.nested(cableNestAttrResult, func(result *netlinkAttributeEncoder) {
+		result.uint8(cableResultAttrPair, uint8(CablePairB))
+		result.uint8(cableResultAttrCode, uint8(CableTestResultOpen))
+	})
+	attrs.nested(cableNestAttrFaultLength, func(length *netlinkAttributeEncoder) {
+		length.uint8(cableFaultLengthAttrPair, uint8(CablePairB))
+		length.uint32(cableFaultLengthAttrCm, 1234)
+	})
+	nest, err := parseNetlinkAttributes(attrs.bytes())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	result, err := newCableTestResult(nest)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(result.Pairs) != 2 {
+		t.Fatalf("Expected 2 pairs, got %d", len(result.Pairs))
+	}
+	if result.Pairs[0].Code != CableTestResultOK || result.Pairs[0].FaultLengthReported {
+		t.Errorf("Unexpected result for pair A: %+v", result.Pairs[0])
+	}
+	if result.Pairs[1].Code != CableTestResultOpen || result.Pairs[1].FaultLength != 1234 {
+		t.Errorf("Unexpected result for pair B: %+v", result.Pairs[1])
+	}
+}
+
+func TestParseCableTestTDRResult(t *testing.T) {
+	attrs := &netlinkAttributeEncoder{}
+	attrs.nested(cableTDRNestAttrPulse, func(pulse *netlinkAttributeEncoder) {
+		pulse.uint16(cablePulseAttrMv, 1000)
+	})
+	attrs.nested(cableTDRNestAttrStep, func(step *netlinkAttributeEncoder) {
+		step.uint32(cableStepAttrFirstDistance, 100)
+		step.uint32(cableStepAttrLastDistance, 300)
+		step.uint32(cableStepAttrStepDistance, 100)
+	})
+	for _, mv := range []int16{-20, 15, 400} {
+		mv := mv
+		attrs.nested(cableTDRNestAttrAmplitude, func(amplitude *netlinkAttributeEncoder) {
+			amplitude.uint8(cableAmplitudeAttrPair, uint8(CablePairC))
+			amplitude.uint16(cableAmplitudeAttrMv, uint16(mv))
+		})
+	}
+	nest, err := parseNetlinkAttributes(attrs.bytes())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	result, err := newCableTestTDRResult(nest)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if result.PulseMillivolts != 1000 {
+		t.Errorf("Expected pulse of 1000 mV, got %d", result.PulseMillivolts)
+	}
+	if len(result.Samples) != 3 {
+		t.Fatalf("Expected 3 samples, got %d", len(result.Samples))
+	}
+	last := result.Samples[2]
+	if last.Pair != CablePairC || last.Distance != 300 || last.Millivolts != 400 {
+		t.Errorf("Unexpected sample %+v", last)
+	}
+	if result.Samples[0].Millivolts != -20 {
+		t.Errorf("Expected -20 mV, got %d", result.Samples[0].Millivolts)
+	}
+}
+
+func TestParseStandardStatsGroup(t *testing.T) {
+	attrs := &netlinkAttributeEncoder{}
+	attrs.uint32(statsGroupAttrID, uint32(StandardStatsGroupRMON))
+	attrs.nested(statsGroupAttrStat, func(stat *netlinkAttributeEncoder) {
+		stat.put(2, []byte{0x2A, 0, 0, 0, 0, 0, 0, 0})
+	})
+	attrs.nested(statsGroupAttrHistogramRx, func(bucket *netlinkAttributeEncoder) {
+		bucket.uint32(statsGroupAttrHistogramBucketLo, 65)
+		bucket.uint32(statsGroupAttrHistogramBucketHi, 127)
+		bucket.put(statsGroupAttrHistogramValue, []byte{0x07, 0, 0, 0, 0, 0, 0, 0})
+	})
+	group, err := parseNetlinkAttributes(attrs.bytes())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	stats := &StandardStats{}
+	if err := stats.addGroup(group); err != nil {
+		t.Fatal(err.Error())
+	}
+	if stats.RMON == nil {
+		t.Fatal("Expected RMON statistics")
+	}
+	if stats.RMON.Counters["etherStatsFragments"] != 42 {
+		t.Errorf("Expected 42 fragments, got %d", stats.RMON.Counters["etherStatsFragments"])
+	}
+	if len(stats.RMON.RxHistogram) != 1 || stats.RMON.RxHistogram[0] != (HistogramBucket{Low: 65, High: 127, Value: 7}) {
+		t.Errorf("Unexpected rx histogram %+v", stats.RMON.RxHistogram)
+	}
+	if len(stats.RMON.TxHistogram) != 0 {
+		t.Errorf("Expected empty tx histogram, got %+v", stats.RMON.TxHistogram)
+	}
+}
+
+func TestParsePSEStatus(t *testing.T) {
+	attrs := &netlinkAttributeEncoder{}
+	attrs.uint32(pseAttrC33AdminState, uint32(PSEAdminStateEnabled))
+	attrs.uint32(pseAttrC33PowerDetectStatus, uint32(C33PSEPowerDetectionDelivering))
+	attrs.uint32(pseAttrC33PowerClass, 4)
+	attrs.uint32(pseAttrC33ActualPower, 12500)
+	attrs.nested(pseAttrC33PowerLimitRanges, func(limitRange *netlinkAttributeEncoder) {
+		limitRange.uint32(pseLimitRangeAttrMin, 15000)
+		limitRange.uint32(pseLimitRangeAttrMax, 30000)
+	})
+	parsed, err := parseNetlinkAttributes(attrs.bytes())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	status := &PSEStatus{}
+	if err := status.parse(parsed); err != nil {
+		t.Fatal(err.Error())
+	}
+	if status.C33AdminState != PSEAdminStateEnabled || status.C33PowerDetectionStatus != C33PSEPowerDetectionDelivering {
+		t.Errorf("Unexpected PSE state %+v", status)
+	}
+	if status.C33PowerClass != 4 || status.C33ActualPower != 12500 {
+		t.Errorf("Unexpected PSE power %+v", status)
+	}
+	if len(status.C33PowerLimitRanges) != 1 || status.C33PowerLimitRanges[0] != (PSEPowerLimitRange{Min: 15000, Max: 30000}) {
+		t.Errorf("Unexpected PSE power limit ranges %+v", status.C33PowerLimitRanges)
+	}
+	if status.PoDLAdminState.String() != "Not reported" {
+		t.Errorf("Expected PoDL admin state not to be reported, got %s", status.PoDLAdminState)
+	}
+}
@@ -0,0 +1,145 @@
+package ethtool
+
+import (
+	"github.com/pkg/errors"
+)
+
+const (
+	// Get PLCA (physical layer collision avoidance) configuration
+	plcaGetConfigCommand = 0x27
+	// Set PLCA configuration
+	plcaSetConfigCommand = 0x28
+	// Get PLCA status
+	plcaGetStatusCommand = 0x29
+)
+
+/* ETHTOOL_A_PLCA_* */
+const (
+	plcaAttrHeader     = 0x01
+	plcaAttrVersion    = 0x02
+	plcaAttrEnabled    = 0x03
+	plcaAttrStatus     = 0x04
+	plcaAttrNodeCount  = 0x05
+	plcaAttrNodeID     = 0x06
+	plcaAttrTOTimer    = 0x07
+	plcaAttrBurstCount = 0x08
+	plcaAttrBurstTimer = 0x09
+)
+
+// PLCAConfig PLCA configuration of a 10BASE-T1S PHY (IEEE 802.3cg).
+// When retrieved, nil fields are not supported by the PHY. When set, nil fields are left unchanged.
+type PLCAConfig struct {
+	// Version of the Open Alliance PLCA management interface, only reported
+	Version *uint16
+	Enabled *bool
+	// Number of PLCA nodes on the link, only relevant for the coordinator (node ID 0)
+	NodeCount *uint32
+	NodeID    *uint32
+	// Transmit opportunity timer in bit times
+	TransmitOpportunityTimer *uint32
+	// Number of additional frames a node may send in a single transmit opportunity
+	BurstCount *uint32
+	// Time in bit times to wait for the MAC to send another frame before yielding the transmit opportunity
+	BurstTimer *uint32
+}
+
+// PLCAStatus PLCA status of a 10BASE-T1S PHY
+type PLCAStatus struct {
+	// Whether the PLCA reconciliation sublayer is active, i.e. beacons are received
+	Up bool
+}
+
+// GetPLCAConfig retrieves the PLCA configuration of the interface's PHY
+func (i *Interface) GetPLCAConfig() (*PLCAConfig, error) {
+	attrs := &netlinkAttributeEncoder{}
+	i.encodeRequestHeader(attrs, plcaAttrHeader, 0)
+
+	replies, err := i.netlinkRequest(plcaGetConfigCommand, attrs)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Could not retrieve PLCA configuration for interface %s", i.Name)
+	}
+
+	config := &PLCAConfig{}
+	for _, reply := range replies {
+		config.parse(reply.Attributes)
+	}
+	return config, nil
+}
+
+func (p *PLCAConfig) parse(attrs netlinkAttributes) {
+	uint32Pointer := func(attr netlinkAttribute) *uint32 {
+		value := attr.uint32()
+		return &value
+	}
+	for _, attr := range attrs {
+		switch attr.Type {
+		case plcaAttrVersion:
+			version := attr.uint16()
+			p.Version = &version
+		case plcaAttrEnabled:
+			enabled := attr.uint8() != 0
+			p.Enabled = &enabled
+		case plcaAttrNodeCount:
+			p.NodeCount = uint32Pointer(attr)
+		case plcaAttrNodeID:
+			p.NodeID = uint32Pointer(attr)
+		case plcaAttrTOTimer:
+			p.TransmitOpportunityTimer = uint32Pointer(attr)
+		case plcaAttrBurstCount:
+			p.BurstCount = uint32Pointer(attr)
+		case plcaAttrBurstTimer:
+			p.BurstTimer = uint32Pointer(attr)
+		}
+	}
+}
+
+// SetPLCAConfig sets the given PLCA parameters of the interface's PHY, Version is ignored
+func (i *Interface) SetPLCAConfig(config *PLCAConfig) error {
+	attrs := &netlinkAttributeEncoder{}
+	i.encodeRequestHeader(attrs, plcaAttrHeader, 0)
+	if config.Enabled != nil {
+		enabled := uint8(0)
+		if *config.Enabled {
+			enabled = 1
+		}
+		attrs.uint8(plcaAttrEnabled, enabled)
+	}
+	for _, param := range []struct {
+		attrType uint16
+		value    *uint32
+	}{
+		{plcaAttrNodeCount, config.NodeCount},
+		{plcaAttrNodeID, config.NodeID},
+		{plcaAttrTOTimer, config.TransmitOpportunityTimer},
+		{plcaAttrBurstCount, config.BurstCount},
+		{plcaAttrBurstTimer, config.BurstTimer},
+	} {
+		if param.value != nil {
+			attrs.uint32(param.attrType, *param.value)
+		}
+	}
+
+	if _, err := i.netlinkRequest(plcaSetConfigCommand, attrs); err != nil {
+		return errors.Wrapf(err, "Could not set PLCA configuration for interface %s", i.Name)
+	}
+	return nil
+}
+
+// GetPLCAStatus retrieves the PLCA status of the interface's PHY
+func (i *Interface) GetPLCAStatus() (*PLCAStatus, error) {
+	attrs := &netlinkAttributeEncoder{}
+	i.encodeRequestHeader(attrs, plcaAttrHeader, 0)
+
+	replies, err := i.netlinkRequest(plcaGetStatusCommand, attrs)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Could not retrieve PLCA status for interface %s", i.Name)
+	}
+
+	status := &PLCAStatus{}
+	for _, reply := range replies {
+		if attr, found := reply.Attributes.get(plcaAttrStatus); found {
+			status.Up = attr.uint8() != 0
+		}
+	}
+	return status, nil
+}
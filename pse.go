@@ -0,0 +1,264 @@
+package ethtool
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Get PSE (power sourcing equipment) status
+	pseGetCommand = 0x24
+	// Set PSE parameters
+	pseSetCommand = 0x25
+)
+
+/* ETHTOOL_A_PSE_* */
+const (
+	pseAttrHeader                = 0x01
+	pseAttrPoDLAdminState        = 0x02
+	pseAttrPoDLAdminControl      = 0x03
+	pseAttrPoDLPowerDetectStatus = 0x04
+	pseAttrC33AdminState         = 0x05
+	pseAttrC33AdminControl       = 0x06
+	pseAttrC33PowerDetectStatus  = 0x07
+	pseAttrC33PowerClass         = 0x08
+	pseAttrC33ActualPower        = 0x09
+	pseAttrC33ExtendedState      = 0x0a
+	pseAttrC33ExtendedSubstate   = 0x0b
+	pseAttrC33PowerLimit         = 0x0c
+	pseAttrC33PowerLimitRanges   = 0x0d
+
+	pseLimitRangeAttrMin = 0x01
+	pseLimitRangeAttrMax = 0x02
+)
+
+// PSEAdminState administrative state of a PSE, shared by PoDL (IEEE 802.3 clause 104) and PoE (clause 33)
+type PSEAdminState uint32
+
+const (
+	// PSEAdminStateUnknown state is unknown
+	PSEAdminStateUnknown PSEAdminState = 0x01
+	// PSEAdminStateDisabled PSE functions are disabled
+	PSEAdminStateDisabled PSEAdminState = 0x02
+	// PSEAdminStateEnabled PSE functions are enabled
+	PSEAdminStateEnabled PSEAdminState = 0x03
+)
+
+func (p PSEAdminState) String() string {
+	str, found := map[PSEAdminState]string{
+		PSEAdminStateUnknown:  "unknown",
+		PSEAdminStateDisabled: "disabled",
+		PSEAdminStateEnabled:  "enabled",
+	}[p]
+	if found {
+		return str
+	}
+	return "Not reported"
+}
+
+// MarshalJSON implements the encoding/json/Marshaler interface's MarshalJSON function
+func (p PSEAdminState) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+// PoDLPSEPowerDetectionStatus power detection status of a PoDL PSE (IEEE 802.3-2018 30.15.1.1.3)
+type PoDLPSEPowerDetectionStatus uint32
+
+const (
+	// PoDLPSEPowerDetectionUnknown status is unknown
+	PoDLPSEPowerDetectionUnknown PoDLPSEPowerDetectionStatus = 0x01
+	// PoDLPSEPowerDetectionDisabled detection is disabled
+	PoDLPSEPowerDetectionDisabled PoDLPSEPowerDetectionStatus = 0x02
+	// PoDLPSEPowerDetectionSearching PSE is searching for a powered device
+	PoDLPSEPowerDetectionSearching PoDLPSEPowerDetectionStatus = 0x03
+	// PoDLPSEPowerDetectionDelivering PSE is delivering power
+	PoDLPSEPowerDetectionDelivering PoDLPSEPowerDetectionStatus = 0x04
+	// PoDLPSEPowerDetectionSleep PSE is in sleep state
+	PoDLPSEPowerDetectionSleep PoDLPSEPowerDetectionStatus = 0x05
+	// PoDLPSEPowerDetectionIdle PSE is idle
+	PoDLPSEPowerDetectionIdle PoDLPSEPowerDetectionStatus = 0x06
+	// PoDLPSEPowerDetectionError PSE is in an error state
+	PoDLPSEPowerDetectionError PoDLPSEPowerDetectionStatus = 0x07
+)
+
+func (p PoDLPSEPowerDetectionStatus) String() string {
+	str, found := map[PoDLPSEPowerDetectionStatus]string{
+		PoDLPSEPowerDetectionUnknown:    "unknown",
+		PoDLPSEPowerDetectionDisabled:   "disabled",
+		PoDLPSEPowerDetectionSearching:  "searching",
+		PoDLPSEPowerDetectionDelivering: "delivering power",
+		PoDLPSEPowerDetectionSleep:      "sleep",
+		PoDLPSEPowerDetectionIdle:       "idle",
+		PoDLPSEPowerDetectionError:      "error",
+	}[p]
+	if found {
+		return str
+	}
+	return "Not reported"
+}
+
+// MarshalJSON implements the encoding/json/Marshaler interface's MarshalJSON function
+func (p PoDLPSEPowerDetectionStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+// C33PSEPowerDetectionStatus power detection status of a PoE PSE (IEEE 802.3-2022 30.9.1.1.5)
+type C33PSEPowerDetectionStatus uint32
+
+const (
+	// C33PSEPowerDetectionUnknown status is unknown
+	C33PSEPowerDetectionUnknown C33PSEPowerDetectionStatus = 0x01
+	// C33PSEPowerDetectionDisabled detection is disabled
+	C33PSEPowerDetectionDisabled C33PSEPowerDetectionStatus = 0x02
+	// C33PSEPowerDetectionSearching PSE is searching for a powered device
+	C33PSEPowerDetectionSearching C33PSEPowerDetectionStatus = 0x03
+	// C33PSEPowerDetectionDelivering PSE is delivering power
+	C33PSEPowerDetectionDelivering C33PSEPowerDetectionStatus = 0x04
+	// C33PSEPowerDetectionTest PSE is in test mode
+	C33PSEPowerDetectionTest C33PSEPowerDetectionStatus = 0x05
+	// C33PSEPowerDetectionFault PSE detected a fault condition
+	C33PSEPowerDetectionFault C33PSEPowerDetectionStatus = 0x06
+	// C33PSEPowerDetectionOtherFault PSE detected an implementation specific fault condition
+	C33PSEPowerDetectionOtherFault C33PSEPowerDetectionStatus = 0x07
+)
+
+func (c C33PSEPowerDetectionStatus) String() string {
+	str, found := map[C33PSEPowerDetectionStatus]string{
+		C33PSEPowerDetectionUnknown:    "unknown",
+		C33PSEPowerDetectionDisabled:   "disabled",
+		C33PSEPowerDetectionSearching:  "searching",
+		C33PSEPowerDetectionDelivering: "delivering power",
+		C33PSEPowerDetectionTest:       "test",
+		C33PSEPowerDetectionFault:      "fault",
+		C33PSEPowerDetectionOtherFault: "other fault",
+	}[c]
+	if found {
+		return str
+	}
+	return "Not reported"
+}
+
+// MarshalJSON implements the encoding/json/Marshaler interface's MarshalJSON function
+func (c C33PSEPowerDetectionStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+// PSEPowerLimitRange a range of power limits (in milliwatts) supported by a PoE PSE
+type PSEPowerLimitRange struct {
+	Min uint32
+	Max uint32
+}
+
+// PSEStatus status of the interface's PSE, zero values mean not reported by the driver.
+// PoDL fields are set for PoDL (single pair) PSEs, C33 fields for PoE PSEs.
+type PSEStatus struct {
+	PoDLAdminState           PSEAdminState
+	PoDLPowerDetectionStatus PoDLPSEPowerDetectionStatus
+	C33AdminState            PSEAdminState
+	C33PowerDetectionStatus  C33PSEPowerDetectionStatus
+	// Power class of the attached powered device
+	C33PowerClass uint32
+	// Power drawn by the attached powered device in milliwatts
+	C33ActualPower uint32
+	// Raw extended state and substate, these further describe the reason of a fault
+	C33ExtendedState    uint32
+	C33ExtendedSubstate uint32
+	// Configured power limit in milliwatts
+	C33PowerLimit       uint32
+	C33PowerLimitRanges []PSEPowerLimitRange
+}
+
+// PSEConfig PSE parameters to set, nil fields are left unchanged
+type PSEConfig struct {
+	PoDLAdminControl *PSEAdminState
+	C33AdminControl  *PSEAdminState
+	// Power limit in milliwatts
+	C33PowerLimit *uint32
+}
+
+// GetPSEStatus retrieves the status of the interface's PSE (power sourcing equipment, PoE / PoDL)
+func (i *Interface) GetPSEStatus() (*PSEStatus, error) {
+	attrs := &netlinkAttributeEncoder{}
+	i.encodeRequestHeader(attrs, pseAttrHeader, 0)
+
+	replies, err := i.netlinkRequest(pseGetCommand, attrs)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Could not retrieve PSE status for interface %s", i.Name)
+	}
+
+	status := &PSEStatus{}
+	for _, reply := range replies {
+		if err := status.parse(reply.Attributes); err != nil {
+			return nil, err
+		}
+	}
+	return status, nil
+}
+
+func (p *PSEStatus) parse(attrs netlinkAttributes) error {
+	for _, attr := range attrs {
+		switch attr.Type {
+		case pseAttrPoDLAdminState:
+			p.PoDLAdminState = PSEAdminState(attr.uint32())
+		case pseAttrPoDLPowerDetectStatus:
+			p.PoDLPowerDetectionStatus = PoDLPSEPowerDetectionStatus(attr.uint32())
+		case pseAttrC33AdminState:
+			p.C33AdminState = PSEAdminState(attr.uint32())
+		case pseAttrC33PowerDetectStatus:
+			p.C33PowerDetectionStatus = C33PSEPowerDetectionStatus(attr.uint32())
+		case pseAttrC33PowerClass:
+			p.C33PowerClass = attr.uint32()
+		case pseAttrC33ActualPower:
+			p.C33ActualPower = attr.uint32()
+		case pseAttrC33ExtendedState:
+			p.C33ExtendedState = attr.uint32()
+		case pseAttrC33ExtendedSubstate:
+			p.C33ExtendedSubstate = attr.uint32()
+		case pseAttrC33PowerLimit:
+			p.C33PowerLimit = attr.uint32()
+		case pseAttrC33PowerLimitRanges:
+			rangeAttrs, err := attr.nested()
+			if err != nil {
+				return errors.Wrapf(err, "Could not parse PSE power limit range")
+			}
+			limitRange := PSEPowerLimitRange{}
+			if min, found := rangeAttrs.get(pseLimitRangeAttrMin); found {
+				limitRange.Min = min.uint32()
+			}
+			if max, found := rangeAttrs.get(pseLimitRangeAttrMax); found {
+				limitRange.Max = max.uint32()
+			}
+			p.C33PowerLimitRanges = append(p.C33PowerLimitRanges, limitRange)
+		}
+	}
+	return nil
+}
+
+// SetPSE sets the given parameters of the interface's PSE
+func (i *Interface) SetPSE(config *PSEConfig) error {
+	attrs := &netlinkAttributeEncoder{}
+	i.encodeRequestHeader(attrs, pseAttrHeader, 0)
+	if config.PoDLAdminControl != nil {
+		attrs.uint32(pseAttrPoDLAdminControl, uint32(*config.PoDLAdminControl))
+	}
+	if config.C33AdminControl != nil {
+		attrs.uint32(pseAttrC33AdminControl, uint32(*config.C33AdminControl))
+	}
+	if config.C33PowerLimit != nil {
+		attrs.uint32(pseAttrC33PowerLimit, *config.C33PowerLimit)
+	}
+
+	if _, err := i.netlinkRequest(pseSetCommand, attrs); err != nil {
+		return errors.Wrapf(err, "Could not set PSE parameters for interface %s", i.Name)
+	}
+	return nil
+}
+
+func (p *PSEStatus) String() string {
+	if p.PoDLAdminState != 0 {
+		return fmt.Sprintf("PoDL PSE %s, %s", p.PoDLAdminState, p.PoDLPowerDetectionStatus)
+	}
+	return fmt.Sprintf("PoE PSE %s, %s, class %d, %d mW of %d mW", p.C33AdminState, p.C33PowerDetectionStatus,
+		p.C33PowerClass, p.C33ActualPower, p.C33PowerLimit)
+}
@@ -0,0 +1,112 @@
+package ethtool
+
+import (
+	"github.com/pkg/errors"
+	"regexp"
+	"sort"
+	"strconv"
+	"unsafe"
+)
+
+const (
+	// Get driver statistics
+	getStatsIoctl = 0x0000001d
+)
+
+// ethtoolStats header of struct ethtool_stats, followed by nStats 64 bit counters
+type ethtoolStats struct {
+	cmd    uint32
+	nStats uint32
+}
+
+// QueueStats driver statistics of a single queue, counter names are stripped of their queue prefix
+type QueueStats struct {
+	Queue uint32
+	Rx    map[string]uint64
+	Tx    map[string]uint64
+}
+
+// Naming conventions drivers use for per queue counters, e.g. rx_queue_0_packets, tx-0.tx_packets and rx0_packets
+var queueStatsPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^(rx|tx)_queue_(\d+)_(.+)$`),
+	regexp.MustCompile(`^(rx|tx)-(\d+)\.(?:rx_|tx_)?(.+)$`),
+	regexp.MustCompile(`^(rx|tx)(\d+)_(.+)$`),
+}
+
+// GetStats retrieves the driver statistics as shown by ethtool -S
+func (i *Interface) GetStats() (map[string]uint64, error) {
+	names, err := i.GetStringSet(StringSetStats)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Could not retrieve statistics names for interface %s", i.Name)
+	}
+	if len(names) == 0 {
+		return map[string]uint64{}, nil
+	}
+
+	headerLength := int(unsafe.Sizeof(ethtoolStats{}))
+	buf := make([]uint64, (headerLength+len(names)*8)/8)
+	stats := (*ethtoolStats)(unsafe.Pointer(&buf[0]))
+	stats.cmd = getStatsIoctl
+	stats.nStats = uint32(len(names))
+
+	if err := i.performIoctl(uintptr(unsafe.Pointer(&buf[0]))); err != nil {
+		return nil, errors.Wrapf(err, "Error running ioctl getStatsIoctl")
+	}
+	if int(stats.nStats) != len(names) {
+		return nil, errors.Errorf("Number of statistics changed from %d to %d while being retrieved", len(names), stats.nStats)
+	}
+
+	ret := make(map[string]uint64)
+	for index, name := range names {
+		ret[name] = buf[headerLength/8+index]
+	}
+	return ret, nil
+}
+
+// GetQueueStats retrieves the driver statistics and groups the per queue counters by queue
+func (i *Interface) GetQueueStats() ([]QueueStats, error) {
+	stats, err := i.GetStats()
+	if err != nil {
+		return nil, err
+	}
+	return newQueueStats(stats), nil
+}
+
+// newQueueStats picks the per queue counters from the driver statistics, the result is sorted by queue
+func newQueueStats(stats map[string]uint64) []QueueStats {
+	queues := make(map[uint32]*QueueStats)
+	for name, value := range stats {
+		for _, pattern := range queueStatsPatterns {
+			match := pattern.FindStringSubmatch(name)
+			if match == nil {
+				continue
+			}
+			queue, err := strconv.ParseUint(match[2], 10, 32)
+			if err != nil {
+				break
+			}
+			q, found := queues[uint32(queue)]
+			if !found {
+				q = &QueueStats{
+					Queue: uint32(queue),
+					Rx:    make(map[string]uint64),
+					Tx:    make(map[string]uint64),
+				}
+				queues[uint32(queue)] = q
+			}
+			if match[1] == "rx" {
+				q.Rx[match[3]] = value
+			} else {
+				q.Tx[match[3]] = value
+			}
+			break
+		}
+	}
+
+	ret := make([]QueueStats, 0, len(queues))
+	for _, q := range queues {
+		ret = append(ret, *q)
+	}
+	sort.Slice(ret, func(a, b int) bool { return ret[a].Queue < ret[b].Queue })
+	return ret
+}
@@ -0,0 +1,28 @@
+package ethtool
+
+import (
+	"testing"
+)
+
+func TestNewQueueStats(t *testing.T) {
+	queues := newQueueStats(map[string]uint64{
+		"rx_packets":            100,
+		"rx_queue_1_packets":    10,
+		"tx_queue_1_bytes":      20,
+		"tx-0.tx_packets":       30,
+		"rx-0.rx_bytes":         40,
+		"rx12_csum_unnecessary": 50,
+	})
+	if len(queues) != 3 {
+		t.Fatalf("Expected 3 queues, got %d: %+v", len(queues), queues)
+	}
+	if queues[0].Queue != 0 || queues[0].Tx["packets"] != 30 || queues[0].Rx["bytes"] != 40 {
+		t.Errorf("Unexpected stats for queue 0: %+v", queues[0])
+	}
+	if queues[1].Queue != 1 || queues[1].Rx["packets"] != 10 || queues[1].Tx["bytes"] != 20 {
+		t.Errorf("Unexpected stats for queue 1: %+v", queues[1])
+	}
+	if queues[2].Queue != 12 || queues[2].Rx["csum_unnecessary"] != 50 {
+		t.Errorf("Unexpected stats for queue 12: %+v", queues[2])
+	}
+}
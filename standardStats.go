@@ -0,0 +1,297 @@
+package ethtool
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Get standardised statistics
+	statsGetCommand = 0x20
+)
+
+/* ETHTOOL_A_STATS_* */
+const (
+	statsAttrHeader = 0x02
+	statsAttrGroups = 0x03
+	statsAttrGroup  = 0x04
+	statsAttrSource = 0x05
+
+	statsGroupAttrID                = 0x02
+	statsGroupAttrStat              = 0x04
+	statsGroupAttrHistogramRx       = 0x05
+	statsGroupAttrHistogramTx       = 0x06
+	statsGroupAttrHistogramBucketLo = 0x07
+	statsGroupAttrHistogramBucketHi = 0x08
+	statsGroupAttrHistogramValue    = 0x09
+)
+
+/* ETHTOOL_A_BITSET_* */
+const (
+	bitsetAttrNoMask = 0x01
+	bitsetAttrSize   = 0x02
+	bitsetAttrValue  = 0x04
+)
+
+// StandardStatsGroup a group of standardised statistics
+type StandardStatsGroup uint32
+
+const (
+	// StandardStatsGroupPHY IEEE 802.3 PHY counters (30.3.2.1)
+	StandardStatsGroupPHY StandardStatsGroup = 0x00
+	// StandardStatsGroupMAC IEEE 802.3 MAC counters (30.3.1.1)
+	StandardStatsGroupMAC StandardStatsGroup = 0x01
+	// StandardStatsGroupCtrl IEEE 802.3 MAC control counters (30.3.3)
+	StandardStatsGroupCtrl StandardStatsGroup = 0x02
+	// StandardStatsGroupRMON RMON (RFC 2819) counters and packet size histograms
+	StandardStatsGroupRMON StandardStatsGroup = 0x03
+)
+
+var allStandardStatsGroups = []StandardStatsGroup{
+	StandardStatsGroupPHY, StandardStatsGroupMAC, StandardStatsGroupCtrl, StandardStatsGroupRMON}
+
+func (s StandardStatsGroup) String() string {
+	str, found := map[StandardStatsGroup]string{
+		StandardStatsGroupPHY:  "eth-phy",
+		StandardStatsGroupMAC:  "eth-mac",
+		StandardStatsGroupCtrl: "eth-ctrl",
+		StandardStatsGroupRMON: "rmon",
+	}[s]
+	if found {
+		return str
+	}
+	return fmt.Sprintf("Unknown (%d)", uint32(s))
+}
+
+// StandardStatsSource selects which MAC of a MAC merge capable interface the statistics are taken from
+type StandardStatsSource uint32
+
+const (
+	// StandardStatsSourceAggregate statistics of eMAC and pMAC combined
+	StandardStatsSourceAggregate StandardStatsSource = 0x00
+	// StandardStatsSourceEMAC statistics of the express MAC
+	StandardStatsSourceEMAC StandardStatsSource = 0x01
+	// StandardStatsSourcePMAC statistics of the preemptible MAC
+	StandardStatsSourcePMAC StandardStatsSource = 0x02
+)
+
+func (s StandardStatsSource) String() string {
+	str, found := map[StandardStatsSource]string{
+		StandardStatsSourceAggregate: "aggregate",
+		StandardStatsSourceEMAC:      "eMAC",
+		StandardStatsSourcePMAC:      "pMAC",
+	}[s]
+	if found {
+		return str
+	}
+	return fmt.Sprintf("Unknown (%d)", uint32(s))
+}
+
+// MarshalJSON implements the encoding/json/Marshaler interface's MarshalJSON function
+func (s StandardStatsSource) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// Counter names as used by the kernel, indexed by their attribute type within a group
+var (
+	standardStatsPHYNames = []string{
+		"SymbolErrorDuringCarrier",
+	}
+	standardStatsMACNames = []string{
+		"FramesTransmittedOK",
+		"SingleCollisionFrames",
+		"MultipleCollisionFrames",
+		"FramesReceivedOK",
+		"FrameCheckSequenceErrors",
+		"AlignmentErrors",
+		"OctetsTransmittedOK",
+		"FramesWithDeferredXmissions",
+		"LateCollisions",
+		"FramesAbortedDueToXSColls",
+		"FramesLostDueToIntMACXmitError",
+		"CarrierSenseErrors",
+		"OctetsReceivedOK",
+		"FramesLostDueToIntMACRcvError",
+		"MulticastFramesXmittedOK",
+		"BroadcastFramesXmittedOK",
+		"FramesWithExcessiveDeferral",
+		"MulticastFramesReceivedOK",
+		"BroadcastFramesReceivedOK",
+		"InRangeLengthErrors",
+		"OutOfRangeLengthField",
+		"FrameTooLongErrors",
+	}
+	standardStatsCtrlNames = []string{
+		"MACControlFramesTransmitted",
+		"MACControlFramesReceived",
+		"UnsupportedOpcodesReceived",
+	}
+	standardStatsRMONNames = []string{
+		"etherStatsUndersizePkts",
+		"etherStatsOversizePkts",
+		"etherStatsFragments",
+		"etherStatsJabbers",
+	}
+)
+
+// StandardStats standardised statistics, counters not provided by the driver are omitted from the maps
+type StandardStats struct {
+	Source StandardStatsSource
+	PHY    map[string]uint64
+	MAC    map[string]uint64
+	Ctrl   map[string]uint64
+	RMON   *RMONStats
+}
+
+// RMONStats RMON counters and packet size histograms
+type RMONStats struct {
+	Counters    map[string]uint64
+	RxHistogram []HistogramBucket
+	TxHistogram []HistogramBucket
+}
+
+// HistogramBucket number of packets with a size between Low and High bytes (inclusive)
+type HistogramBucket struct {
+	Low   uint32
+	High  uint32
+	Value uint64
+}
+
+// GetStandardStats retrieves the given groups of standardised statistics, all groups are retrieved if none are given
+func (i *Interface) GetStandardStats(groups ...StandardStatsGroup) (*StandardStats, error) {
+	return i.GetStandardStatsFromSource(StandardStatsSourceAggregate, groups...)
+}
+
+// GetStandardStatsFromSource retrieves the given groups of standardised statistics for the eMAC, pMAC or both.
+// All groups are retrieved if none are given.
+func (i *Interface) GetStandardStatsFromSource(source StandardStatsSource, groups ...StandardStatsGroup) (*StandardStats, error) {
+	if len(groups) == 0 {
+		groups = allStandardStatsGroups
+	}
+	mask := uint32(0)
+	size := uint32(0)
+	for _, group := range groups {
+		if group >= 32 {
+			return nil, fmt.Errorf("Invalid statistics group %d", uint32(group))
+		}
+		mask |= 1 << group
+		if uint32(group)+1 > size {
+			size = uint32(group) + 1
+		}
+	}
+
+	attrs := &netlinkAttributeEncoder{}
+	i.encodeRequestHeader(attrs, statsAttrHeader, 0)
+	attrs.nested(statsAttrGroups, func(bitset *netlinkAttributeEncoder) {
+		bitset.put(bitsetAttrNoMask, []byte{})
+		bitset.uint32(bitsetAttrSize, size)
+		bitset.uint32(bitsetAttrValue, mask)
+	})
+	// older kernels do not know the source attribute, so only send it if required
+	if source != StandardStatsSourceAggregate {
+		attrs.uint32(statsAttrSource, uint32(source))
+	}
+
+	replies, err := i.netlinkRequest(statsGetCommand, attrs)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Could not retrieve standard statistics for interface %s", i.Name)
+	}
+
+	stats := &StandardStats{
+		Source: source,
+	}
+	for _, reply := range replies {
+		for _, attr := range reply.Attributes {
+			if attr.Type != statsAttrGroup {
+				continue
+			}
+			groupAttrs, err := attr.nested()
+			if err != nil {
+				return nil, errors.Wrapf(err, "Could not parse standard statistics")
+			}
+			if err := stats.addGroup(groupAttrs); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return stats, nil
+}
+
+func (s *StandardStats) addGroup(attrs netlinkAttributes) error {
+	id, found := attrs.get(statsGroupAttrID)
+	if !found {
+		return errors.New("Statistics group without ID")
+	}
+
+	switch StandardStatsGroup(id.uint32()) {
+	case StandardStatsGroupPHY:
+		s.PHY = parseStatsCounters(attrs, standardStatsPHYNames)
+	case StandardStatsGroupMAC:
+		s.MAC = parseStatsCounters(attrs, standardStatsMACNames)
+	case StandardStatsGroupCtrl:
+		s.Ctrl = parseStatsCounters(attrs, standardStatsCtrlNames)
+	case StandardStatsGroupRMON:
+		rxHistogram, err := parseStatsHistogram(attrs, statsGroupAttrHistogramRx)
+		if err != nil {
+			return err
+		}
+		txHistogram, err := parseStatsHistogram(attrs, statsGroupAttrHistogramTx)
+		if err != nil {
+			return err
+		}
+		s.RMON = &RMONStats{
+			Counters:    parseStatsCounters(attrs, standardStatsRMONNames),
+			RxHistogram: rxHistogram,
+			TxHistogram: txHistogram,
+		}
+	}
+	return nil
+}
+
+// parseStatsCounters each counter is wrapped in its own nest, with the counter's index as attribute type
+func parseStatsCounters(attrs netlinkAttributes, names []string) map[string]uint64 {
+	counters := make(map[string]uint64)
+	for _, attr := range attrs {
+		if attr.Type != statsGroupAttrStat {
+			continue
+		}
+		stats, err := attr.nested()
+		if err != nil {
+			continue
+		}
+		for _, stat := range stats {
+			name := fmt.Sprintf("unknown-%d", stat.Type)
+			if int(stat.Type) < len(names) {
+				name = names[stat.Type]
+			}
+			counters[name] = stat.uint64()
+		}
+	}
+	return counters
+}
+
+func parseStatsHistogram(attrs netlinkAttributes, histogramType uint16) ([]HistogramBucket, error) {
+	buckets := []HistogramBucket{}
+	for _, attr := range attrs {
+		if attr.Type != histogramType {
+			continue
+		}
+		bucketAttrs, err := attr.nested()
+		if err != nil {
+			return nil, errors.Wrapf(err, "Could not parse histogram bucket")
+		}
+		bucket := HistogramBucket{}
+		if low, found := bucketAttrs.get(statsGroupAttrHistogramBucketLo); found {
+			bucket.Low = low.uint32()
+		}
+		if high, found := bucketAttrs.get(statsGroupAttrHistogramBucketHi); found {
+			bucket.High = high.uint32()
+		}
+		if value, found := bucketAttrs.get(statsGroupAttrHistogramValue); found {
+			bucket.Value = value.uint64()
+		}
+		buckets = append(buckets, bucket)
+	}
+	return buckets, nil
+}
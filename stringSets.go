@@ -57,11 +57,11 @@ var existingStringSets = []StringSet{
 	StringSetRssHashFuncs, StringSetTunables, StringSetPhyStats, StringSetPhyTunables, StringSetLinkModes,
 	StringSetMsgClasses, StringSetWolModes, StringSetSofTimestamping, StringSetTimestampTxTypes, StringSetTimestampRxFilters}
 
+// ethtoolGStrings header of struct ethtool_gstrings, followed by length strings of maxStringLength bytes each
 type ethtoolGStrings struct {
 	cmd       uint32
 	stringSet uint32
 	length    uint32
-	data      [maxNumStrings * maxStringLength]byte
 }
 
 type ethtoolSsetInfo struct {
@@ -82,19 +82,22 @@ func (i *Interface) GetStringSet(set StringSet) ([]string, error) {
 		return []string{}, nil
 	}
 
-	gStrings := ethtoolGStrings{
-		cmd:       getStringSet,
-		stringSet: uint32(set),
-		length:    length,
-		data:      [maxNumStrings * maxStringLength]byte{},
-	}
+	headerLength := int(unsafe.Sizeof(ethtoolGStrings{}))
+	buf := make([]byte, headerLength+int(length)*maxStringLength)
+	gStrings := (*ethtoolGStrings)(unsafe.Pointer(&buf[0]))
+	gStrings.cmd = getStringSet
+	gStrings.stringSet = uint32(set)
+	gStrings.length = length
 
-	if err := i.performIoctl(uintptr(unsafe.Pointer(&gStrings))); err != nil {
+	if err := i.performIoctl(uintptr(unsafe.Pointer(&buf[0]))); err != nil {
 		return nil, errors.Wrapf(err, "Error performing ioctl getStringSet: %v", err)
 	}
+	if gStrings.length > length {
+		return nil, errors.Errorf("String set grew from %d to %d entries while being retrieved", length, gStrings.length)
+	}
 	ret := make([]string, int(gStrings.length))
 	for i := 0; i < int(gStrings.length); i++ {
-		b := gStrings.data[i*maxStringLength : (i+1)*maxStringLength]
+		b := buf[headerLength+i*maxStringLength : headerLength+(i+1)*maxStringLength]
 		ret[i] = string(bytes.Trim(b, "\x00"))
 	}
 	return ret, nil